@@ -3,16 +3,46 @@ package flatfile
 import (
 	"reflect"
 	"strconv"
-	"unicode/utf8"
+	"time"
 	"unsafe"
 
 	"github.com/pkg/errors"
 )
 
+//timeType is the type of time.Time fields, which are parsed using the format tag option
+var timeType = reflect.TypeOf(time.Time{})
+
+//decoder holds the options controlling how field data is converted while unmarshalling
+type decoder struct {
+	//aliasStrings makes string fields refer to the unmarshalled data instead of copying it
+	aliasStrings bool
+	//lenient decodes every field of a record, collecting the fields which fail to decode instead of stopping at the first
+	lenient bool
+	//reflection decodes types with generated methods by reflection too, so that tests can compare the two
+	reflection bool
+	//numericPolicy is the set of classes of numeric text which decode when numericPolicySet is true, see WithNumericPolicy
+	numericPolicy    NumericClass
+	numericPolicySet bool
+}
+
 //assignBasedOnKind performs assignment of fieldData to field based on kind
-func assignBasedOnKind(kind reflect.Kind, field reflect.Value, fieldData []byte, ffpTag *flatfileTag) error {
+func (d *decoder) assignBasedOnKind(kind reflect.Kind, field reflect.Value, fieldData []byte, ffpTag *flatfileTag) error {
 	var err error
 	err = nil
+	if len(fieldData) < ffpTag.length && !decodesCutShort(kind, field, ffpTag) {
+		return errors.Wrapf(ErrCutShort, "flatfile.assignBasedOnKind: %d of %d bytes", len(fieldData), ffpTag.length)
+	}
+	if ffpTag.numeric() {
+		switch kind {
+		case reflect.Struct, reflect.Ptr, reflect.Array, reflect.Slice:
+			//the encoding and decimals options apply to the elements
+		default:
+			return errors.Wrap(assignNumber(kind, field, fieldData, ffpTag), "flatfile.assignBasedOnKind: AssignmentError")
+		}
+	}
+	if d.numericPolicySet && numericKind(kind) && ffpTag.override == "" {
+		return errors.Wrap(d.assignPolicyNumber(kind, field, fieldData, ffpTag), "flatfile.assignBasedOnKind: AssignmentError")
+	}
 	switch kind {
 	case reflect.Bool:
 		err = assignBool(kind, field, fieldData)
@@ -52,161 +82,248 @@ func assignBasedOnKind(kind reflect.Kind, field reflect.Value, fieldData []byte,
 	case reflect.Float64:
 		err = assignFloat64(kind, field, fieldData)
 	case reflect.String:
-		field.Set(reflect.ValueOf(string(fieldData)))
+		d.assignString(field, fieldData)
 	case reflect.Struct:
-		err = Unmarshal(fieldData, field.Addr().Interface(), 0, 0, false)
+		if field.Type() == timeType && ffpTag.format != "" {
+			err = assignTime(field, fieldData, ffpTag.format)
+			break
+		}
+		err = d.unmarshal(fieldData, field.Addr().Interface(), 0, 0, false)
 	case reflect.Ptr:
-		//If pointer to struct
-		if field.Elem().Kind() == reflect.Struct {
-			//Unmarshal struct
-			err = Unmarshal(fieldData, field.Interface(), 0, 0, false)
-		} else {
-			err = assignBasedOnKind(field.Elem().Kind(), field.Elem(), fieldData, ffpTag)
+		//optional pointers are left nil when their data is blank
+		if ffpTag.optional && blank(fieldData) {
+			field.Set(reflect.Zero(field.Type()))
+			break
 		}
-	case reflect.Array:
-		for i := 0; i < field.Len(); i++ {
-			//fmt.Println("sl element interface", field.Index(i))
-			lowerBound := i * ffpTag.length
-			upperBound := lowerBound + ffpTag.length
-			assignBasedOnKind(field.Type().Elem().Kind(), field.Index(i), fieldData[lowerBound:upperBound], ffpTag)
+		//nil pointers, including pointers to pointers and elements of arrays and slices, are allocated
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
 		}
+		err = d.assignBasedOnKind(field.Elem().Kind(), field.Elem(), fieldData, ffpTag)
+	case reflect.Array:
+		return d.assignElements(field, fieldData, ffpTag)
 	case reflect.Slice:
 		if ffpTag.occurs < 1 {
-			err = errors.Errorf("flatfile.assignBasedOnKind: Occurs clause must be provided when using slice. `flatfile:\"col,len,occurs\"`")
+			return errors.Errorf("flatfile.assignBasedOnKind: Occurs clause must be provided when using slice. `flatfile:\"col,len,occurs\"`")
 		}
 		//make slice of length ffpTag.occurs to avoid index out of range err
 		field.Set(reflect.MakeSlice(field.Type(), ffpTag.occurs, ffpTag.occurs))
-		for i := 0; i < ffpTag.occurs; i++ {
-			//fmt.Println("sl element interface", field.Index(i))
-			lowerBound := i * ffpTag.length
-			upperBound := lowerBound + ffpTag.length
-			assignBasedOnKind(field.Type().Elem().Kind(), field.Index(i), fieldData[lowerBound:upperBound], ffpTag)
-		}
+		return d.assignElements(field, fieldData, ffpTag)
 	}
 	return errors.Wrap(err, "flatfile.assignBasedOnKind: AssignmentError")
 }
 
+//decodesCutShort returns true when a field of kind can be assigned data cut short by the end of the record
+//Strings are decoded from the bytes which remain, structs, pointers, arrays and slices check their own fields and elements.
+func decodesCutShort(kind reflect.Kind, field reflect.Value, ffpTag *flatfileTag) bool {
+	switch kind {
+	case reflect.String, reflect.Ptr, reflect.Array, reflect.Slice:
+		return true
+	case reflect.Struct:
+		return field.Type() != timeType || ffpTag.format == ""
+	}
+	return false
+}
+
+//blank returns true when fieldData holds only spaces
+func blank(fieldData []byte) bool {
+	for _, b := range fieldData {
+		if b != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+//checkOptional returns an error when the optional option is used on a field which is not a pointer or an array or slice of pointers
+func checkOptional(ffpTag *flatfileTag, fieldType reflect.Type) error {
+	if !ffpTag.optional {
+		return nil
+	}
+	for fieldType.Kind() == reflect.Array || fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Ptr {
+		return errors.Errorf("flatfile.checkOptional: The optional option requires a pointer field, not %s", fieldType)
+	}
+	return nil
+}
+
+/*assignElements assigns each occurrence in fieldData to an element of an array or slice
+
+Elements starting after the end of fieldData are skipped like fields starting after the end of a record, and elements
+cut short are decoded like fields cut short, see Unmarshal. Errors are FieldErrors named by the index of the element,
+for example [2] or [2].Amount.
+Decoding stops at the first element which fails to decode, unless the decoder is lenient.
+*/
+func (d *decoder) assignElements(field reflect.Value, fieldData []byte, ffpTag *flatfileTag) error {
+	var fieldErrs FieldErrors
+	for i := 0; i < field.Len(); i++ {
+		index := "[" + strconv.Itoa(i) + "]"
+		lowerBound := i * ffpTag.length
+		upperBound := lowerBound + ffpTag.length
+		if lowerBound >= len(fieldData) {
+			break
+		}
+
+		elem := field.Index(i)
+		elemData := fieldData[lowerBound:min(upperBound, len(fieldData))]
+		err := d.assignBasedOnKind(elem.Kind(), elem, elemData, ffpTag)
+		var nestedErrs FieldErrors
+		switch {
+		case err == nil:
+		case errors.As(err, &nestedErrs):
+			fieldErrs = append(fieldErrs, nestedErrs.prefix(index)...)
+		case d.lenient:
+			elem.Set(reflect.Zero(elem.Type()))
+			fieldErrs = append(fieldErrs, decodeError(index, elemData, err))
+		default:
+			return decodeError(index, elemData, err)
+		}
+	}
+	if len(fieldErrs) > 0 {
+		return fieldErrs
+	}
+	return nil
+}
+
+//assignString assigns fieldData to a string field
+//When decoder.aliasStrings is set the string shares memory with fieldData, which must then never be modified
+func (d *decoder) assignString(field reflect.Value, fieldData []byte) {
+	if d.aliasStrings && len(fieldData) > 0 {
+		field.SetString(unsafe.String(&fieldData[0], len(fieldData)))
+		return
+	}
+	field.SetString(string(fieldData))
+}
+
 func assignBool(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseBool(string(fieldData))
-	//fmt.Println(newFieldVal)
+	newFieldVal, err := ParseBool(fieldData)
 	if err == nil {
-		field.Set(reflect.ValueOf(newFieldVal))
+		field.SetBool(newFieldVal)
 	}
 
 	return errors.Wrap(err, "flatfile.assignBool error")
 }
 
 func assignUint(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	var dummy uint
-	//Determine bitness using Sizeof
-	//this will return 1 for 8-bit, 2 for 16-bit, 4 for 32-bit, 8 for 64-bit. Multiply the result to get bitsize and convert to int for Parsing
-	newFieldVal, err := strconv.ParseUint(string(fieldData), 10, int(unsafe.Sizeof(dummy)*8))
-	if err == nil {
-		field.Set(reflect.ValueOf(uint(newFieldVal)))
+	//bitSize 0 parses using the size of uint on this platform
+	newFieldVal, err := ParseUint(fieldData, 0)
+	if err != nil {
+		return errors.Wrapf(err, "flatfile.assignUint: Failed to assignUint %v ", field)
 	}
-	return errors.Wrapf(err, "flatfile.assignUint: Failed to assignUint %v ", field)
+	field.SetUint(newFieldVal)
+	return nil
 }
 
 func assignUint8(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseUint(string(fieldData), 10, 8)
+	newFieldVal, err := ParseUint(fieldData, 8)
 	if err == nil {
-		field.Set(reflect.ValueOf(uint8(newFieldVal)))
+		field.SetUint(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignUint8 error")
 }
 
 func assignUint16(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseUint(string(fieldData), 10, 16)
+	newFieldVal, err := ParseUint(fieldData, 16)
 	if err == nil {
-		field.Set(reflect.ValueOf(uint16(newFieldVal)))
+		field.SetUint(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignUint16 error")
 }
 
 func assignUint32(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseUint(string(fieldData), 10, 32)
+	newFieldVal, err := ParseUint(fieldData, 32)
 	if err == nil {
-		field.Set(reflect.ValueOf(uint32(newFieldVal)))
+		field.SetUint(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignUint32 error")
 }
 
 func assignUint64(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseUint(string(fieldData), 10, 64)
+	newFieldVal, err := ParseUint(fieldData, 64)
 	if err == nil {
-		field.Set(reflect.ValueOf(newFieldVal))
+		field.SetUint(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignUint64 error")
 }
 
 func assignInt(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	var dummy int
-	//Determine bitness using Sizeof
-	//this will return 1 for 8-bit, 2 for 16-bit, 4 for 32-bit, 8 for 64-bit. Multiply the result to get bitsize and convert to int for Parsing
-	newFieldVal, err := strconv.ParseInt(string(fieldData), 10, int(unsafe.Sizeof(dummy)*8))
-	if err == nil {
-		field.Set(reflect.ValueOf(int(newFieldVal)))
+	//bitSize 0 parses using the size of int on this platform
+	newFieldVal, err := ParseInt(fieldData, 0)
+	if err != nil {
+		return errors.Wrapf(err, "flatfile.assignInt: Failed to assignInt %v ", field)
 	}
-	return errors.Wrapf(err, "flatfile.assignInt: Failed to assignInt %v ", field)
+	field.SetInt(newFieldVal)
+	return nil
 }
 
 func assignInt8(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseInt(string(fieldData), 10, 8)
+	newFieldVal, err := ParseInt(fieldData, 8)
 	if err == nil {
-		field.Set(reflect.ValueOf(int8(newFieldVal)))
+		field.SetInt(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignInt8 error")
 }
 
 func assignInt16(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseInt(string(fieldData), 10, 16)
+	newFieldVal, err := ParseInt(fieldData, 16)
 	if err == nil {
-		field.Set(reflect.ValueOf(int16(newFieldVal)))
+		field.SetInt(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignInt16 error")
 }
 
 func assignInt32(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseInt(string(fieldData), 10, 32)
+	newFieldVal, err := ParseInt(fieldData, 32)
 	if err == nil {
-		field.Set(reflect.ValueOf(int32(newFieldVal)))
+		field.SetInt(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignInt32 error")
 }
 
 func assignInt64(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseInt(string(fieldData), 10, 64)
+	newFieldVal, err := ParseInt(fieldData, 64)
 	if err == nil {
-		field.Set(reflect.ValueOf(int64(newFieldVal)))
+		field.SetInt(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignInt64 error")
 }
 
 func assignFloat32(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseFloat(string(fieldData), 32)
+	newFieldVal, err := ParseFloat(fieldData, 32)
 	if err == nil {
-		field.Set(reflect.ValueOf(float32(newFieldVal)))
+		field.SetFloat(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignFloat32 error")
 }
 
 func assignFloat64(kind reflect.Kind, field reflect.Value, fieldData []byte) error {
-	newFieldVal, err := strconv.ParseFloat(string(fieldData), 64)
+	newFieldVal, err := ParseFloat(fieldData, 64)
 	if err == nil {
-		field.Set(reflect.ValueOf(newFieldVal))
+		field.SetFloat(newFieldVal)
 	}
 	return errors.Wrap(err, "flatfile.assignFloat64 error")
 }
 
 func assignByte(field reflect.Value, fieldData byte) error {
-	field.Set(reflect.ValueOf(fieldData))
+	field.SetUint(uint64(fieldData))
 	return nil
 }
 
 func assignRune(field reflect.Value, fieldData []byte) error {
-	newFieldVal, _ := utf8.DecodeRune(fieldData)
-	if newFieldVal == utf8.RuneError {
-		return errors.New("flatfile.assignRune error")
+	newFieldVal, err := ParseRune(fieldData)
+	if err != nil {
+		return errors.Wrap(err, "flatfile.assignRune error")
+	}
+	field.SetInt(int64(newFieldVal))
+	return nil
+}
+
+func assignTime(field reflect.Value, fieldData []byte, format string) error {
+	newFieldVal, err := time.Parse(format, string(fieldData))
+	if err != nil {
+		return errors.Wrap(err, "flatfile.assignTime error")
 	}
 	field.Set(reflect.ValueOf(newFieldVal))
 	return nil
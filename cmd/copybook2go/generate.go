@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ahmedalhulaibi/flatfile/copybook"
+)
+
+//maxFloatDigits is the number of decimal digits a float64 always holds exactly
+const maxFloatDigits = 15
+
+//maxIntegerDigits is the number of decimal digits flatfile decodes into an integer
+const maxIntegerDigits = 18
+
+//group is a group item waiting for its struct to be written
+type group struct {
+	typeName string
+	item     *copybook.Item
+}
+
+//generator writes the structs of a copybook
+type generator struct {
+	buf bytes.Buffer
+	//typeNames holds the names of the types already declared
+	typeNames map[string]bool
+	pending   []group
+	warnings  []copybook.Warning
+}
+
+//generate returns the Go source declaring a struct for each record of cb, and the unsupported clauses of the copybook
+func generate(cb *copybook.Copybook, pkg, source string) ([]byte, []copybook.Warning, error) {
+	g := &generator{typeNames: map[string]bool{}, warnings: cb.Warnings()}
+
+	fmt.Fprintf(&g.buf, "// Code generated by copybook2go from %s; DO NOT EDIT.\n\npackage %s\n", source, pkg)
+	for _, record := range cb.Records {
+		typeName := g.typeName(record.Name, "")
+		fmt.Fprintf(&g.buf, "\n// %s is record %s on line %d of %s. The record length is %d.\n", typeName, record.Name, record.Line, source, record.Size)
+		if record.Redefines != "" {
+			fmt.Fprintf(&g.buf, "// It redefines %s.\n", record.Redefines)
+		}
+		fields := record.Children
+		if !record.Group() {
+			fields = []*copybook.Item{record}
+		}
+		g.writeStruct(typeName, fields)
+
+		for len(g.pending) > 0 {
+			next := g.pending[0]
+			g.pending = g.pending[1:]
+			fmt.Fprintf(&g.buf, "\n// %s is group %s on line %d of %s.\n", next.typeName, next.item.Name, next.item.Line, source)
+			g.writeStruct(next.typeName, next.item.Children)
+		}
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("formatting generated code: %s", err)
+	}
+	sort.SliceStable(g.warnings, func(i, j int) bool { return g.warnings[i].Line < g.warnings[j].Line })
+	return src, g.warnings, nil
+}
+
+//writeStruct writes a struct type with a field for each named item
+func (g *generator) writeStruct(typeName string, items []*copybook.Item) {
+	fieldNames := map[string]bool{}
+	fmt.Fprintf(&g.buf, "type %s struct {\n", typeName)
+	for _, item := range items {
+		if item.Filler() && !hasNamedItems(item) {
+			continue
+		}
+		name := unique(goName(item.Name), fieldNames)
+		fieldNames[name] = true
+
+		if item.Redefines != "" {
+			fmt.Fprintf(&g.buf, "// %s redefines %s. Both fields are decoded from columns %d to %d.\n", item.Name, item.Redefines, item.Offset+1, item.Offset+item.Size*occurrences(item))
+			g.warn(item, fmt.Sprintf("REDEFINES %s is generated as a second field over the same columns, both fields are decoded", item.Redefines))
+		}
+		for _, condition := range item.Conditions {
+			fmt.Fprintf(&g.buf, "// 88 %s VALUE %s\n", condition.Name, strings.Join(condition.Values, " "))
+		}
+
+		var fieldType string
+		options := []string{strconv.Itoa(item.Offset + 1), strconv.Itoa(item.Size)}
+		if item.Group() {
+			fieldType = g.typeName(item.Name, typeName)
+			g.pending = append(g.pending, group{typeName: fieldType, item: item})
+		} else {
+			var notes []string
+			fieldType, options, notes = g.elementary(item, options)
+			for _, note := range notes {
+				fmt.Fprintf(&g.buf, "// %s\n", note)
+			}
+		}
+		for _, note := range item.Unsupported {
+			fmt.Fprintf(&g.buf, "// Unsupported: %s\n", note)
+		}
+		if item.Occurs > 0 {
+			fieldType = fmt.Sprintf("[%d]%s", item.Occurs, fieldType)
+		}
+		fmt.Fprintf(&g.buf, "%s %s `flatfile:\"%s\"`\n", name, fieldType, strings.Join(options, ","))
+	}
+	g.buf.WriteString("}\n")
+}
+
+//elementary returns the Go type and tag options of an elementary item
+func (g *generator) elementary(item *copybook.Item, options []string) (string, []string, []string) {
+	pic := item.Picture
+	switch {
+	case pic == nil || pic.Category != copybook.Numeric || item.SignLeading || item.SignSeparate:
+		return "string", options, nil
+	case item.Usage != copybook.Display && item.Usage != copybook.Packed && item.Usage != copybook.Binary:
+		return "string", options, nil
+	case pic.Digits > maxIntegerDigits:
+		message := fmt.Sprintf("%d digits do not fit in an int64, the item is read as text", pic.Digits)
+		g.warn(item, message)
+		return "string", options, []string{"Unsupported: " + message}
+	}
+
+	switch item.Usage {
+	case copybook.Packed:
+		options = append(options, "enc=packed")
+	case copybook.Binary:
+		if pic.Signed {
+			options = append(options, "enc=binary")
+		} else {
+			options = append(options, "enc=ubinary")
+		}
+	default:
+		if pic.Signed {
+			options = append(options, "enc=zoned")
+		}
+	}
+
+	var notes []string
+	fieldType := "uint64"
+	switch {
+	case pic.Scale > 0:
+		fieldType = "float64"
+		options = append(options, "dec="+strconv.Itoa(pic.Scale))
+		if pic.Digits > maxFloatDigits {
+			message := fmt.Sprintf("%d digits may not be represented exactly by a float64", pic.Digits)
+			g.warn(item, message)
+			notes = append(notes, message)
+		}
+	case pic.Signed:
+		fieldType = "int64"
+	}
+	return fieldType, options, notes
+}
+
+func (g *generator) warn(item *copybook.Item, message string) {
+	g.warnings = append(g.warnings, copybook.Warning{Line: item.Line, Name: item.Name, Message: message})
+}
+
+//typeName returns an unused type name for a record or group
+//Groups whose name is taken are prefixed with the name of the enclosing type.
+func (g *generator) typeName(cobolName, parent string) string {
+	name := goName(cobolName)
+	if g.typeNames[name] && parent != "" {
+		name = parent + name
+	}
+	name = unique(name, g.typeNames)
+	g.typeNames[name] = true
+	return name
+}
+
+//unique appends a number to name when it is already used
+func unique(name string, used map[string]bool) string {
+	if !used[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		if candidate := name + strconv.Itoa(i); !used[candidate] {
+			return candidate
+		}
+	}
+}
+
+//goName converts a COBOL name such as CUST-FIRST-NAME to an exported Go name such as CustFirstName
+func goName(cobolName string) string {
+	var name strings.Builder
+	for _, word := range strings.FieldsFunc(cobolName, func(r rune) bool { return r == '-' || r == '_' }) {
+		word = strings.ToLower(word)
+		name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	if name.Len() == 0 || unicode.IsDigit(rune(name.String()[0])) {
+		return "X" + name.String()
+	}
+	return name.String()
+}
+
+//hasNamedItems returns true when a group contains an item which is not FILLER
+func hasNamedItems(item *copybook.Item) bool {
+	for _, child := range item.Children {
+		if !child.Filler() || hasNamedItems(child) {
+			return true
+		}
+	}
+	return false
+}
+
+//occurrences returns the number of times an item repeats
+func occurrences(item *copybook.Item) int {
+	if item.Occurs > 0 {
+		return item.Occurs
+	}
+	return 1
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmedalhulaibi/flatfile/copybook"
+)
+
+func TestGenerateMatchesCommittedFile(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "copybooktest")
+	file, err := os.Open(filepath.Join(dir, "customer.cpy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	cb, err := copybook.Parse(file, copybook.FixedFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, warnings, err := generate(cb, "copybooktest", "customer.cpy")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(dir, "customer.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generate() output differs from customer.go, run go generate ./internal/copybooktest")
+	}
+	if len(warnings) != 2 || warnings[0].Name != "OPENED-PARTS" || warnings[1].Name != "LEGACY-FLAGS" {
+		t.Errorf("generate() warnings = %v", warnings)
+	}
+}
+
+func TestGenerateFields(t *testing.T) {
+	src := `77 COUNTER PIC S9(4) COMP.
+01 1ST-REC.
+   05 FILLER.
+      10 FILLER PIC X.
+   05 FILLER.
+      10 CODE PIC X.
+   05 TOTALS OCCURS 2.
+      10 AMOUNT PIC S9(15)V99 COMP-3.
+   05 HUGE PIC 9(19).
+   05 RATE COMP-1.
+   05 SIGNED PIC S9 SIGN LEADING.
+01 OTHER.
+   05 TOTALS PIC X.
+`
+	cb, err := copybook.Parse(strings.NewReader(src), copybook.FreeFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, warnings, err := generate(cb, "p", "test.cpy")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	//gofmt aligns fields, compare with single spaces
+	normalized := strings.Join(strings.Fields(string(got)), " ")
+	for _, want := range []string{
+		"type Counter struct { Counter int64 `flatfile:\"1,2,enc=binary\"` }",
+		"type X1stRec struct {",
+		"Filler Filler `flatfile:\"2,1\"`",
+		"Totals [2]Totals `flatfile:\"3,9\"`",
+		"Amount float64 `flatfile:\"1,9,enc=packed,dec=2\"`",
+		"Huge string `flatfile:\"21,19\"`",
+		"Rate string `flatfile:\"40,4\"`",
+		"Signed string `flatfile:\"44,1\"`",
+		"type Other struct { Totals string `flatfile:\"1,1\"` }",
+	} {
+		if !strings.Contains(normalized, strings.Join(strings.Fields(want), " ")) {
+			t.Errorf("generate() output does not contain %q:\n%s", want, got)
+		}
+	}
+
+	var messages []string
+	for _, warning := range warnings {
+		messages = append(messages, warning.Name+": "+warning.Message)
+	}
+	if len(messages) != 4 || !strings.Contains(messages[0], "float64") || !strings.Contains(messages[1], "int64") {
+		t.Errorf("generate() warnings = %q", messages)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"CUST-FIRST-NAME": "CustFirstName",
+		"WS_TOTAL":        "WsTotal",
+		"1ST-ADDR":        "X1stAddr",
+		"X":               "X",
+	}
+	for cobolName, want := range tests {
+		if got := goName(cobolName); got != want {
+			t.Errorf("goName(%s) = %s, want %s", cobolName, got, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*Command copybook2go generates Go structs with flatfile tags from a COBOL copybook
+
+Each level 01 record becomes a struct. Group items become nested structs, OCCURS becomes an array,
+and numeric items get the enc and dec tag options matching their PICTURE and USAGE clauses.
+Items redefining another item are generated as fields covering the same columns.
+
+Clauses which cannot be represented by flatfile tags are reported on standard error and noted in the generated code.
+
+Usage:
+
+	copybook2go [-package name] [-output file] [-free] [-strict] copybook.cpy
+
+It can be run from a go:generate directive:
+
+	//go:generate go run github.com/ahmedalhulaibi/flatfile/cmd/copybook2go -output customer.go customer.cpy
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ahmedalhulaibi/flatfile/copybook"
+)
+
+func main() {
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file; default $GOPACKAGE or main")
+	output := flag.String("output", "", "output file name; default standard output")
+	free := flag.Bool("free", false, "the copybook is in free format instead of fixed format")
+	strict := flag.Bool("strict", false, "fail when the copybook uses unsupported clauses")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *pkg == "" {
+		*pkg = "main"
+	}
+	format := copybook.FixedFormat
+	if *free {
+		format = copybook.FreeFormat
+	}
+
+	name := flag.Arg(0)
+	file, err := os.Open(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "copybook2go: %s\n", err)
+		os.Exit(1)
+	}
+	cb, err := copybook.Parse(file, format)
+	file.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "copybook2go: %s: %s\n", name, err)
+		os.Exit(1)
+	}
+
+	src, warnings, err := generate(cb, *pkg, filepath.Base(name))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "copybook2go: %s\n", err)
+		os.Exit(1)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "%s:%d: %s: %s\n", name, warning.Line, warning.Name, warning.Message)
+	}
+	if *strict && len(warnings) > 0 {
+		fmt.Fprintf(os.Stderr, "copybook2go: %d unsupported clauses\n", len(warnings))
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "copybook2go: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ahmedalhulaibi/flatfile"
+)
+
+//converters maps each format to the functions converting it from and to flat files
+var converters = map[string]struct {
+	fromFlat func(s *flatfile.Schema, w io.Writer, r io.Reader, opts ...flatfile.Option) error
+	toFlat   func(s *flatfile.Schema, w io.Writer, r io.Reader, opts ...flatfile.Option) error
+}{
+	"csv":   {(*flatfile.Schema).FlatToCSV, (*flatfile.Schema).CSVToFlat},
+	"jsonl": {(*flatfile.Schema).FlatToJSONLines, (*flatfile.Schema).JSONLinesToFlat},
+}
+
+func runConvert(args []string) error {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	layout := flags.String("layout", "", "layout file in JSON or YAML")
+	from := flags.String("from", "flat", "format of the input: flat, csv or jsonl")
+	to := flags.String("to", "csv", "format of the output: flat, csv or jsonl")
+	length := flags.Int("length", 0, "length of flat records which are not separated by line breaks")
+	output := flags.String("output", "", "output file name; default standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *layout == "" || flags.NArg() > 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	schema, err := flatfile.LoadSchema(*layout)
+	if err != nil {
+		return err
+	}
+	var opts []flatfile.Option
+	if *length > 0 {
+		opts = append(opts, flatfile.WithRecordLength(*length))
+	}
+
+	in := io.Reader(os.Stdin)
+	if name := flags.Arg(0); name != "" && name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	writer := bufio.NewWriter(out)
+	if err := convert(schema, writer, in, *from, *to, opts); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+//convert converts r from one format to another. Conversions between two formats other than flat go through a flat file.
+func convert(schema *flatfile.Schema, w io.Writer, r io.Reader, from, to string, opts []flatfile.Option) error {
+	for _, format := range []string{from, to} {
+		if _, ok := converters[format]; !ok && format != "flat" {
+			return fmt.Errorf("unknown format %s, expected flat, csv or jsonl", format)
+		}
+	}
+	switch {
+	case from == "flat" && to == "flat":
+		_, err := io.Copy(w, r)
+		return err
+	case from == "flat":
+		return converters[to].fromFlat(schema, w, r, opts...)
+	case to == "flat":
+		return converters[from].toFlat(schema, w, r, opts...)
+	}
+
+	//fixed length records avoid line breaks inside fields
+	opts = append(opts, flatfile.WithRecordLength(schema.RecordLength()))
+	flat, flatWriter := io.Pipe()
+	go func() {
+		flatWriter.CloseWithError(converters[from].toFlat(schema, flatWriter, r, opts...))
+	}()
+	err := converters[to].fromFlat(schema, w, flat, opts...)
+	flat.CloseWithError(io.ErrClosedPipe)
+	return err
+}
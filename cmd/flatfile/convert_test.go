@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmedalhulaibi/flatfile"
+)
+
+func TestConvert(t *testing.T) {
+	schema, err := flatfile.LoadSchema("testdata/order.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		flat      = "O2024013100150\nO2024020112345\n"
+		csv       = "Opened,Amount\n20240131,1.5\n20240201,123.45\n"
+		jsonLines = "{\"Amount\":1.5,\"Opened\":\"20240131\"}\n{\"Amount\":123.45,\"Opened\":\"20240201\"}\n"
+	)
+	//order.json has no field for the record type in column 1, so it is blank when converting to flat
+	blankType := strings.ReplaceAll(flat, "O", " ")
+	tests := []struct {
+		from, to, input, want string
+	}{
+		{"flat", "csv", flat, csv},
+		{"flat", "jsonl", flat, jsonLines},
+		{"flat", "flat", flat, flat},
+		{"csv", "flat", csv, blankType},
+		{"jsonl", "flat", jsonLines, blankType},
+		{"csv", "jsonl", csv, jsonLines},
+		{"jsonl", "csv", jsonLines, csv},
+	}
+	unconditional := *schema
+	unconditional.Condition = ""
+	if err := unconditional.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			s := schema
+			if tt.from != "flat" {
+				s = &unconditional
+			}
+			var b strings.Builder
+			if err := convert(s, &b, strings.NewReader(tt.input), tt.from, tt.to, nil); err != nil {
+				t.Fatalf("convert() error = %v", err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("convert() = %q, want %q", b.String(), tt.want)
+			}
+		})
+	}
+
+	if err := convert(schema, &strings.Builder{}, strings.NewReader(""), "xml", "flat", nil); err == nil {
+		t.Errorf("convert() expected error for an unknown format")
+	}
+	if err := convert(&unconditional, &strings.Builder{}, strings.NewReader("Opened\nbad\n"), "csv", "jsonl", nil); err == nil {
+		t.Errorf("convert() expected error for an invalid value")
+	}
+}
@@ -0,0 +1,71 @@
+/*Command flatfile works with flat files described by a runtime layout
+
+Layouts are flatfile.Schema files in JSON or YAML. When a file holds several record types, pass one layout per type
+with a condition selecting its records. The first layout whose condition matches a record is used to read it.
+
+Usage:
+
+	flatfile view [-layout file]... [-start n] [-count n] [-page n] [-length n] [-color] data.txt
+	flatfile convert -layout file [-from flat|csv|jsonl] [-to flat|csv|jsonl] [-length n] [-output file] [input]
+
+view prints records as a table with a column per field. Fields which fail to parse are highlighted and their errors
+are listed below the table. When standard input and output are terminals, view waits for Enter between pages.
+
+convert converts between flat files, CSV with a column per field and JSON Lines, using the conversions of flatfile.Schema.
+It reads standard input when no input file is given.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+//commands maps the name of each command to the function running it with the remaining arguments
+var commands = map[string]func(args []string) error{
+	"convert": runConvert,
+	"view":    runView,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		usage()
+		os.Exit(2)
+	}
+	if err := commands[os.Args[1]](os.Args[2:]); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintf(os.Stderr, "flatfile %s: %s\n", os.Args[1], err)
+		}
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(os.Stderr, "usage: flatfile <command> [flags]\n\ncommands: %s\n\nRun flatfile <command> -h for the flags of a command.\n", strings.Join(names, ", "))
+}
+
+//layoutFlags collects the values of a flag which can be repeated
+type layoutFlags []string
+
+func (l *layoutFlags) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *layoutFlags) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+//isTerminal returns true when file is a character device such as a terminal
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
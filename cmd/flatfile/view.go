@@ -0,0 +1,356 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmedalhulaibi/flatfile"
+)
+
+//highlightOn and highlightOff surround fields which fail to parse when colors are enabled
+const (
+	highlightOn  = "\x1b[7m"
+	highlightOff = "\x1b[0m"
+)
+
+func runView(args []string) error {
+	flags := flag.NewFlagSet("view", flag.ContinueOnError)
+	var layoutFiles layoutFlags
+	flags.Var(&layoutFiles, "layout", "layout file in JSON or YAML; repeat for each record type")
+	var opts viewOptions
+	flags.IntVar(&opts.start, "start", 1, "number of the first record to print")
+	flags.IntVar(&opts.count, "count", 0, "number of records to print; default all")
+	flags.IntVar(&opts.page, "page", 50, "number of records per page")
+	flags.IntVar(&opts.length, "length", 0, "length of records which are not separated by line breaks")
+	flags.BoolVar(&opts.color, "color", isTerminal(os.Stdout), "highlight fields which fail to parse")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(layoutFiles) == 0 || flags.NArg() != 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	layouts := make([]*viewLayout, len(layoutFiles))
+	for i, name := range layoutFiles {
+		schema, err := flatfile.LoadSchema(name)
+		if err != nil {
+			return err
+		}
+		if layouts[i], err = newViewLayout(schema); err != nil {
+			return err
+		}
+	}
+
+	data := os.Stdin
+	if name := flags.Arg(0); name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		data = file
+		if isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+			opts.more = os.Stdin
+		}
+	}
+	return view(os.Stdout, data, layouts, opts)
+}
+
+//viewOptions are the flags of the view command
+type viewOptions struct {
+	start, count, page, length int
+	color                      bool
+	//more is read after each page when it is not nil. Reading q stops the view.
+	more io.Reader
+}
+
+//viewLayout is a schema split into the columns of a table
+type viewLayout struct {
+	schema  *flatfile.Schema
+	columns []viewColumn
+}
+
+/*viewColumn is one field of a table, or one occurrence of a field which occurs more than once
+
+Each column is decoded on its own so that a field which fails to parse does not hide the others.
+schema holds only the field of the column, nested in copies of its groups so that columns and conditions keep their meaning.
+*/
+type viewColumn struct {
+	name   string
+	schema *flatfile.Schema
+	//path holds the names of the groups containing the field, followed by the name of the field
+	path []string
+	//start and length are the absolute columns of the field, used to show the data of fields which fail to parse
+	start, length int
+	field         flatfile.SchemaField
+}
+
+func newViewLayout(schema *flatfile.Schema) (*viewLayout, error) {
+	l := &viewLayout{schema: schema}
+	l.addColumns(schema.Fields, "", nil, 0, func(f flatfile.SchemaField) flatfile.SchemaField { return f })
+	for i := range l.columns {
+		if err := l.columns[i].schema.Compile(); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+//addColumns adds a column for each field, expanding groups and occurrences. wrap nests a field in its groups.
+func (l *viewLayout) addColumns(fields []flatfile.SchemaField, prefix string, path []string, offset int, wrap func(flatfile.SchemaField) flatfile.SchemaField) {
+	for _, field := range fields {
+		occurs := field.Occurs
+		if occurs < 1 {
+			occurs = 1
+		}
+		for i := 0; i < occurs; i++ {
+			occurrence := field
+			occurrence.Occurs = 0
+			occurrence.Column = field.Column + i*field.Length
+			name := prefix + field.Name
+			if field.Occurs > 0 {
+				name += "[" + strconv.Itoa(i) + "]"
+			}
+			fieldPath := append(path[:len(path):len(path)], field.Name)
+
+			if field.Type == "group" || len(field.Fields) > 0 {
+				group := wrap
+				l.addColumns(field.Fields, name+".", fieldPath, offset+occurrence.Column-1, func(child flatfile.SchemaField) flatfile.SchemaField {
+					parent := occurrence
+					parent.Fields = []flatfile.SchemaField{child}
+					return group(parent)
+				})
+				continue
+			}
+			l.columns = append(l.columns, viewColumn{
+				name:   name,
+				schema: &flatfile.Schema{Name: l.schema.Name, Fields: []flatfile.SchemaField{wrap(occurrence)}},
+				path:   fieldPath,
+				start:  offset + occurrence.Column,
+				length: field.Length,
+				field:  field,
+			})
+		}
+	}
+}
+
+//viewRow is a record split into the cells of a table
+type viewRow struct {
+	number int
+	layout *viewLayout
+	cells  []string
+	failed []bool
+	errs   []string
+}
+
+//row decodes every column of data
+func (l *viewLayout) row(number int, data []byte) viewRow {
+	row := viewRow{number: number, layout: l, cells: make([]string, len(l.columns)), failed: make([]bool, len(l.columns))}
+	for i := range l.columns {
+		column := &l.columns[i]
+		text, err := column.decode(data)
+		if err != nil {
+			start, end := min(column.start-1, len(data)), min(column.start-1+column.length, len(data))
+			text = strconv.Quote(string(data[start:end]))
+			row.failed[i] = true
+			row.errs = append(row.errs, fmt.Sprintf("record %d %s: %s", number, column.name, err))
+		}
+		row.cells[i] = text
+	}
+	return row
+}
+
+//decode returns the text of the column, or an empty string when the field is not in data or its condition is not met
+func (c *viewColumn) decode(data []byte) (string, error) {
+	record, err := c.schema.Decode(data)
+	if err != nil {
+		return "", err
+	}
+	for _, group := range c.path[:len(c.path)-1] {
+		if record, err = record.Group(group); err != nil {
+			return "", nil
+		}
+	}
+	value, ok := record.Get(c.path[len(c.path)-1])
+	if !ok {
+		return "", nil
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.TrimRight(v, " "), nil
+	case time.Time:
+		return v.Format(c.field.Format), nil
+	case uint8:
+		if c.field.Type == "byte" {
+			return string(rune(v)), nil
+		}
+	case int32:
+		if c.field.Type == "rune" {
+			return string(v), nil
+		}
+	}
+	return fmt.Sprint(value), nil
+}
+
+//view writes the records read from r as tables, starting a new table for each page and each change of record type
+func view(w io.Writer, r io.Reader, layouts []*viewLayout, opts viewOptions) error {
+	reader := bufio.NewReader(r)
+	var more *bufio.Reader
+	if opts.more != nil {
+		more = bufio.NewReader(opts.more)
+	}
+	if opts.page < 1 {
+		opts.page = 1
+	}
+
+	var page []viewRow
+	printed := 0
+	for number := 1; opts.count == 0 || printed < opts.count; number++ {
+		data, err := readRecord(reader, opts.length)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if number < opts.start {
+			continue
+		}
+		printed++
+
+		row := viewRow{number: number, cells: []string{strconv.Quote(string(data))}, failed: []bool{true}, errs: []string{fmt.Sprintf("record %d: No layout matches the record", number)}}
+		for _, layout := range layouts {
+			if layout.schema.Match(data) {
+				row = layout.row(number, data)
+				break
+			}
+		}
+		page = append(page, row)
+
+		if len(page) == opts.page {
+			if err := writePage(w, page, opts.color); err != nil {
+				return err
+			}
+			page = page[:0]
+			if more != nil {
+				fmt.Fprint(w, "-- Enter for the next page, q to quit --")
+				answer, err := more.ReadString('\n')
+				if err != nil || strings.TrimSpace(answer) == "q" {
+					return nil
+				}
+			}
+		}
+	}
+	return writePage(w, page, opts.color)
+}
+
+//readRecord reads a line, or a record of length bytes when length is not zero
+func readRecord(reader *bufio.Reader, length int) ([]byte, error) {
+	if length > 0 {
+		data := make([]byte, length)
+		n, err := io.ReadFull(reader, data)
+		if err == io.ErrUnexpectedEOF {
+			return data[:n], nil
+		}
+		return data, err
+	}
+	line, err := reader.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	line = []byte(strings.TrimRight(string(line), "\r\n"))
+	return line, err
+}
+
+//writePage writes a table for each run of rows with the same layout, followed by the errors of its rows
+func writePage(w io.Writer, page []viewRow, color bool) error {
+	for start := 0; start < len(page); {
+		end := start + 1
+		for end < len(page) && page[end].layout == page[start].layout {
+			end++
+		}
+		if err := writeTable(w, page[start:end], color); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+func writeTable(w io.Writer, rows []viewRow, color bool) error {
+	headers := []string{"#"}
+	if layout := rows[0].layout; layout != nil {
+		for _, column := range layout.columns {
+			headers = append(headers, column.name)
+		}
+	} else {
+		headers = append(headers, "Record")
+	}
+
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	for _, row := range rows {
+		widths[0] = max(widths[0], len(strconv.Itoa(row.number)))
+		for i, cell := range row.cells {
+			width := utf8.RuneCountInString(cell)
+			if row.failed[i] && !color {
+				width++
+			}
+			widths[i+1] = max(widths[i+1], width)
+		}
+	}
+
+	var b strings.Builder
+	if name := rows[0].layout; name != nil && name.schema.Name != "" {
+		fmt.Fprintf(&b, "%s\n", name.schema.Name)
+	}
+	writeCells(&b, headers, nil, widths, color)
+	separators := make([]string, len(widths))
+	for i, width := range widths {
+		separators[i] = strings.Repeat("-", width)
+	}
+	writeCells(&b, separators, nil, widths, color)
+	var errs []string
+	for _, row := range rows {
+		writeCells(&b, append([]string{strconv.Itoa(row.number)}, row.cells...), append([]bool{false}, row.failed...), widths, color)
+		errs = append(errs, row.errs...)
+	}
+	for _, err := range errs {
+		fmt.Fprintf(&b, "! %s\n", err)
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+//writeCells writes a line of cells padded to widths. Failed cells are highlighted, or marked with ! without colors.
+func writeCells(b *strings.Builder, cells []string, failed []bool, widths []int, color bool) {
+	var line strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			line.WriteString(" | ")
+		}
+		padding := widths[i] - utf8.RuneCountInString(cell)
+		switch {
+		case failed != nil && failed[i] && color:
+			cell = highlightOn + cell + highlightOff
+		case failed != nil && failed[i]:
+			cell = "!" + cell
+			padding--
+		}
+		line.WriteString(cell)
+		line.WriteString(strings.Repeat(" ", padding))
+	}
+	b.WriteString(strings.TrimRight(line.String(), " "))
+	b.WriteString("\n")
+}
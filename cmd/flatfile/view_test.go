@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmedalhulaibi/flatfile"
+)
+
+const viewData = "CAMY  019TORONTCA416905\nCBOB  0X9OTTAWACA61\nO2024013100150\nO20241399ABCDE\nHHEADER\n"
+
+func testLayouts(t *testing.T) []*viewLayout {
+	t.Helper()
+	var layouts []*viewLayout
+	for _, name := range []string{"testdata/customer.yaml", "testdata/order.json"} {
+		schema, err := flatfile.LoadSchema(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		layout, err := newViewLayout(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		layouts = append(layouts, layout)
+	}
+	return layouts
+}
+
+func TestView(t *testing.T) {
+	var b strings.Builder
+	if err := view(&b, strings.NewReader(viewData), testLayouts(t), viewOptions{start: 1, page: 50}); err != nil {
+		t.Fatalf("view() error = %v", err)
+	}
+	want := `customer
+# | Name | Age    | Address.City | Address.Country | Phones[0] | Phones[1]
+- | ---- | ------ | ------------ | --------------- | --------- | ---------
+1 | AMY  | 19     | TORONT       | CA              | 416       | 905
+2 | BOB  | !"0X9" | OTTAWA       | CA              | !"61"     |
+! record 2 Age: flatfile.Schema.Decode: Failed to decode: flatfile: Field Age value "0X9" failed to decode: strconv.ParseUint: parsing "0X9": invalid syntax
+! record 2 Phones[0]: flatfile.Schema.Decode: Failed to decode: flatfile: Field Phones value "61" failed to decode: flatfile: value is cut short by the end of the record
+
+order
+# | Opened      | Amount
+- | ----------- | --------
+3 | 20240131    | 1.5
+4 | !"20241399" | !"ABCDE"
+! record 4 Opened: flatfile.Schema.Decode: Failed to decode: flatfile: Field Opened value "20241399" failed to decode: parsing time "20241399": month out of range
+! record 4 Amount: flatfile.Schema.Decode: Failed to decode: flatfile: Field Amount value "ABCDE" failed to decode: strconv.ParseInt: parsing "ABCDE": invalid syntax
+
+# | Record
+- | ----------
+5 | !"HHEADER"
+! record 5: No layout matches the record
+
+`
+	if got := b.String(); got != want {
+		t.Errorf("view() = \n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestViewPaging(t *testing.T) {
+	tests := []struct {
+		name string
+		opts viewOptions
+		//want must appear in order, notWant must not appear
+		want    []string
+		notWant string
+	}{
+		{"Start and count", viewOptions{start: 3, count: 1, page: 50}, []string{"3 | 20240131"}, "AMY"},
+		{"Page", viewOptions{start: 1, count: 2, page: 1}, []string{"customer\n#", "1 | AMY", "customer\n#", "2 | BOB"}, "order"},
+		{"Quit", viewOptions{start: 1, page: 1, more: strings.NewReader("\nq\n")}, []string{"1 | AMY", "-- Enter", "2 | BOB", "-- Enter"}, "order"},
+		{"Color", viewOptions{start: 2, count: 1, page: 1, color: true}, []string{"| \x1b[7m\"0X9\"\x1b[0m |"}, "!\""},
+		{"Fixed length", viewOptions{start: 1, count: 1, page: 1, length: 9}, []string{"1 | AMY  | 19  |  "}, "TORONT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := view(&b, strings.NewReader(viewData), testLayouts(t), tt.opts); err != nil {
+				t.Fatalf("view() error = %v", err)
+			}
+			got := b.String()
+			if strings.Contains(got, tt.notWant) {
+				t.Errorf("view() = %s, want no %q", got, tt.notWant)
+			}
+			rest := got
+			for _, want := range tt.want {
+				i := strings.Index(rest, want)
+				if i < 0 {
+					t.Fatalf("view() = %s, want %q in order", got, want)
+				}
+				rest = rest[i+len(want):]
+			}
+		})
+	}
+}
+
+func TestNewViewLayoutErr(t *testing.T) {
+	schema := &flatfile.Schema{Fields: []flatfile.SchemaField{{Name: "A", Column: 1, Length: 1, Type: "time"}}}
+	if _, err := newViewLayout(schema); err == nil {
+		t.Errorf("newViewLayout() expected error")
+	}
+}
@@ -0,0 +1,626 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ahmedalhulaibi/flatfile"
+)
+
+//generatedSuffix marks files written by flatfilegen, which are skipped when parsing the package
+const generatedSuffix = "_flatfile.go"
+
+//generatedTestSuffix marks files written by flatfilegen for types declared in test files
+const generatedTestSuffix = "_flatfile_test.go"
+
+//flatfilePath is the import path of the flatfile package, whose functions the generated code calls
+const flatfilePath = "github.com/ahmedalhulaibi/flatfile"
+
+//basicTypes maps predeclared type names to the bit size used to parse them
+var basicTypes = map[string]int{
+	"string": 0, "bool": 0,
+	"int": 0, "int8": 8, "int16": 16, "int32": 32, "rune": 32, "int64": 64,
+	"uint": 0, "uint8": 8, "byte": 8, "uint16": 16, "uint32": 32, "uint64": 64,
+	"float32": 32, "float64": 64,
+}
+
+//fieldType describes the Go type of a struct field
+type fieldType struct {
+	//kind is the predeclared type name for basic types, or one of struct, pointer, array and slice
+	kind string
+	//name is the declared type name of basic and struct types
+	name string
+	//length is the length of an array
+	length int
+	//elem is the element type of pointers, arrays and slices
+	elem *fieldType
+}
+
+//field is a tagged struct field
+type field struct {
+	name   string
+	rawTag string
+	tag    flatfile.Tag
+	typ    *fieldType
+}
+
+//generator writes the methods of the requested types of a package
+type generator struct {
+	pkgName string
+	//decls maps the names of the types declared in the package to their definition
+	decls map[string]ast.Expr
+	//generated holds the names of the types being generated
+	generated map[string]bool
+	imports   map[string]bool
+	//qualifier is the name used to refer to the flatfile package, empty within the flatfile package itself
+	qualifier string
+	buf       bytes.Buffer
+	//vars is used to create unique variable names
+	vars int
+}
+
+//generate parses the package in dir and returns the source of the methods for typeNames
+//With tests the types declared in the test files of the package are found too, the methods must then be written to a test file.
+func generate(dir string, typeNames []string, tests bool) ([]byte, error) {
+	g := &generator{decls: map[string]ast.Expr{}, generated: map[string]bool{}, imports: map[string]bool{}, qualifier: "flatfile."}
+	if err := g.parsePackage(dir, tests); err != nil {
+		return nil, err
+	}
+	if importPath(dir) == flatfilePath {
+		g.qualifier = ""
+	}
+
+	var body bytes.Buffer
+	for _, name := range typeNames {
+		g.generated[name] = true
+	}
+	for _, name := range typeNames {
+		fields, err := g.structFields(name)
+		if err != nil {
+			return nil, err
+		}
+		g.buf.Reset()
+		if err := g.writeUnmarshal(name, fields); err != nil {
+			return nil, err
+		}
+		if err := g.writeMarshal(name, fields); err != nil {
+			return nil, err
+		}
+		body.Write(g.buf.Bytes())
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by flatfilegen; DO NOT EDIT.\n\npackage %s\n\n", g.pkgName)
+	var imports []string
+	for path := range g.imports {
+		if path != flatfilePath || g.qualifier != "" {
+			imports = append(imports, path)
+		}
+	}
+	sort.Strings(imports)
+	src.WriteString("import (\n")
+	//standard library packages are listed first, separated from other packages by a blank line
+	for _, std := range []bool{true, false} {
+		for _, path := range imports {
+			if isStandard(path) == std {
+				fmt.Fprintf(&src, "\t%q\n", path)
+			}
+		}
+		if std {
+			src.WriteString("\n")
+		}
+	}
+	src.WriteString(")\n")
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %s", err)
+	}
+	return formatted, nil
+}
+
+//isStandard returns true when path is the import path of a standard library package
+func isStandard(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
+
+//importPath returns the import path of the package in dir within the module declared by the closest go.mod file,
+//or an empty string when there is none
+func importPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		if data, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					rel, err := filepath.Rel(root, abs)
+					if err != nil {
+						return ""
+					}
+					return strings.TrimSuffix(strings.Trim(module, `" `)+"/"+filepath.ToSlash(rel), "/.")
+				}
+			}
+			return ""
+		}
+		if root == filepath.Dir(root) {
+			return ""
+		}
+	}
+}
+
+//parsePackage collects the type declarations of the non generated files in dir, including test files of the package with tests
+func (g *generator) parsePackage(dir string, tests bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		test := strings.HasSuffix(name, "_test.go")
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || test && !tests || strings.HasSuffix(name, generatedSuffix) || strings.HasSuffix(name, generatedTestSuffix) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		if test && strings.HasSuffix(file.Name.Name, "_test") {
+			//the external test package cannot have methods on the types of the package
+			continue
+		}
+		if g.pkgName != "" && g.pkgName != file.Name.Name {
+			return fmt.Errorf("multiple packages in %s: %s and %s", dir, g.pkgName, file.Name.Name)
+		}
+		g.pkgName = file.Name.Name
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if typeSpec.Assign == token.NoPos && typeSpec.TypeParams == nil {
+					g.decls[typeSpec.Name.Name] = typeSpec.Type
+				}
+			}
+		}
+	}
+	if g.pkgName == "" {
+		return fmt.Errorf("no Go files in %s", dir)
+	}
+	return nil
+}
+
+//structFields returns the tagged fields of the named struct type
+func (g *generator) structFields(name string) ([]field, error) {
+	structType, ok := g.decls[name].(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a struct type declared in package %s", name, g.pkgName)
+	}
+
+	var fields []field
+	for _, astField := range structType.Fields.List {
+		var rawTags string
+		if astField.Tag != nil {
+			var err error
+			if rawTags, err = strconv.Unquote(astField.Tag.Value); err != nil {
+				return nil, err
+			}
+		}
+		rawTag, ok := reflect.StructTag(rawTags).Lookup("flatfile")
+		if !ok {
+			if g.flattened(astField) {
+				return nil, fmt.Errorf("%s: untagged embedded structs are not supported", name)
+			}
+			continue
+		}
+		if len(astField.Names) == 0 {
+			return nil, fmt.Errorf("%s: embedded fields are not supported", name)
+		}
+
+		tag, err := flatfile.ParseTag(rawTag)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %s", name, astField.Names[0].Name, err)
+		}
+		if err := supportedTag(tag); err != nil {
+			return nil, fmt.Errorf("%s.%s: %s", name, astField.Names[0].Name, err)
+		}
+		typ, err := g.resolve(astField.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %s", name, astField.Names[0].Name, err)
+		}
+		if typ.kind == "slice" && tag.Occurs < 1 {
+			return nil, fmt.Errorf("%s.%s: occurs option must be provided when using slice", name, astField.Names[0].Name)
+		}
+
+		for _, ident := range astField.Names {
+			if !ident.IsExported() {
+				return nil, fmt.Errorf("%s.%s: unexported fields cannot be unmarshalled", name, ident.Name)
+			}
+			fields = append(fields, field{name: ident.Name, rawTag: rawTag, tag: tag, typ: typ})
+		}
+	}
+	return fields, nil
+}
+
+//flattened returns true for an untagged embedded struct declared in the package, whose fields Unmarshal decodes as fields of the struct embedding it
+func (g *generator) flattened(astField *ast.Field) bool {
+	if len(astField.Names) > 0 {
+		return false
+	}
+	expr := astField.Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok || !ident.IsExported() {
+		return false
+	}
+	_, ok = g.decls[ident.Name].(*ast.StructType)
+	return ok
+}
+
+//supportedTag returns an error when tag uses options the generator does not translate
+func supportedTag(tag flatfile.Tag) error {
+	if tag.Required || tag.Min != "" || tag.Max != "" || tag.OneOf != nil || tag.Pattern != "" {
+		return fmt.Errorf("validation rules (required, min, max, oneof and pattern) are not supported by flatfilegen")
+	}
+	known := flatfile.Tag{Column: tag.Column, Length: tag.Length, Occurs: tag.Occurs, Override: tag.Override, Condition: tag.Condition, Optional: tag.Optional}
+	if !reflect.DeepEqual(known, tag) {
+		return fmt.Errorf("tag options not supported by flatfilegen")
+	}
+	if tag.Condition != nil && tag.Condition.Expression != "" {
+		return fmt.Errorf("condition expressions are not supported by flatfilegen, use the form col-len-value")
+	}
+	return nil
+}
+
+//resolve converts a field type expression to a fieldType
+func (g *generator) resolve(expr ast.Expr) (*fieldType, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if _, ok := basicTypes[t.Name]; ok {
+			return &fieldType{kind: t.Name, name: t.Name}, nil
+		}
+		decl, ok := g.decls[t.Name]
+		if !ok {
+			return nil, fmt.Errorf("type %s is not declared in package %s", t.Name, g.pkgName)
+		}
+		if _, ok := decl.(*ast.StructType); ok {
+			return &fieldType{kind: "struct", name: t.Name}, nil
+		}
+		underlying, err := g.resolve(decl)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := basicTypes[underlying.kind]; !ok {
+			return nil, fmt.Errorf("type %s is not supported", t.Name)
+		}
+		underlying.name = t.Name
+		return underlying, nil
+	case *ast.StarExpr:
+		elem, err := g.resolve(t.X)
+		if err != nil {
+			return nil, err
+		}
+		return &fieldType{kind: "pointer", elem: elem}, nil
+	case *ast.ArrayType:
+		elem, err := g.resolve(t.Elt)
+		if err != nil {
+			return nil, err
+		}
+		if elem.kind == "array" || elem.kind == "slice" {
+			return nil, fmt.Errorf("arrays and slices of arrays or slices are not supported")
+		}
+		if t.Len == nil {
+			return &fieldType{kind: "slice", elem: elem}, nil
+		}
+		lit, ok := t.Len.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			return nil, fmt.Errorf("array length must be an integer literal")
+		}
+		length, err := strconv.Atoi(lit.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &fieldType{kind: "array", length: length, elem: elem}, nil
+	}
+	return nil, fmt.Errorf("type %s is not supported", types.ExprString(expr))
+}
+
+//occurrences returns how many times a field repeats in a record, as flatfile.RecordLength counts them
+func occurrences(f field) int {
+	if f.tag.Occurs > 0 {
+		return f.tag.Occurs
+	}
+	if f.typ.kind == "array" {
+		return f.typ.length
+	}
+	return 1
+}
+
+//recordLength returns the length of a record of the named struct type
+func (g *generator) recordLength(name string) (int, error) {
+	fields, err := g.structFields(name)
+	if err != nil {
+		return 0, err
+	}
+	length := 0
+	for _, f := range fields {
+		if end := f.tag.Column - 1 + f.tag.Length*occurrences(f); end > length {
+			length = end
+		}
+	}
+	return length, nil
+}
+
+//newVar returns a variable name which is unique within the generated file
+func (g *generator) newVar(prefix string) string {
+	g.vars++
+	return fmt.Sprintf("%s%d", prefix, g.vars)
+}
+
+//printf writes generated code, format refers to the flatfile package as flatfile
+func (g *generator) printf(format string, args ...interface{}) {
+	g.buf.WriteString(g.sprintf(format, args...))
+}
+
+//sprintf formats generated code like printf
+func (g *generator) sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(strings.ReplaceAll(format, "flatfile.", g.qualifier), args...)
+}
+
+//condition returns the Go expression testing the condition option of tag against data
+//Like flatfile.ShouldUnmarshal, a range which extends past the end of data compares the part within data.
+func condition(tag flatfile.Tag, data string) string {
+	lower := tag.Condition.Column - 1
+	return fmt.Sprintf("string(%s[min(%d, len(%s)):min(%d, len(%s))]) == %s",
+		data, lower, data, lower+tag.Condition.Length, data, strconv.Quote(tag.Condition.Value))
+}
+
+//writeUnmarshal writes the UnmarshalFlatfile method of a type
+//The generated code follows flatfile.Unmarshal: fields and elements starting beyond the end of data are skipped,
+//those cut short fail with flatfile.ErrCutShort unless they are strings, and decoding stops at the first field or
+//element which fails to decode. Errors are the *flatfile.FieldError of the field, for example Lines[2].Amount.
+func (g *generator) writeUnmarshal(name string, fields []field) error {
+	g.imports["fmt"] = true
+	g.printf("\n// UnmarshalFlatfile decodes data into v without reflection.\n")
+	g.printf("// It is equivalent to flatfile.Unmarshal(data, v, 0, 0, false).\n")
+	g.printf("func (v *%s) UnmarshalFlatfile(data []byte) error {\n", name)
+	for _, f := range fields {
+		g.printf("\t// %s `flatfile:%q`\n", f.name, f.rawTag)
+		if f.tag.Condition != nil {
+			g.printf("if %s {\n", condition(f.tag, "data"))
+		}
+		lower := f.tag.Column - 1
+		fieldData := g.newVar("fieldData")
+		//the data of all occurrences, each element is bounds checked when it is decoded
+		g.printf("if len(data) > %d {\n%s := data[%d:min(%d, len(data))]\n", lower, fieldData, lower, lower+f.tag.Length*occurrences(f))
+		onErr := g.sprintf("return flatfile.DecodeError(%q, %s, err)", f.name, fieldData)
+		if err := g.writeDecode("v."+f.name, f.typ, fieldData, f.tag, onErr); err != nil {
+			return err
+		}
+		g.printf("}\n")
+		if f.tag.Condition != nil {
+			g.printf("}\n")
+		}
+	}
+	g.printf("return nil\n}\n")
+	return nil
+}
+
+//writeDecode writes the statements decoding fieldData into target
+//onErr is the statement executed when decoding fails, with the error in variable err
+func (g *generator) writeDecode(target string, typ *fieldType, fieldData string, tag flatfile.Tag, onErr string) error {
+	g.imports[flatfilePath] = true
+	switch {
+	case typ.kind == "string", typ.kind == "struct", typ.kind == "pointer", typ.kind == "array", typ.kind == "slice":
+		//strings are decoded from the bytes which remain, the others check their own fields and elements
+	case tag.Length > 1:
+		//data holds at least one byte, as fields and elements starting beyond the end of data are skipped
+		g.printf("if len(%s) < %d {\nerr := flatfile.ErrCutShort\n%s\n}\n", fieldData, tag.Length, onErr)
+	}
+	switch typ.kind {
+	case "string":
+		g.printf("%s = %s(%s)\n", target, typ.name, fieldData)
+	case "bool":
+		g.printf("if b, err := flatfile.ParseBool(%s); err != nil {\n%s\n} else {\n%s = %s(b)\n}\n", fieldData, onErr, target, typ.name)
+	case "uint8", "byte":
+		if tag.Override == "byte" {
+			g.printf("%s = %s(%s[0])\n", target, typ.name, fieldData)
+			return nil
+		}
+		g.writeParse(target, typ, "ParseUint", fieldData, onErr)
+	case "uint", "uint16", "uint32", "uint64":
+		g.writeParse(target, typ, "ParseUint", fieldData, onErr)
+	case "int32", "rune":
+		if tag.Override == "rune" {
+			g.printf("if r, err := flatfile.ParseRune(%s); err != nil {\n%s\n} else {\n%s = %s(r)\n}\n", fieldData, onErr, target, typ.name)
+			return nil
+		}
+		g.writeParse(target, typ, "ParseInt", fieldData, onErr)
+	case "int", "int8", "int16", "int64":
+		g.writeParse(target, typ, "ParseInt", fieldData, onErr)
+	case "float32", "float64":
+		g.writeParse(target, typ, "ParseFloat", fieldData, onErr)
+	case "struct":
+		if g.generated[typ.name] {
+			g.printf("if err := %s.UnmarshalFlatfile(%s); err != nil {\n%s\n}\n", target, fieldData, onErr)
+		} else {
+			g.printf("if err := flatfile.Unmarshal(%s, %s, 0, 0, false); err != nil {\n%s\n}\n", fieldData, address(target), onErr)
+		}
+	case "pointer":
+		if tag.Optional {
+			g.imports["bytes"] = true
+			g.printf("if len(bytes.TrimLeft(%s, \" \")) == 0 {\n%s = nil\n} else {\n", fieldData, target)
+		}
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", target, target, typeExpr(typ.elem))
+		if err := g.writeDecode("(*"+target+")", typ.elem, fieldData, tag, onErr); err != nil {
+			return err
+		}
+		if tag.Optional {
+			g.printf("}\n")
+		}
+	case "array", "slice":
+		count := typ.length
+		if typ.kind == "slice" {
+			count = tag.Occurs
+			g.printf("%s = make(%s, %d)\n", target, typeExpr(typ), count)
+		}
+		i := g.newVar("i")
+		elemData := g.newVar("elemData")
+		//the error of an element is named by its index within the field, for example Lines[2].Amount
+		elemErr := g.sprintf("err = flatfile.DecodeError(fmt.Sprintf(\"[%%d]\", %s), %s, err)\n%s", i, elemData, onErr)
+		g.printf("for %s := 0; %s < %d; %s++ {\n", i, i, count, i)
+		g.printf("if %s*%d >= len(%s) {\nbreak\n}\n", i, tag.Length, fieldData)
+		g.printf("%s := %s[%s*%d : min((%s+1)*%d, len(%s))]\n", elemData, fieldData, i, tag.Length, i, tag.Length, fieldData)
+		if err := g.writeDecode(target+"["+i+"]", typ.elem, elemData, tag, elemErr); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	default:
+		return fmt.Errorf("type %s is not supported", typ.kind)
+	}
+	return nil
+}
+
+//address returns the Go expression taking the address of target
+func address(target string) string {
+	if strings.HasPrefix(target, "(*") && strings.HasSuffix(target, ")") {
+		return target[2 : len(target)-1]
+	}
+	return "&" + target
+}
+
+//writeParse writes a call to one of the flatfile parse functions followed by the assignment of its result
+func (g *generator) writeParse(target string, typ *fieldType, parse string, fieldData string, onErr string) {
+	g.printf("if n, err := flatfile.%s(%s, %d); err != nil {\n%s\n} else {\n%s = %s(n)\n}\n", parse, fieldData, basicTypes[typ.kind], onErr, target, typ.name)
+}
+
+//firstByte returns the Go expression of the first byte of the slice expression dst
+func firstByte(dst string) string {
+	if strings.Count(dst, "[") == 1 {
+		if lower, _, ok := strings.Cut(dst, ":"); ok {
+			return lower + "]"
+		}
+	}
+	return dst + "[0]"
+}
+
+//typeExpr returns the Go type expression of typ
+func typeExpr(typ *fieldType) string {
+	switch typ.kind {
+	case "pointer":
+		return "*" + typeExpr(typ.elem)
+	case "array":
+		return fmt.Sprintf("[%d]%s", typ.length, typeExpr(typ.elem))
+	case "slice":
+		return "[]" + typeExpr(typ.elem)
+	}
+	return typ.name
+}
+
+//writeMarshal writes the MarshalFlatfile method of a type
+func (g *generator) writeMarshal(name string, fields []field) error {
+	length, err := g.recordLength(name)
+	if err != nil {
+		return err
+	}
+	g.imports["bytes"] = true
+	g.printf("\n// MarshalFlatfile encodes v into a record without reflection.\n")
+	g.printf("// It is equivalent to flatfile.Marshal(v).\n")
+	g.printf("func (v %s) MarshalFlatfile() ([]byte, error) {\n", name)
+	g.printf("data := bytes.Repeat([]byte{' '}, %d)\n", length)
+	for _, f := range fields {
+		if f.tag.Condition != nil && f.tag.Condition.Column-1+f.tag.Condition.Length > length {
+			//the condition can never be met within the record
+			continue
+		}
+		g.printf("\t// %s `flatfile:%q`\n", f.name, f.rawTag)
+		if f.tag.Condition != nil {
+			g.printf("if %s {\n", condition(f.tag, "data"))
+		}
+		lower := f.tag.Column - 1
+		onErr := fmt.Sprintf("return nil, fmt.Errorf(\"%s.%s: %%w\", err)", name, f.name)
+		if err := g.writeEncode("v."+f.name, f.typ, fmt.Sprintf("data[%d:%d]", lower, lower+f.tag.Length*occurrences(f)), f.tag, onErr); err != nil {
+			return err
+		}
+		if f.tag.Condition != nil {
+			g.printf("}\n")
+		}
+	}
+	g.printf("return data, nil\n}\n")
+	return nil
+}
+
+//writeEncode writes the statements encoding source into dst
+func (g *generator) writeEncode(source string, typ *fieldType, dst string, tag flatfile.Tag, onErr string) error {
+	switch typ.kind {
+	case "string":
+		g.printf("if err := flatfile.EncodeString(%s, string(%s)); err != nil {\n%s\n}\n", dst, source, onErr)
+	case "bool":
+		g.printf("if err := flatfile.EncodeBool(%s, bool(%s)); err != nil {\n%s\n}\n", dst, source, onErr)
+	case "uint8", "byte":
+		if tag.Override == "byte" {
+			g.printf("%s = byte(%s)\n", firstByte(dst), source)
+			return nil
+		}
+		g.printf("if err := flatfile.EncodeUint(%s, uint64(%s)); err != nil {\n%s\n}\n", dst, source, onErr)
+	case "uint", "uint16", "uint32", "uint64":
+		g.printf("if err := flatfile.EncodeUint(%s, uint64(%s)); err != nil {\n%s\n}\n", dst, source, onErr)
+	case "int32", "rune":
+		if tag.Override == "rune" {
+			g.printf("if err := flatfile.EncodeRune(%s, rune(%s)); err != nil {\n%s\n}\n", dst, source, onErr)
+			return nil
+		}
+		g.printf("if err := flatfile.EncodeInt(%s, int64(%s)); err != nil {\n%s\n}\n", dst, source, onErr)
+	case "int", "int8", "int16", "int64":
+		g.printf("if err := flatfile.EncodeInt(%s, int64(%s)); err != nil {\n%s\n}\n", dst, source, onErr)
+	case "float32", "float64":
+		g.printf("if err := flatfile.EncodeFloat(%s, float64(%s), %d); err != nil {\n%s\n}\n", dst, source, basicTypes[typ.kind], onErr)
+	case "struct":
+		record := g.newVar("record")
+		if g.generated[typ.name] {
+			g.printf("if %s, err := %s.MarshalFlatfile(); err != nil {\n%s\n}", record, source, onErr)
+		} else {
+			g.printf("if %s, err := flatfile.Marshal(%s); err != nil {\n%s\n}", record, source, onErr)
+		}
+		g.printf(" else if len(%s) > len(%s) {\nerr := flatfile.ErrValueTooLong\n%s\n} else {\ncopy(%s, %s)\n}\n", record, dst, onErr, dst, record)
+	case "pointer":
+		g.printf("if %s != nil {\n", source)
+		if err := g.writeEncode("(*"+source+")", typ.elem, dst, tag, onErr); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case "array", "slice":
+		//like flatfile.Marshal, more elements than occurrences fail, whatever their value
+		if typ.kind == "slice" || tag.Occurs > 0 && typ.length > tag.Occurs {
+			g.printf("if len(%s) > %d {\nerr := fmt.Errorf(\"%%d elements exceed the %d occurrences of the field\", len(%s))\n%s\n}\n", source, tag.Occurs, tag.Occurs, source, onErr)
+		}
+		i := g.newVar("i")
+		g.printf("for %s := range %s {\n", i, source)
+		elemDst := fmt.Sprintf("%s[%s*%d:(%s+1)*%d]", dst, i, tag.Length, i, tag.Length)
+		if err := g.writeEncode(source+"["+i+"]", typ.elem, elemDst, tag, onErr); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	default:
+		return fmt.Errorf("type %s is not supported", typ.kind)
+	}
+	return nil
+}
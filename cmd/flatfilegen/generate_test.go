@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMatchesCommittedFile(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	got, err := generate(dir, []string{"Customer", "Address", "Codes"}, false)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(dir, "customer_flatfile.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generate() output differs from customer_flatfile.go, run go generate ./internal/gentest")
+	}
+}
+
+func TestGenerateMatchesCommittedTestFile(t *testing.T) {
+	//the types of the flatfile package tests are listed by the go:generate directive of unmarshal_test.go
+	dir := filepath.Join("..", "..")
+	src, err := os.ReadFile(filepath.Join(dir, "unmarshal_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, line := range strings.Split(string(src), "\n") {
+		if fields := strings.Fields(line); len(fields) > 4 && fields[0] == "//go:generate" && fields[3] == "./cmd/flatfilegen" {
+			types = strings.Split(fields[5], ",")
+		}
+	}
+	if len(types) == 0 {
+		t.Fatal("unmarshal_test.go has no go:generate directive for flatfilegen")
+	}
+
+	got, err := generate(dir, types, true)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(dir, "unmarshal_flatfile_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generate() output differs from unmarshal_flatfile_test.go, run go generate ./unmarshal_test.go")
+	}
+	if _, err := generate(dir, types, false); err == nil {
+		t.Error("generate() found the types of test files without tests")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		types   []string
+		wantErr string
+	}{
+		{"Unknown type", "type A struct{}", []string{"B"}, "B is not a struct type"},
+		{"Not a struct", "type A int", []string{"A"}, "A is not a struct type"},
+		{"Unexported field", "type A struct{ a string `flatfile:\"1,1\"` }", []string{"A"}, "unexported"},
+		{"Invalid tag", "type A struct{ B string `flatfile:\"1\"` }", []string{"A"}, "A.B"},
+		{"Unsupported type", "type A struct{ B map[string]int `flatfile:\"1,1\"` }", []string{"A"}, "map[string]int is not supported"},
+		{"Imported type", "type A struct{ B time.Time `flatfile:\"1,1\"` }", []string{"A"}, "time.Time is not supported"},
+		{"Slice without occurs", "type A struct{ B []int `flatfile:\"1,1\"` }", []string{"A"}, "occurs"},
+		{"Nested slices", "type A struct{ B [][]int `flatfile:\"1,1,occurs=2\"` }", []string{"A"}, "arrays and slices"},
+		{"Validation rule", "type A struct{ B string `flatfile:\"1,1,required\"` }", []string{"A"}, "validation rules"},
+		{"Condition expression", "type A struct{ B string `flatfile:\"1,1,cond=2:1 in X|Y\"` }", []string{"A"}, "condition expressions"},
+		{"Untagged embedded struct", "type H struct{ B string `flatfile:\"1,1\"` }\ntype A struct{ *H }", []string{"A"}, "untagged embedded structs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := "package p\n\nimport \"time\"\n\nvar _ time.Time\n\n" + tt.src + "\n"
+			if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := generate(dir, tt.types, false)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("generate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
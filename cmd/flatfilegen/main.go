@@ -0,0 +1,60 @@
+/*Command flatfilegen generates UnmarshalFlatfile and MarshalFlatfile methods for structs with flatfile tags
+
+The generated methods slice and parse record data without reflection. flatfile.Unmarshal and flatfile.Marshal
+detect the methods and call them, so callers do not need to change.
+
+Add a go:generate directive to the package declaring the types:
+
+	//go:generate flatfilegen -type CustomerRecord,Address
+
+Usage:
+
+	flatfilegen -type T[,T...] [-output file] [-dir directory]
+
+When the output file is a _test.go file, the types may be declared in the test files of the package.
+
+Fields may be strings, bools, integers, floats, structs declared in the same package, and pointers, arrays and slices of those.
+Generation fails for any field or tag option the generator cannot translate, such as validation rules. Such types
+keep using reflection. flatfile.Unmarshal also uses reflection when decoding options such as WithLenient are provided.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of struct type names")
+	output := flag.String("output", "", "output file name; default <first type>_flatfile.go")
+	dir := flag.String("dir", ".", "directory of the package declaring the types")
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	types := strings.Split(*typeNames, ",")
+
+	name := *output
+	if name == "" {
+		name = strings.ToLower(types[0]) + "_flatfile.go"
+	}
+	//methods written to a test file may be declared for the types of the test files
+	src, err := generate(*dir, types, strings.HasSuffix(name, "_test.go"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "flatfilegen: %s\n", err)
+		os.Exit(1)
+	}
+
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(*dir, name)
+	}
+	if err := os.WriteFile(name, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "flatfilegen: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,502 @@
+package flatfile
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*condition is a parsed condition expression of the condition option
+
+	1:2 = H1                  the data of columns 1 to 2, without trailing spaces, equals H1
+	1:2 != H1                 does not equal H1
+	1:2 in H1|H2|'H 3'        equals one of the values
+	1:4 prefix H-             starts with H-
+	5:3 < 100                 is a number less than 100, also <=, > and >=
+	8:10 blank                contains only spaces, also nonblank
+	Version >= 3              the decoded value of the field Version, declared before this field, is at least 3
+	not, and, or, ( )         combine conditions, and binds tighter than or
+
+A range is the column and length of the data compared, columns are relative to the data the field is decoded from.
+Fields are compared with their type: numbers as numbers, bools as bools and other fields as the text validation rules
+compare. Blank fields are zero, nil or only spaces.
+Values which contain spaces, commas, parentheses, | or operators, or which are keywords, are quoted with single quotes.
+A single quote in a quoted value is written twice.
+*/
+type condition struct {
+	//op is and, or, not, or the operator of a comparison
+	op          string
+	left, right *condition
+	col, length int
+	//field is the name of the field compared instead of a range, bound to the index path and tag of the field by resolve
+	field    string
+	path     []int
+	fieldTag *flatfileTag
+	values   []string
+	number   float64
+	//numbers holds the values compared with a numeric field
+	numbers []float64
+}
+
+//conditionKeywords are the words which cannot be used as values without quotes
+var conditionKeywords = map[string]bool{"and": true, "or": true, "not": true, "in": true, "prefix": true, "blank": true, "nonblank": true}
+
+//parseCondition parses a condition expression
+func parseCondition(expr string) (*condition, error) {
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &conditionParser{tokens: tokens}
+	cond, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errors.Errorf("flatfile.parseCondition: Unexpected %s in condition %s", p.tokens[p.pos].text, expr)
+	}
+	return cond, nil
+}
+
+//conditionToken is a word, operator or quoted value of a condition expression
+type conditionToken struct {
+	text   string
+	quoted bool
+}
+
+//tokenizeCondition splits a condition expression into tokens
+func tokenizeCondition(expr string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ':
+			i++
+		case c == '\'':
+			var value strings.Builder
+			for i++; ; i++ {
+				if i >= len(expr) {
+					return nil, errors.Errorf("flatfile.tokenizeCondition: Unterminated quote in condition %s", expr)
+				}
+				if expr[i] == '\'' {
+					if i+1 < len(expr) && expr[i+1] == '\'' {
+						i++
+					} else {
+						break
+					}
+				}
+				value.WriteByte(expr[i])
+			}
+			i++
+			tokens = append(tokens, conditionToken{text: value.String(), quoted: true})
+		case strings.HasPrefix(expr[i:], "!=") || strings.HasPrefix(expr[i:], "<=") || strings.HasPrefix(expr[i:], ">="):
+			tokens = append(tokens, conditionToken{text: expr[i : i+2]})
+			i += 2
+		case strings.IndexByte("()|=<>", c) >= 0:
+			tokens = append(tokens, conditionToken{text: expr[i : i+1]})
+			i++
+		default:
+			start := i
+			for i < len(expr) && strings.IndexByte(" '()|=<>!", expr[i]) < 0 {
+				i++
+			}
+			if i == start {
+				return nil, errors.Errorf("flatfile.tokenizeCondition: Unexpected %c in condition %s", c, expr)
+			}
+			tokens = append(tokens, conditionToken{text: expr[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+//conditionParser is a recursive descent parser of condition expressions
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+//keyword returns true and moves past the next token when it is the unquoted word or operator w
+func (p *conditionParser) keyword(w string) bool {
+	if p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == w {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *conditionParser) or() (*condition, error) {
+	left, err := p.and()
+	for err == nil && p.keyword("or") {
+		var right *condition
+		right, err = p.and()
+		left = &condition{op: "or", left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *conditionParser) and() (*condition, error) {
+	left, err := p.unary()
+	for err == nil && p.keyword("and") {
+		var right *condition
+		right, err = p.unary()
+		left = &condition{op: "and", left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *conditionParser) unary() (*condition, error) {
+	if p.keyword("not") {
+		cond, err := p.unary()
+		return &condition{op: "not", left: cond}, err
+	}
+	if p.keyword("(") {
+		cond, err := p.or()
+		if err == nil && !p.keyword(")") {
+			err = errors.New("flatfile.parseCondition: Missing )")
+		}
+		return cond, err
+	}
+	return p.comparison()
+}
+
+//comparison parses a range or field name followed by an operator and its values
+func (p *conditionParser) comparison() (*condition, error) {
+	cond := &condition{}
+	if err := p.operand(cond); err != nil {
+		return nil, err
+	}
+	rangeText := p.tokens[p.pos-1].text
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return nil, errors.Errorf("flatfile.parseCondition: Expected an operator after %s", rangeText)
+	}
+	cond.op = p.tokens[p.pos].text
+	p.pos++
+	switch cond.op {
+	case "blank", "nonblank":
+		return cond, nil
+	case "=", "!=", "prefix":
+		value, err := p.value()
+		cond.values = []string{value}
+		return cond, err
+	case "in":
+		for {
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			cond.values = append(cond.values, value)
+			if !p.keyword("|") {
+				return cond, nil
+			}
+		}
+	case "<", "<=", ">", ">=":
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if cond.number, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, errors.Wrapf(err, "flatfile.parseCondition: %s must be compared with a number", rangeText)
+		}
+		cond.values = []string{value}
+		return cond, nil
+	}
+	return nil, errors.Errorf("flatfile.parseCondition: Unknown operator %s after %s", cond.op, rangeText)
+}
+
+//operand parses the range or field name compared by cond
+func (p *conditionParser) operand(cond *condition) error {
+	if p.pos >= len(p.tokens) {
+		return errors.New("flatfile.parseCondition: Expected a range in the form col:len or a field name")
+	}
+	rangeText := p.tokens[p.pos].text
+	if colText, lenText, ok := strings.Cut(rangeText, ":"); ok && !p.tokens[p.pos].quoted {
+		col, colErr := strconv.Atoi(colText)
+		length, lenErr := strconv.Atoi(lenText)
+		if colErr != nil || lenErr != nil {
+			return errors.Errorf("flatfile.parseCondition: Expected a range in the form col:len but got %s", rangeText)
+		}
+		if col < 1 || length < 1 {
+			return errors.Errorf("flatfile.parseCondition: Column and length of range %s must be greater than 0", rangeText)
+		}
+		cond.col, cond.length = col, length
+	} else if !p.tokens[p.pos].quoted && isFieldName(rangeText) {
+		cond.field = rangeText
+	} else {
+		return errors.Errorf("flatfile.parseCondition: Expected a range in the form col:len or a field name but got %s", rangeText)
+	}
+	p.pos++
+	return nil
+}
+
+//value parses a quoted value or a word which is not a keyword
+func (p *conditionParser) value() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", errors.New("flatfile.parseCondition: Expected a value")
+	}
+	token := p.tokens[p.pos]
+	if !token.quoted && (conditionKeywords[token.text] || strings.IndexByte("()|=<>!", token.text[0]) >= 0) {
+		return "", errors.Errorf("flatfile.parseCondition: Expected a value but got %s, quote values which are keywords or operators", token.text)
+	}
+	p.pos++
+	//values are compared with data without trailing spaces
+	return strings.TrimRight(token.text, " "), nil
+}
+
+//isFieldName returns true when name is an exported Go identifier
+func isFieldName(name string) bool {
+	for i, c := range name {
+		switch {
+		case c >= 'A' && c <= 'Z':
+		case i > 0 && (c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_'):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+/*met evaluates the condition against data and the struct value v the data is decoded from or encoded to
+
+Ranges which extend past the end of data compare the part of the range within data.
+Conditions on fields are not met when v is not valid, for example for ShouldUnmarshal.
+*/
+func (c *condition) met(data []byte, v reflect.Value) bool {
+	switch c.op {
+	case "and":
+		return c.left.met(data, v) && c.right.met(data, v)
+	case "or":
+		return c.left.met(data, v) || c.right.met(data, v)
+	case "not":
+		return !c.left.met(data, v)
+	}
+	if c.field != "" {
+		return c.fieldMet(v)
+	}
+
+	return c.textMet(strings.TrimRight(string(dataRange(data, c.col, c.length)), " "))
+}
+
+//dataRange returns the bytes of data from column col for length bytes, cut short by the end of data
+func dataRange(data []byte, col, length int) []byte {
+	return data[min(col-1, len(data)):min(col-1+length, len(data))]
+}
+
+//fieldMet compares the value of the field of the condition in v
+func (c *condition) fieldMet(v reflect.Value) bool {
+	if !v.IsValid() || c.fieldTag == nil {
+		return false
+	}
+	field, isNil := c.fieldValue(v)
+	if isNil {
+		return c.op == "blank"
+	}
+
+	switch c.op {
+	case "blank", "nonblank":
+		blank := field.IsZero() || (field.Kind() == reflect.String && strings.TrimSpace(field.String()) == "")
+		return blank == (c.op == "blank")
+	}
+	if c.numbers == nil || c.op == "prefix" {
+		return c.textMet(valueText(field, c.fieldTag))
+	}
+
+	var n float64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(field.Uint())
+	default:
+		n = field.Float()
+	}
+	switch c.op {
+	case "=":
+		return n == c.numbers[0]
+	case "!=":
+		return n != c.numbers[0]
+	case "in":
+		for _, number := range c.numbers {
+			if n == number {
+				return true
+			}
+		}
+		return false
+	}
+	return c.compareNumber(n)
+}
+
+//fieldValue returns the field of the condition in v with pointers dereferenced, isNil is true when a pointer is nil
+func (c *condition) fieldValue(v reflect.Value) (field reflect.Value, isNil bool) {
+	field = v
+	for _, index := range c.path {
+		field = field.Field(index)
+		for field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				return field, true
+			}
+			field = field.Elem()
+		}
+	}
+	return field, false
+}
+
+//textMet compares text with the values of the condition
+func (c *condition) textMet(text string) bool {
+	switch c.op {
+	case "blank":
+		return text == ""
+	case "nonblank":
+		return text != ""
+	case "=":
+		return text == c.values[0]
+	case "!=":
+		return text != c.values[0]
+	case "in":
+		return contains(c.values, text)
+	case "prefix":
+		return strings.HasPrefix(text, c.values[0])
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return false
+	}
+	return c.compareNumber(n)
+}
+
+//compareNumber compares n with the number of a <, <=, > or >= condition
+func (c *condition) compareNumber(n float64) bool {
+	switch c.op {
+	case "<":
+		return n < c.number
+	case "<=":
+		return n <= c.number
+	case ">":
+		return n > c.number
+	}
+	return n >= c.number
+}
+
+//format returns the condition as an expression with its columns moved by offset
+func (c *condition) format(offset int) string {
+	switch c.op {
+	case "and", "or":
+		return c.left.formatOperand(c.op, offset) + " " + c.op + " " + c.right.formatOperand(c.op, offset)
+	case "not":
+		return "not " + c.left.formatOperand(c.op, offset)
+	}
+
+	text := strconv.Itoa(offset+c.col) + ":" + strconv.Itoa(c.length) + " " + c.op
+	if c.field != "" {
+		text = c.field + " " + c.op
+	}
+	for i, value := range c.values {
+		if i == 0 {
+			text += " " + quoteConditionValue(value)
+		} else {
+			text += "|" + quoteConditionValue(value)
+		}
+	}
+	return text
+}
+
+//formatOperand formats an operand of op, in parentheses when it binds less tightly than op
+func (c *condition) formatOperand(op string, offset int) string {
+	if (c.op == "or" && op != "or") || (c.op == "and" && op == "not") {
+		return "(" + c.format(offset) + ")"
+	}
+	return c.format(offset)
+}
+
+//quoteConditionValue quotes a value which would not be parsed as a single value otherwise
+func quoteConditionValue(value string) string {
+	if value != "" && !conditionKeywords[value] && strings.IndexAny(value, " ',()|=<>!") < 0 {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+//references returns true when the condition compares fields
+func (c *condition) references() bool {
+	if c == nil {
+		return false
+	}
+	return c.field != "" || c.left.references() || c.right.references()
+}
+
+//resolve binds the fields compared by the condition of field i of struct type t
+//Fields must be tagged and declared before field i, so that they are decoded first.
+func (c *condition) resolve(t reflect.Type, layout *structLayout, i int) error {
+	if c == nil {
+		return nil
+	}
+	if err := c.left.resolve(t, layout, i); err != nil {
+		return err
+	}
+	if err := c.right.resolve(t, layout, i); err != nil {
+		return err
+	}
+	if c.field == "" {
+		return nil
+	}
+
+	//fields of untagged embedded structs are compared like fields of the struct
+	field, ok := t.FieldByName(c.field)
+	fieldLayouts := layout
+	for depth := 0; ok && depth < len(field.Index)-1; depth++ {
+		embedded := &fieldLayouts.fields[field.Index[depth]]
+		ok = embedded.embedded
+		fieldLayouts = layoutOf(embeddedStruct(t.FieldByIndex(field.Index[:depth+1]).Type))
+	}
+	if !ok || !fieldLayouts.fields[field.Index[len(field.Index)-1]].tagged {
+		return errors.Errorf("flatfile.condition: %s is not a field with a flatfile tag", c.field)
+	}
+	if field.Index[0] >= i {
+		return errors.Errorf("flatfile.condition: %s must be declared before the fields whose conditions compare it", c.field)
+	}
+	c.path = field.Index
+	c.fieldTag = &fieldLayouts.fields[field.Index[len(field.Index)-1]].tag
+
+	fieldType := field.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return errors.Errorf("flatfile.condition: %s cannot be compared, it is a %s", c.field, fieldType.Kind())
+	case reflect.Struct:
+		if fieldType != timeType {
+			return errors.Errorf("flatfile.condition: %s cannot be compared, it is a nested struct", c.field)
+		}
+	}
+	numeric := numericKind(fieldType.Kind()) && c.fieldTag.override == ""
+	switch c.op {
+	case "blank", "nonblank", "prefix":
+		return nil
+	case "<", "<=", ">", ">=":
+		if !numeric {
+			return errors.Errorf("flatfile.condition: %s cannot be compared with %s, it is not a number", c.field, c.op)
+		}
+		c.numbers = []float64{c.number}
+		return nil
+	}
+	c.numbers = nil
+	for j, value := range c.values {
+		switch {
+		case numeric:
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return errors.Wrapf(err, "flatfile.condition: %s must be compared with numbers", c.field)
+			}
+			c.numbers = append(c.numbers, n)
+		case fieldType.Kind() == reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return errors.Wrapf(err, "flatfile.condition: %s must be compared with true or false", c.field)
+			}
+			c.values[j] = strconv.FormatBool(b)
+		}
+	}
+	return nil
+}
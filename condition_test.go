@@ -0,0 +1,264 @@
+package flatfile
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConditionMet(t *testing.T) {
+	tests := []struct {
+		expr string
+		data string
+		want bool
+	}{
+		{"1:4 = H-01", "H-01", true},
+		{"1:4 = H1", "H1  ", true},
+		{"1:4 = 'H1  '", "H1  ", true},
+		{"1:4=H1", "H2  ", false},
+		{"1:2 != H1", "H2", true},
+		{"1:2 in H1|H2|'H,3'", "H2", true},
+		{"1:3 in H1|H2|'H,3'", "H,3", true},
+		{"1:3 in H1|H2", "H3", false},
+		{"1:4 prefix H-", "H-01", true},
+		{"1:4 prefix H-", "HX01", false},
+		{"1:3 < 100", "099", true},
+		{"1:3 <= 99", "099", true},
+		{"1:3 > 99", " 99", false},
+		{"1:3 >= -5", "-5 ", true},
+		{"1:3 < 100", "ABC", false},
+		{"1:3 blank", "   X", true},
+		{"1:3 nonblank", "   X", false},
+		{"4:3 blank", "   X", false},
+		{"5:3 blank", "   X", true},
+		{"1:1 = 'it''s'", "it's", false},
+		{"1:4 = 'it''s'", "it's", true},
+		{"1:1 = A and 2:1 = B", "AB", true},
+		{"1:1 = A and 2:1 = B", "AC", false},
+		{"1:1 = X or 2:1 = B", "AB", true},
+		{"not 1:1 = A", "AB", false},
+		{"not (1:1 = X or 2:1 = X) and 1:2 nonblank", "AB", true},
+		{"1:1 = A or 1:1 = B and 2:1 = C", "AX", true},
+		{"(1:1 = A or 1:1 = B) and 2:1 = C", "AX", false},
+		{"1:1 = 'and'", "and", false},
+		{"1:3 = 'and'", "and", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cond, err := parseCondition(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCondition(%q) unexpected error %s", tt.expr, err)
+			}
+			if got := cond.met([]byte(tt.data), reflect.Value{}); got != tt.want {
+				t.Errorf("met(%q) = %v, want %v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"1:2",
+		"1:2 =",
+		"1:2 = and",
+		"1:2 = =",
+		"A:2 = X",
+		"0:2 = X",
+		"1:0 = X",
+		"'1:2' = X",
+		"1:2 ~ X",
+		"1:2 < X",
+		"1:2 = 'X",
+		"(1:2 = X",
+		"1:2 = X)",
+		"1:2 = X 2:1 = Y",
+		"1:2 = X and",
+		"1:2 in X|",
+		"1:2 = !X",
+		"version = 3",
+		"Version",
+		"Version.Major = 3",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := parseCondition(expr); err == nil {
+				t.Errorf("parseCondition(%q) expected error", expr)
+			}
+		})
+	}
+}
+
+func TestConditionFormat(t *testing.T) {
+	tests := []struct {
+		expr   string
+		offset int
+		want   string
+	}{
+		{"1:2=H1", 0, "1:2 = H1"},
+		{"1:2 in H1|'H 2 '|'it''s'|''", 10, "11:2 in H1|'H 2'|'it''s'|''"},
+		{"not (1:1 = A or 2:1 blank) and 3:1 > 5", 0, "not (1:1 = A or 2:1 blank) and 3:1 > 5"},
+		{"(1:1 = A or 1:1 = B) and not 2:1 = 'or'", 4, "(5:1 = A or 5:1 = B) and not 6:1 = 'or'"},
+		{"1:1 = A or 1:1 = B and 2:1 = C", 0, "1:1 = A or 1:1 = B and 2:1 = C"},
+		{"RecordType=D and Version >= 3", 4, "RecordType = D and Version >= 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cond, err := parseCondition(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cond.format(tt.offset); got != tt.want {
+				t.Errorf("format(%d) = %q, want %q", tt.offset, got, tt.want)
+			}
+			if _, err := parseCondition(cond.format(0)); err != nil {
+				t.Errorf("format() returned an expression which does not parse: %s", err)
+			}
+		})
+	}
+}
+
+type conditionRecord struct {
+	Type    string `flatfile:"1,4"`
+	Header  string `flatfile:"5,5,cond=1:4 in H-01|'H,02'"`
+	Amount  int    `flatfile:"5,3,cond=1:4 = D-01 and 8:2 nonblank,required"`
+	Comment string `flatfile:"5,5,condition=1:4 prefix C"`
+}
+
+func TestConditionTags(t *testing.T) {
+	tests := []struct {
+		data string
+		want conditionRecord
+	}{
+		{"H-01HELLO", conditionRecord{Type: "H-01", Header: "HELLO"}},
+		{"H,02HELLO", conditionRecord{Type: "H,02", Header: "HELLO"}},
+		{"D-0112345", conditionRecord{Type: "D-01", Amount: 123}},
+		{"D-01123  ", conditionRecord{Type: "D-01"}},
+		{"C-01HELLO", conditionRecord{Type: "C-01", Comment: "HELLO"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var record conditionRecord
+			if err := Unmarshal([]byte(tt.data), &record, 0, 0, false); err != nil {
+				t.Fatal(err)
+			}
+			if record != tt.want {
+				t.Errorf("Unmarshal() got: %+v want: %+v", record, tt.want)
+			}
+		})
+	}
+
+	tag, err := ParseTag("5,5,cond=1:4 in H-01|'H,02'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&TagCondition{Expression: "1:4 in H-01|'H,02'"}); !reflect.DeepEqual(tag.Condition, want) {
+		t.Errorf("ParseTag() condition = %+v, want %+v", tag.Condition, want)
+	}
+}
+
+type fieldConditionRecord struct {
+	RecordType string  `flatfile:"1,1"`
+	Version    int     `flatfile:"2,2"`
+	Active     bool    `flatfile:"4,1"`
+	Detail     string  `flatfile:"5,5,cond=RecordType = D and Version >= 3"`
+	Note       string  `flatfile:"5,5,cond=RecordType in H|T or Active = true"`
+	Rate       int     `flatfile:"10,3,cond=Version in 1|2"`
+	Total      float64 `flatfile:"13,4,cond=Rate > 100 and Version != 2"`
+}
+
+func TestFieldConditions(t *testing.T) {
+	tests := []struct {
+		data string
+		want fieldConditionRecord
+	}{
+		{"D03FHELLO       ", fieldConditionRecord{RecordType: "D", Version: 3, Detail: "HELLO"}},
+		{"D02F     050    ", fieldConditionRecord{RecordType: "D", Version: 2, Rate: 50}},
+		{"H03FHELLO       ", fieldConditionRecord{RecordType: "H", Version: 3, Note: "HELLO"}},
+		{"D02THELLO000    ", fieldConditionRecord{RecordType: "D", Version: 2, Active: true, Note: "HELLO"}},
+		{"D01F     2501234", fieldConditionRecord{RecordType: "D", Version: 1, Rate: 250, Total: 1234}},
+		{"D01F     0501234", fieldConditionRecord{RecordType: "D", Version: 1, Rate: 50}},
+		{"D02F     2501234", fieldConditionRecord{RecordType: "D", Version: 2, Rate: 250}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var record fieldConditionRecord
+			if err := Unmarshal([]byte(tt.data), &record, 0, 0, false); err != nil {
+				t.Fatal(err)
+			}
+			if record != tt.want {
+				t.Errorf("Unmarshal() got: %+v want: %+v", record, tt.want)
+			}
+
+			//fields whose condition is not met are written as spaces
+			data, err := Marshal(&tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var again fieldConditionRecord
+			if err := Unmarshal(data, &again, 0, 0, false); err != nil || again != tt.want {
+				t.Errorf("Marshal() got: %q which unmarshals to %+v, %v", data, again, err)
+			}
+		})
+	}
+
+	if ShouldUnmarshal(&layoutOf(reflect.TypeOf(fieldConditionRecord{})).fields[3].tag, []byte("D03FHELLO")) {
+		t.Error("ShouldUnmarshal() is true for a condition comparing fields")
+	}
+}
+
+func TestFieldConditionMarshal(t *testing.T) {
+	data, err := Marshal(fieldConditionRecord{RecordType: "D", Version: 2, Detail: "SKIP", Note: "SKIP", Rate: 250, Total: 99})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "D02F     250    "; string(data) != want {
+		t.Errorf("Marshal() got: %q want: %q", data, want)
+	}
+}
+
+func TestFieldConditionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"unknown field", &struct {
+			A string `flatfile:"1,1,cond=B = X"`
+		}{}},
+		{"later field", &struct {
+			A string `flatfile:"1,1,cond=B = X"`
+			B string `flatfile:"2,1"`
+		}{}},
+		{"untagged field", &struct {
+			B string
+			A string `flatfile:"1,1,cond=B = X"`
+		}{}},
+		{"string compared as number", &struct {
+			B string `flatfile:"1,1"`
+			A string `flatfile:"2,1,cond=B > 3"`
+		}{}},
+		{"number compared with text", &struct {
+			B int    `flatfile:"1,1"`
+			A string `flatfile:"2,1,cond=B = X"`
+		}{}},
+		{"bool compared with text", &struct {
+			B bool   `flatfile:"1,1"`
+			A string `flatfile:"2,1,cond=B = X"`
+		}{}},
+		{"nested struct", &struct {
+			B struct {
+				C string `flatfile:"1,1"`
+			} `flatfile:"1,1"`
+			A string `flatfile:"2,1,cond=B blank"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte("123"), tt.v, 0, 0, false); err == nil {
+				t.Error("Unmarshal() expected error")
+			}
+		})
+	}
+
+	if _, err := SchemaOf(fieldConditionRecord{}); err == nil {
+		t.Error("SchemaOf() expected error for a condition comparing fields")
+	}
+}
@@ -0,0 +1,340 @@
+package flatfile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*FlatToCSV converts the flat file r to CSV with a header row of column names
+
+Groups and fields which occur more than once are flattened into columns such as Address.City and Phones[0], as listed by Columns.
+Strings are written without their padding. Fields which are not decoded because of a condition or a short record are empty.
+opts configure how records are read, for example WithRecordLength.
+*/
+func (s *Schema) FlatToCSV(w io.Writer, r io.Reader, opts ...Option) error {
+	columns := s.columns("", nil)
+	writer := csv.NewWriter(w)
+	writer.Write(columnNames(columns))
+	err := s.readFlat(r, opts, func(record *Record) error {
+		row := make([]string, len(columns))
+		for i := range columns {
+			row[i] = columns[i].text(record)
+		}
+		return writer.Write(row)
+	})
+	if err != nil {
+		return errors.Wrap(err, "flatfile.Schema.FlatToCSV: Failed to convert")
+	}
+	writer.Flush()
+	return errors.Wrap(writer.Error(), "flatfile.Schema.FlatToCSV: Failed to write")
+}
+
+/*FlatToJSONLines converts the flat file r to JSON Lines with an object per record
+
+Groups are nested objects and fields which occur more than once are arrays. Strings are written without their padding,
+time fields are strings in their format, and byte and rune fields are strings of one character.
+*/
+func (s *Schema) FlatToJSONLines(w io.Writer, r io.Reader, opts ...Option) error {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	err := s.readFlat(r, opts, func(record *Record) error {
+		return encoder.Encode(record.jsonValues())
+	})
+	if err != nil {
+		return errors.Wrap(err, "flatfile.Schema.FlatToJSONLines: Failed to convert")
+	}
+	return errors.Wrap(writer.Flush(), "flatfile.Schema.FlatToJSONLines: Failed to write")
+}
+
+/*CSVToFlat converts CSV written by FlatToCSV, or any CSV whose header row holds column names, to a flat file
+
+Columns may be in any order and may be left out. Empty cells leave their field blank.
+Records are written one per line, or back to back when WithRecordLength is given.
+*/
+func (s *Schema) CSVToFlat(w io.Writer, r io.Reader, opts ...Option) error {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "flatfile.Schema.CSVToFlat: Failed to read header")
+	}
+	byName := map[string]*schemaColumn{}
+	columns := s.columns("", nil)
+	for i := range columns {
+		byName[columns[i].name] = &columns[i]
+	}
+	headerColumns := make([]*schemaColumn, len(header))
+	for i, name := range header {
+		if headerColumns[i] = byName[name]; headerColumns[i] == nil {
+			return errors.Errorf("flatfile.Schema.CSVToFlat: Column %s is not in schema %s", name, s.Name)
+		}
+	}
+
+	writer := s.newFlatWriter(w, opts)
+	for number := 1; ; number++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "flatfile.Schema.CSVToFlat: Failed to read CSV")
+		}
+		values := map[string]any{}
+		for i, text := range row {
+			if text != "" {
+				headerColumns[i].set(values, text)
+			}
+		}
+		if err := writer.write(values); err != nil {
+			return errors.Wrapf(err, "flatfile.Schema.CSVToFlat: Failed to convert record %d", number)
+		}
+	}
+	return errors.Wrap(writer.Flush(), "flatfile.Schema.CSVToFlat: Failed to write")
+}
+
+//JSONLinesToFlat converts JSON Lines written by FlatToJSONLines to a flat file
+//Missing and null values leave their field blank. Numbers and strings are converted to the type of their field.
+func (s *Schema) JSONLinesToFlat(w io.Writer, r io.Reader, opts ...Option) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	writer := s.newFlatWriter(w, opts)
+	for number := 1; ; number++ {
+		var values map[string]any
+		err := decoder.Decode(&values)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "flatfile.Schema.JSONLinesToFlat: Failed to read record %d", number)
+		}
+		if err := writer.write(values); err != nil {
+			return errors.Wrapf(err, "flatfile.Schema.JSONLinesToFlat: Failed to convert record %d", number)
+		}
+	}
+	return errors.Wrap(writer.Flush(), "flatfile.Schema.JSONLinesToFlat: Failed to write")
+}
+
+//Columns returns the names of the columns written by FlatToCSV
+func (s *Schema) Columns() []string {
+	return columnNames(s.columns("", nil))
+}
+
+//readFlat decodes each record of r and passes it to fn
+func (s *Schema) readFlat(r io.Reader, opts []Option, fn func(*Record) error) error {
+	o := newOptions(opts)
+	file := o.newFlatFile(r)
+	for {
+		data, err := file.readRecord(o.ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !s.Match(data) {
+			return errors.Errorf("Record %d does not match the condition %s of schema %s", file.records, s.Condition, s.Name)
+		}
+		record, err := s.decode(data, &o.decoder)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to decode record %d", file.records)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+//flatWriter writes records marshalled by a schema, separated by line breaks unless they have a fixed length
+type flatWriter struct {
+	schema *Schema
+	*bufio.Writer
+	lineBreaks bool
+}
+
+func (s *Schema) newFlatWriter(w io.Writer, opts []Option) *flatWriter {
+	return &flatWriter{schema: s, Writer: bufio.NewWriter(w), lineBreaks: newOptions(opts).recordLength == 0}
+}
+
+func (w *flatWriter) write(values map[string]any) error {
+	data, err := w.schema.Marshal(values)
+	if err != nil {
+		return err
+	}
+	w.Write(data)
+	if w.lineBreaks {
+		w.WriteByte('\n')
+	}
+	return nil
+}
+
+//schemaColumn is a column of a flattened schema: a field which is not a group, or one occurrence of it
+type schemaColumn struct {
+	name  string
+	path  []columnStep
+	field *SchemaField
+}
+
+//columnStep is a field on the path from a record to a column. index is -1 when the field does not occur more than once.
+type columnStep struct {
+	name          string
+	index, occurs int
+}
+
+//columns flattens the fields of the schema, expanding groups and occurrences
+func (s *Schema) columns(prefix string, path []columnStep) []schemaColumn {
+	var columns []schemaColumn
+	for i := range s.Fields {
+		field := &s.Fields[i]
+		for index := 0; index < field.occurrences(); index++ {
+			name := prefix + field.Name
+			step := columnStep{name: field.Name, index: -1}
+			if field.tag.occurs > 0 {
+				name += "[" + strconv.Itoa(index) + "]"
+				step = columnStep{name: field.Name, index: index, occurs: field.tag.occurs}
+			}
+			fieldPath := append(path[:len(path):len(path)], step)
+			if field.group != nil {
+				columns = append(columns, field.group.columns(name+".", fieldPath)...)
+			} else {
+				columns = append(columns, schemaColumn{name: name, path: fieldPath, field: field})
+			}
+		}
+	}
+	return columns
+}
+
+func columnNames(columns []schemaColumn) []string {
+	names := make([]string, len(columns))
+	for i := range columns {
+		names[i] = columns[i].name
+	}
+	return names
+}
+
+//text returns the text of the column in record, or an empty string when it was not decoded
+func (c *schemaColumn) text(record *Record) string {
+	var value any = record
+	for _, step := range c.path {
+		group, ok := value.(*Record)
+		if !ok {
+			return ""
+		}
+		if value, ok = group.values[step.name]; !ok {
+			return ""
+		}
+		if step.index >= 0 {
+			value = reflect.ValueOf(value).Index(step.index).Interface()
+		}
+	}
+	return c.field.formatValue(value)
+}
+
+//set stores text as the value of the column in values, creating the maps and slices of its groups
+func (c *schemaColumn) set(values map[string]any, text string) {
+	last := len(c.path) - 1
+	for _, step := range c.path[:last] {
+		if step.index < 0 {
+			group, ok := values[step.name].(map[string]any)
+			if !ok {
+				group = map[string]any{}
+				values[step.name] = group
+			}
+			values = group
+			continue
+		}
+		occurrences, ok := values[step.name].([]any)
+		if !ok {
+			occurrences = make([]any, step.occurs)
+			values[step.name] = occurrences
+		}
+		group, ok := occurrences[step.index].(map[string]any)
+		if !ok {
+			group = map[string]any{}
+			occurrences[step.index] = group
+		}
+		values = group
+	}
+
+	step := c.path[last]
+	if step.index < 0 {
+		values[step.name] = text
+		return
+	}
+	occurrences, ok := values[step.name].([]any)
+	if !ok {
+		occurrences = make([]any, step.occurs)
+		values[step.name] = occurrences
+	}
+	occurrences[step.index] = text
+}
+
+//formatValue returns the text of a decoded value of the field
+func (f *SchemaField) formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimRight(v, " ")
+	case time.Time:
+		return v.Format(f.tag.format)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case uint8:
+		if f.tag.override == "byte" {
+			return string(rune(v))
+		}
+	case int32:
+		if f.tag.override == "rune" {
+			return string(v)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+//jsonValues returns the values of the record with groups as nested maps and values as written by FlatToJSONLines
+func (r *Record) jsonValues() map[string]any {
+	values := make(map[string]any, len(r.values))
+	for i := range r.schema.Fields {
+		field := &r.schema.Fields[i]
+		value, ok := r.values[field.Name]
+		if !ok {
+			continue
+		}
+		if field.tag.occurs == 0 {
+			values[field.Name] = field.jsonValue(value)
+			continue
+		}
+		elems := reflect.ValueOf(value)
+		occurrences := make([]any, elems.Len())
+		for j := range occurrences {
+			occurrences[j] = field.jsonValue(elems.Index(j).Interface())
+		}
+		values[field.Name] = occurrences
+	}
+	return values
+}
+
+//jsonValue returns one decoded value of the field as written by FlatToJSONLines
+func (f *SchemaField) jsonValue(value any) any {
+	switch v := value.(type) {
+	case *Record:
+		return v.jsonValues()
+	case string, time.Time:
+		return f.formatValue(value)
+	}
+	if f.tag.override != "" {
+		return f.formatValue(value)
+	}
+	return value
+}
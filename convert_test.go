@@ -0,0 +1,247 @@
+package flatfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const convertFlat = "AMY1900-01-01019123 FAKE STREETCA41611122229053334444012345\nBOB2001-12-31042456 REAL AVENUEUS61311122220000000000\n"
+
+const convertCSV = `Name,OpenDate,Age,Address.Street,Address.Country,Phones[0],Phones[1],Initial,Balance
+AMY,1900-01-01,19,123 FAKE STREET,CA,4161112222,9053334444,A,123.45
+BOB,2001-12-31,42,456 REAL AVENUE,US,6131112222,0,B,
+`
+
+const convertJSONLines = `{"Address":{"Country":"CA","Street":"123 FAKE STREET"},"Age":19,"Balance":123.45,"Initial":"A","Name":"AMY","OpenDate":"1900-01-01","Phones":[4161112222,9053334444]}
+{"Address":{"Country":"US","Street":"456 REAL AVENUE"},"Age":42,"Initial":"B","Name":"BOB","OpenDate":"2001-12-31","Phones":[6131112222,0]}
+`
+
+func TestSchemaConvert(t *testing.T) {
+	schema, err := ReadSchemaYAML(strings.NewReader(customerSchemaYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var csv, jsonLines bytes.Buffer
+	if err := schema.FlatToCSV(&csv, strings.NewReader(convertFlat)); err != nil {
+		t.Fatalf("FlatToCSV() error = %v", err)
+	}
+	if csv.String() != convertCSV {
+		t.Errorf("FlatToCSV() = %s, want %s", csv.String(), convertCSV)
+	}
+	if err := schema.FlatToJSONLines(&jsonLines, strings.NewReader(convertFlat)); err != nil {
+		t.Fatalf("FlatToJSONLines() error = %v", err)
+	}
+	if jsonLines.String() != convertJSONLines {
+		t.Errorf("FlatToJSONLines() = %s, want %s", jsonLines.String(), convertJSONLines)
+	}
+
+	//The second record is short so the blank Balance is written as spaces and the record is padded to RecordLength
+	wantFlat := "AMY1900-01-01019123 FAKE STREETCA41611122229053334444012345\nBOB2001-12-31042456 REAL AVENUEUS61311122220000000000      \n"
+	var fromCSV, fromJSON bytes.Buffer
+	if err := schema.CSVToFlat(&fromCSV, strings.NewReader(convertCSV)); err != nil {
+		t.Fatalf("CSVToFlat() error = %v", err)
+	}
+	if fromCSV.String() != wantFlat {
+		t.Errorf("CSVToFlat() = %q, want %q", fromCSV.String(), wantFlat)
+	}
+	if err := schema.JSONLinesToFlat(&fromJSON, strings.NewReader(convertJSONLines)); err != nil {
+		t.Fatalf("JSONLinesToFlat() error = %v", err)
+	}
+	if fromJSON.String() != wantFlat {
+		t.Errorf("JSONLinesToFlat() = %q, want %q", fromJSON.String(), wantFlat)
+	}
+
+	short := &Schema{Fields: schema.Fields[:3:3]}
+	if err := short.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	var fixed bytes.Buffer
+	if err := short.CSVToFlat(&fixed, strings.NewReader("Age,Name,OpenDate\n19,AMY,1900-01-01\n42,BOB,2001-12-31\n"), WithRecordLength(short.RecordLength())); err != nil {
+		t.Fatalf("CSVToFlat() error = %v", err)
+	}
+	if fixed.String() != "AMY1900-01-01019BOB2001-12-31042" {
+		t.Errorf("CSVToFlat() with WithRecordLength = %q", fixed.String())
+	}
+	var fixedCSV bytes.Buffer
+	if err := short.FlatToCSV(&fixedCSV, &fixed, WithRecordLength(short.RecordLength())); err != nil {
+		t.Fatalf("FlatToCSV() error = %v", err)
+	}
+	if fixedCSV.String() != "Name,OpenDate,Age\nAMY,1900-01-01,19\nBOB,2001-12-31,42\n" {
+		t.Errorf("FlatToCSV() with WithRecordLength = %s", fixedCSV.String())
+	}
+}
+
+func TestSchemaConvertErrors(t *testing.T) {
+	schema, err := ReadSchemaYAML(strings.NewReader(customerSchemaYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		convert func(w *bytes.Buffer) error
+		wantErr string
+	}{
+		{"Invalid flat record", func(w *bytes.Buffer) error {
+			return schema.FlatToCSV(w, strings.NewReader("AMY1900-01-01X19"))
+		}, "Failed to decode record 1"},
+		{"Invalid flat record JSON", func(w *bytes.Buffer) error {
+			return schema.FlatToJSONLines(w, strings.NewReader("AMY1900-01-01019\nAMY1900-01-01X19"))
+		}, "Failed to decode record 2"},
+		{"Unknown column", func(w *bytes.Buffer) error {
+			return schema.CSVToFlat(w, strings.NewReader("Name,Phone\n"))
+		}, "Column Phone is not in schema customer"},
+		{"Invalid CSV value", func(w *bytes.Buffer) error {
+			return schema.CSVToFlat(w, strings.NewReader("Name,Age\nAMY,old\n"))
+		}, "Failed to convert record 1"},
+		{"Value too long", func(w *bytes.Buffer) error {
+			return schema.CSVToFlat(w, strings.NewReader("Name\nAMY\nAMANDA\n"))
+		}, "Failed to convert record 2"},
+		{"Invalid JSON", func(w *bytes.Buffer) error {
+			return schema.JSONLinesToFlat(w, strings.NewReader("{\"Name\": 1"))
+		}, "Failed to read record 1"},
+		{"Invalid JSON value", func(w *bytes.Buffer) error {
+			return schema.JSONLinesToFlat(w, strings.NewReader(`{"Phones": 1}`))
+		}, "Field occurs 2 times"},
+		{"Condition not met", func(w *bytes.Buffer) error {
+			order := &Schema{Name: "order", Condition: "1-1-O", Fields: []SchemaField{{Name: "A", Column: 1, Length: 1}}}
+			order.Compile()
+			return order.FlatToCSV(w, strings.NewReader("O\nC\n"))
+		}, "Record 2 does not match the condition 1-1-O of schema order"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.convert(&bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaMarshal(t *testing.T) {
+	schema, err := ReadSchemaYAML(strings.NewReader(customerSchemaYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("AMY1900-01-01019123 FAKE STREETCA41611122229053334444012345")
+
+	record, err := schema.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, _ := schema.Unmarshal(data)
+	for name, values := range map[string]map[string]any{"Record": record.values, "Map": values} {
+		got, err := schema.Marshal(values)
+		if err != nil || string(got) != string(data) {
+			t.Errorf("Marshal(%s) = %q, %v, want %q", name, got, err, data)
+		}
+	}
+
+	converted, err := schema.Marshal(map[string]any{"Name": "AMY", "Age": 19, "Phones": []any{int8(1), json.Number("2")}, "OpenDate": time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil || string(converted[:16]) != "AMY1900-01-01019" || string(converted[33:53]) != "00000000010000000002" {
+		t.Errorf("Marshal() = %q, %v", converted, err)
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]any
+		wantErr string
+	}{
+		{"Lossy conversion", map[string]any{"Age": 1.5}, "without losing precision"},
+		{"Negative to uint", map[string]any{"Age": -1}, "without losing precision"},
+		{"Wrong type", map[string]any{"Age": true}, "Field is a uint but the value is a bool"},
+		{"Wrong group", map[string]any{"Address": "123"}, "Group values must be maps"},
+		{"Too many occurrences", map[string]any{"Phones": []int64{1, 2, 3}}, "3 elements exceed the 2 occurrences"},
+		{"Empty text", map[string]any{"Age": ""}, "Empty text"},
+		{"Too long", map[string]any{"Address": map[string]any{"Country": "CAN"}}, "Failed to marshal field Address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := schema.Marshal(tt.values); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Marshal() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type schemaOfRecord struct {
+	Name     string          `flatfile:"1,3"`
+	Opened   time.Time       `flatfile:"4,8,fmt=20060102"`
+	Address  *marshalAddress `flatfile:"12,10"`
+	Phones   [2]uint16       `flatfile:"22,3"`
+	Tags     []string        `flatfile:"28,2,occurs=2"`
+	Grade    byte            `flatfile:"32,1,ovr=byte"`
+	Balance  float64         `flatfile:"33,4,enc=packed,dec=2,cond=1-3-AMY"`
+	Untagged string
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := SchemaOf(&schemaOfRecord{})
+	if err != nil {
+		t.Fatalf("SchemaOf() error = %v", err)
+	}
+	want := []SchemaField{
+		{Name: "Name", Column: 1, Length: 3, Type: "string"},
+		{Name: "Opened", Column: 4, Length: 8, Type: "time", Format: "20060102"},
+		{Name: "Address", Column: 12, Length: 10, Type: "group", Fields: []SchemaField{
+			{Name: "City", Column: 1, Length: 8, Type: "string"},
+			{Name: "Country", Column: 9, Length: 2, Type: "string"},
+		}},
+		{Name: "Phones", Column: 22, Length: 3, Type: "uint16", Occurs: 2},
+		{Name: "Tags", Column: 28, Length: 2, Type: "string", Occurs: 2},
+		{Name: "Grade", Column: 32, Length: 1, Type: "byte"},
+		{Name: "Balance", Column: 33, Length: 4, Type: "float64", Encoding: "packed", Decimals: 2, Condition: "1-3-AMY"},
+	}
+	exported, _ := json.Marshal(schema.Fields)
+	wantJSON, _ := json.Marshal(want)
+	if schema.Name != "schemaOfRecord" || string(exported) != string(wantJSON) {
+		t.Errorf("SchemaOf() = %s, want %s", exported, wantJSON)
+	}
+
+	original := schemaOfRecord{Name: "AMY", Opened: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Address: &marshalAddress{City: "TORONTO ", Country: "CA"},
+		Phones: [2]uint16{416, 905}, Tags: []string{"AB", "CD"}, Grade: 'A', Balance: -12.5}
+	data, err := Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := schema.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(values["Phones"], []uint16{416, 905}) || values["Balance"] != -12.5 || values["Grade"] != byte('A') {
+		t.Errorf("Unmarshal() = %v", values)
+	}
+	remarshalled, err := schema.Marshal(values)
+	if err != nil || !bytes.Equal(remarshalled, data) {
+		t.Errorf("Marshal() = %q, %v, want %q", remarshalled, err, data)
+	}
+
+	errorTests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"Not a struct", "x"},
+		{"Bad tag", struct {
+			A string `flatfile:"1"`
+		}{}},
+		{"Unsupported type", struct {
+			A map[string]string `flatfile:"1,1"`
+		}{}},
+		{"Bad nested tag", struct {
+			A struct {
+				B string `flatfile:"x,1"`
+			} `flatfile:"1,1"`
+		}{}},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SchemaOf(tt.v); err == nil {
+				t.Errorf("SchemaOf() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,661 @@
+/*Package copybook parses COBOL copybooks and computes the position of each data item in a record
+
+It supports level numbers 01 to 49, 77 and 88, PICTURE, USAGE, OCCURS, REDEFINES, VALUE and FILLER.
+Clauses which change the layout in ways flatfile cannot represent, such as OCCURS DEPENDING ON, SYNCHRONIZED
+and SIGN SEPARATE, are recorded in Item.Unsupported and reported by Copybook.Warnings.
+
+cmd/copybook2go uses this package to generate Go structs with flatfile tags.
+*/
+package copybook
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//Format is the source format of a copybook
+type Format int
+
+const (
+	//FixedFormat copybooks have a sequence area in columns 1 to 6, an indicator in column 7 and code in columns 8 to 72
+	FixedFormat Format = iota
+	//FreeFormat copybooks have code in any column. Comments start with *>
+	FreeFormat
+)
+
+//Usage is the storage format of a data item
+type Usage int
+
+const (
+	//Display items store one character per position
+	Display Usage = iota
+	//Binary items are COMP, COMP-4, COMP-5 and BINARY
+	Binary
+	//Packed items are COMP-3 and PACKED-DECIMAL
+	Packed
+	//Single items are COMP-1 floating point numbers
+	Single
+	//Double items are COMP-2 floating point numbers
+	Double
+	//Index items are USAGE INDEX
+	Index
+	//Pointer items are USAGE POINTER
+	Pointer
+	//NationalUsage items are USAGE NATIONAL
+	NationalUsage
+)
+
+var usages = map[string]Usage{
+	"DISPLAY":         Display,
+	"COMP":            Binary,
+	"COMPUTATIONAL":   Binary,
+	"COMP-4":          Binary,
+	"COMPUTATIONAL-4": Binary,
+	"COMP-5":          Binary,
+	"COMPUTATIONAL-5": Binary,
+	"BINARY":          Binary,
+	"COMP-3":          Packed,
+	"COMPUTATIONAL-3": Packed,
+	"PACKED-DECIMAL":  Packed,
+	"COMP-1":          Single,
+	"COMPUTATIONAL-1": Single,
+	"COMP-2":          Double,
+	"COMPUTATIONAL-2": Double,
+	"INDEX":           Index,
+	"POINTER":         Pointer,
+	"NATIONAL":        NationalUsage,
+}
+
+func (u Usage) String() string {
+	switch u {
+	case Binary:
+		return "COMP"
+	case Packed:
+		return "COMP-3"
+	case Single:
+		return "COMP-1"
+	case Double:
+		return "COMP-2"
+	case Index:
+		return "INDEX"
+	case Pointer:
+		return "POINTER"
+	case NationalUsage:
+		return "NATIONAL"
+	}
+	return "DISPLAY"
+}
+
+//Item is a data description entry
+type Item struct {
+	Level int
+	//Name is FILLER when the entry has no name
+	Name string
+	//Line is the line of the copybook where the entry starts
+	Line int
+	//Picture is nil for group items and items whose usage does not need one
+	Picture *Picture
+	Usage   Usage
+	//Occurs is the number of occurrences of the item, 0 when it has no OCCURS clause
+	Occurs int
+	//Redefines is the name of the item this item redefines
+	Redefines string
+	//SignLeading is true for SIGN LEADING items, which store the sign with the first digit
+	SignLeading bool
+	//SignSeparate is true for SIGN SEPARATE items, which store the sign in an extra character
+	SignSeparate bool
+	//Conditions are the level 88 condition names of the item
+	Conditions []Condition
+	Children   []*Item
+	//Offset is the zero based position of the item within its parent group
+	Offset int
+	//Size is the number of bytes of one occurrence of the item
+	Size int
+	//Unsupported describes clauses which cannot be represented by flatfile tags
+	Unsupported []string
+
+	usageSet bool
+}
+
+//Condition is a level 88 condition name
+type Condition struct {
+	Name string
+	//Values are the literals of the VALUE clause as written in the copybook
+	Values []string
+}
+
+//Group returns true when the item has subordinate items
+func (item *Item) Group() bool {
+	return len(item.Children) > 0
+}
+
+//Filler returns true when the item has no name
+func (item *Item) Filler() bool {
+	return item.Name == "FILLER"
+}
+
+//Copybook is a parsed copybook
+type Copybook struct {
+	//Records are the level 01 and 77 items
+	Records []*Item
+}
+
+//Warning describes a clause which cannot be represented by flatfile tags
+type Warning struct {
+	Line    int
+	Name    string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("line %d: %s: %s", w.Line, w.Name, w.Message)
+}
+
+//Warnings returns the unsupported clauses of all items in the order they appear
+func (c *Copybook) Warnings() []Warning {
+	var warnings []Warning
+	var walk func(items []*Item)
+	walk = func(items []*Item) {
+		for _, item := range items {
+			for _, message := range item.Unsupported {
+				warnings = append(warnings, Warning{Line: item.Line, Name: item.Name, Message: message})
+			}
+			walk(item.Children)
+		}
+	}
+	walk(c.Records)
+	return warnings
+}
+
+//Parse reads a copybook and computes the offset and size of every item
+//Entries without a level 01 item are grouped in a record named RECORD.
+func Parse(r io.Reader, format Format) (*Copybook, error) {
+	tokens, err := tokenize(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	cb := &Copybook{}
+	var stack []*Item
+	var last *Item
+	for len(tokens) > 0 {
+		end := 0
+		for end < len(tokens) && tokens[end].text != "." {
+			end++
+		}
+		entry := tokens[:end]
+		if end < len(tokens) {
+			end++
+		}
+		tokens = tokens[end:]
+
+		entry = skipDirectives(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		item, err := parseEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case item.Level == 88:
+			if last == nil {
+				return nil, errors.Errorf("copybook.Parse: line %d: Level 88 entry %s has no data item", item.Line, item.Name)
+			}
+			last.Conditions = append(last.Conditions, item.Conditions...)
+			continue
+		case item.Level == 66:
+			if len(stack) > 0 {
+				stack[0].Unsupported = append(stack[0].Unsupported, fmt.Sprintf("level 66 RENAMES entry %s on line %d is ignored", item.Name, item.Line))
+			}
+			continue
+		case item.Level == 77:
+			stack = nil
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].Level >= item.Level {
+			stack = stack[:len(stack)-1]
+		}
+		switch {
+		case len(stack) > 0:
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, item)
+		case item.Level == 1 || item.Level == 77:
+			cb.Records = append(cb.Records, item)
+		default:
+			record := &Item{Level: 1, Name: "RECORD", Line: item.Line}
+			record.Unsupported = append(record.Unsupported, "no level 01 item, entries are grouped in RECORD")
+			record.Children = []*Item{item}
+			cb.Records = append(cb.Records, record)
+			stack = []*Item{record}
+		}
+		stack = append(stack, item)
+		last = item
+	}
+
+	for _, record := range cb.Records {
+		if err := layout(record, Display, false); err != nil {
+			return nil, err
+		}
+	}
+	return cb, nil
+}
+
+//token is a word of a copybook and the line it appears on
+type token struct {
+	text string
+	line int
+}
+
+//tokenize splits the code of a copybook into words, literals and the periods ending entries
+func tokenize(r io.Reader, format Format) ([]token, error) {
+	var tokens []token
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		code := scanner.Text()
+		if format == FixedFormat {
+			if len(code) > 72 {
+				code = code[:72]
+			}
+			if len(code) < 7 {
+				continue
+			}
+			switch code[6] {
+			case '*', '/', 'D', 'd':
+				continue
+			}
+			code = code[7:]
+		} else if strings.HasPrefix(strings.TrimSpace(code), "*") {
+			continue
+		}
+		if i := strings.Index(code, "*>"); i >= 0 {
+			code = code[:i]
+		}
+
+		lineTokens, err := splitWords(code, line)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, lineTokens...)
+	}
+	return tokens, errors.Wrap(scanner.Err(), "copybook.Parse: Failed to read copybook")
+}
+
+//splitWords splits one line of code into tokens
+//Quoted literals are single tokens. A period at the end of a word ends the entry.
+func splitWords(code string, line int) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(code); {
+		c := code[i]
+		if c == ' ' || c == '\t' || ((c == ',' || c == ';') && (i+1 == len(code) || code[i+1] == ' ')) {
+			i++
+			continue
+		}
+
+		start := i
+		if c == '\'' || c == '"' {
+			end := strings.IndexByte(code[i+1:], c)
+			if end < 0 {
+				return nil, errors.Errorf("copybook.Parse: line %d: Unterminated literal %s", line, code[i:])
+			}
+			i += end + 2
+		} else {
+			for i < len(code) && code[i] != ' ' && code[i] != '\t' {
+				i++
+			}
+		}
+
+		word := code[start:i]
+		if strings.HasSuffix(word, ".") && word[0] != '\'' && word[0] != '"' || word == "." {
+			if len(word) > 1 {
+				tokens = append(tokens, token{text: word[:len(word)-1], line: line})
+			}
+			tokens = append(tokens, token{text: ".", line: line})
+			continue
+		}
+		tokens = append(tokens, token{text: word, line: line})
+	}
+	return tokens, nil
+}
+
+//skipDirectives removes listing directives, which are not ended by a period, from the start of an entry
+func skipDirectives(entry []token) []token {
+	for len(entry) > 0 {
+		switch strings.ToUpper(entry[0].text) {
+		case "EJECT", "SKIP1", "SKIP2", "SKIP3":
+			entry = entry[1:]
+		default:
+			return entry
+		}
+	}
+	return entry
+}
+
+//keywords are the words starting a clause of a data description entry
+var keywords = map[string]bool{
+	"PIC": true, "PICTURE": true, "USAGE": true, "OCCURS": true, "REDEFINES": true, "VALUE": true, "VALUES": true,
+	"SIGN": true, "LEADING": true, "TRAILING": true, "SYNC": true, "SYNCHRONIZED": true, "JUST": true, "JUSTIFIED": true,
+	"BLANK": true, "GLOBAL": true, "EXTERNAL": true, "RENAMES": true, "ASCENDING": true, "DESCENDING": true, "INDEXED": true,
+}
+
+//isKeyword returns true when word starts a clause
+func isKeyword(word string) bool {
+	_, usage := usages[word]
+	return keywords[word] || usage
+}
+
+//parseEntry parses the tokens of one data description entry
+func parseEntry(entry []token) (*Item, error) {
+	line := entry[0].line
+	level, err := strconv.Atoi(entry[0].text)
+	if err != nil || level < 1 || (level > 49 && level != 66 && level != 77 && level != 88) {
+		return nil, errors.Errorf("copybook.Parse: line %d: Expected a level number but got %s", line, entry[0].text)
+	}
+
+	item := &Item{Level: level, Name: "FILLER", Line: line}
+	words := make([]string, 0, len(entry)-1)
+	for _, t := range entry[1:] {
+		words = append(words, t.text)
+	}
+	if len(words) > 0 && !isKeyword(strings.ToUpper(words[0])) {
+		item.Name = strings.ToUpper(words[0])
+		words = words[1:]
+	}
+	if level == 88 {
+		return parseConditionEntry(item, words)
+	}
+
+	clauses := &clauseParser{item: item, words: words}
+	if err := clauses.parse(); err != nil {
+		return nil, errors.Wrapf(err, "copybook.Parse: line %d: %s", line, item.Name)
+	}
+	return item, nil
+}
+
+//parseConditionEntry parses a level 88 entry into a condition attached to the previous item
+func parseConditionEntry(item *Item, words []string) (*Item, error) {
+	if len(words) == 0 || (strings.ToUpper(words[0]) != "VALUE" && strings.ToUpper(words[0]) != "VALUES") {
+		return nil, errors.Errorf("copybook.Parse: line %d: Level 88 entry %s has no VALUE clause", item.Line, item.Name)
+	}
+	values := words[1:]
+	if len(values) > 0 && (strings.ToUpper(values[0]) == "IS" || strings.ToUpper(values[0]) == "ARE") {
+		values = values[1:]
+	}
+	item.Conditions = []Condition{{Name: item.Name, Values: values}}
+	return item, nil
+}
+
+//clauseParser parses the clauses of a data description entry
+type clauseParser struct {
+	item  *Item
+	words []string
+}
+
+//next returns the next word in upper case, or an empty string at the end of the entry
+func (p *clauseParser) next() string {
+	if len(p.words) == 0 {
+		return ""
+	}
+	word := p.words[0]
+	p.words = p.words[1:]
+	return strings.ToUpper(word)
+}
+
+//peek returns the next word in upper case without consuming it
+func (p *clauseParser) peek() string {
+	if len(p.words) == 0 {
+		return ""
+	}
+	return strings.ToUpper(p.words[0])
+}
+
+//optional consumes the next word when it is one of words
+func (p *clauseParser) optional(words ...string) bool {
+	for _, word := range words {
+		if p.peek() == word {
+			p.next()
+			return true
+		}
+	}
+	return false
+}
+
+//names consumes words up to the next clause
+func (p *clauseParser) names() {
+	for len(p.words) > 0 && !isKeyword(p.peek()) {
+		p.next()
+	}
+}
+
+func (p *clauseParser) unsupported(format string, args ...interface{}) {
+	p.item.Unsupported = append(p.item.Unsupported, fmt.Sprintf(format, args...))
+}
+
+func (p *clauseParser) parse() error {
+	item := p.item
+	for len(p.words) > 0 {
+		word := p.next()
+		if usage, ok := usages[word]; ok {
+			item.Usage, item.usageSet = usage, true
+			continue
+		}
+
+		switch word {
+		case "PIC", "PICTURE":
+			p.optional("IS")
+			if len(p.words) == 0 {
+				return errors.New("Missing picture string")
+			}
+			pic, err := ParsePicture(p.words[0])
+			if err != nil {
+				return err
+			}
+			p.words = p.words[1:]
+			item.Picture = &pic
+		case "USAGE":
+			p.optional("IS")
+			usage, ok := usages[p.peek()]
+			if !ok {
+				return errors.Errorf("Invalid usage %s", p.peek())
+			}
+			p.next()
+			item.Usage, item.usageSet = usage, true
+		case "OCCURS":
+			if err := p.parseOccurs(); err != nil {
+				return err
+			}
+		case "REDEFINES":
+			item.Redefines = p.next()
+			if item.Redefines == "" {
+				return errors.New("Missing name after REDEFINES")
+			}
+		case "VALUE", "VALUES":
+			p.optional("IS", "ARE")
+			p.optional("ALL")
+			if p.next() == "" {
+				return errors.New("Missing literal after VALUE")
+			}
+		case "SIGN", "LEADING", "TRAILING":
+			if word == "SIGN" {
+				p.optional("IS")
+				word = p.next()
+			}
+			if word != "LEADING" && word != "TRAILING" {
+				return errors.Errorf("Expected LEADING or TRAILING after SIGN but got %s", word)
+			}
+			item.SignLeading = word == "LEADING"
+			separate := p.optional("SEPARATE")
+			if separate {
+				p.optional("CHARACTER")
+				item.SignSeparate = true
+			}
+			if separate {
+				p.unsupported("SIGN %s SEPARATE is not supported, the item is read as text", word)
+			} else if word == "LEADING" {
+				p.unsupported("SIGN LEADING is not supported, the item is read as text")
+			}
+		case "SYNC", "SYNCHRONIZED":
+			p.optional("LEFT", "RIGHT")
+			p.unsupported("%s is not supported, alignment bytes are not added to the layout", word)
+		case "JUST", "JUSTIFIED":
+			p.optional("RIGHT")
+		case "BLANK":
+			p.optional("WHEN")
+			if !p.optional("ZERO", "ZEROS", "ZEROES") {
+				return errors.New("Expected ZERO after BLANK WHEN")
+			}
+		case "GLOBAL", "EXTERNAL":
+		default:
+			p.unsupported("unknown clause %s is ignored", word)
+		}
+	}
+	return nil
+}
+
+//parseOccurs parses the clause following OCCURS
+func (p *clauseParser) parseOccurs() error {
+	occurs, err := strconv.Atoi(p.next())
+	if err != nil || occurs < 1 {
+		return errors.New("Invalid OCCURS count")
+	}
+	if p.optional("TO") {
+		maximum, err := strconv.Atoi(p.next())
+		if err != nil || maximum < occurs {
+			return errors.New("Invalid OCCURS TO count")
+		}
+		occurs = maximum
+	}
+	p.optional("TIMES")
+	if p.optional("DEPENDING") {
+		p.optional("ON")
+		p.unsupported("OCCURS DEPENDING ON %s is not supported, the maximum of %d occurrences is used", p.next(), occurs)
+	}
+	for {
+		switch {
+		case p.optional("ASCENDING", "DESCENDING"):
+			p.optional("KEY")
+			p.optional("IS")
+			p.names()
+		case p.optional("INDEXED"):
+			p.optional("BY")
+			p.names()
+		default:
+			p.item.Occurs = occurs
+			return nil
+		}
+	}
+}
+
+//layout computes the size of item and the offsets of its children
+//usage is the usage of the enclosing group, which applies to items without a USAGE clause.
+func layout(item *Item, usage Usage, usageSet bool) error {
+	if !item.usageSet && usageSet {
+		item.Usage, item.usageSet = usage, true
+	}
+
+	if !item.Group() {
+		size, err := storageSize(item)
+		if err != nil {
+			return err
+		}
+		item.Size = size
+		return nil
+	}
+
+	if item.Picture != nil {
+		return errors.Errorf("copybook.Parse: line %d: Group item %s cannot have a PICTURE clause", item.Line, item.Name)
+	}
+	position := 0
+	for i, child := range item.Children {
+		if err := layout(child, item.Usage, item.usageSet); err != nil {
+			return err
+		}
+		child.Offset = position
+		if child.Redefines != "" {
+			redefined := sibling(item.Children[:i], child.Redefines)
+			if redefined == nil {
+				return errors.Errorf("copybook.Parse: line %d: %s redefines %s which is not a preceding item of the same group", child.Line, child.Name, child.Redefines)
+			}
+			child.Offset = redefined.Offset
+		}
+		if end := child.Offset + child.Size*occurrences(child); end > position {
+			position = end
+		}
+	}
+	item.Size = position
+	return nil
+}
+
+//sibling returns the last item named name
+func sibling(items []*Item, name string) *Item {
+	for i := len(items) - 1; i >= 0; i-- {
+		if items[i].Name == name {
+			return items[i]
+		}
+	}
+	return nil
+}
+
+//occurrences returns the number of times an item repeats
+func occurrences(item *Item) int {
+	if item.Occurs > 0 {
+		return item.Occurs
+	}
+	return 1
+}
+
+//storageSize returns the number of bytes of an elementary item
+func storageSize(item *Item) (int, error) {
+	pic := item.Picture
+	switch item.Usage {
+	case Single, Index:
+		item.Unsupported = append(item.Unsupported, fmt.Sprintf("USAGE %s is not supported, the item is read as text", item.Usage))
+		return 4, nil
+	case Double:
+		item.Unsupported = append(item.Unsupported, "USAGE COMP-2 is not supported, the item is read as text")
+		return 8, nil
+	case Pointer:
+		item.Unsupported = append(item.Unsupported, "USAGE POINTER is not supported, the item is read as text")
+		return 8, nil
+	}
+
+	if pic == nil {
+		return 0, errors.Errorf("copybook.Parse: line %d: Elementary item %s has no PICTURE clause", item.Line, item.Name)
+	}
+	if pic.Scaling > 0 {
+		item.Unsupported = append(item.Unsupported, "P scaling positions are not supported, the value is read without them")
+	}
+	if pic.Category == National || item.Usage == NationalUsage {
+		item.Unsupported = append(item.Unsupported, "national items are not supported, the item is read as text")
+		return 2 * pic.Size, nil
+	}
+
+	switch item.Usage {
+	case Packed, Binary:
+		if pic.Category != Numeric {
+			return 0, errors.Errorf("copybook.Parse: line %d: %s item %s must have a numeric picture", item.Line, item.Usage, item.Name)
+		}
+		if item.Usage == Packed {
+			return pic.Digits/2 + 1, nil
+		}
+		switch {
+		case pic.Digits <= 4:
+			return 2, nil
+		case pic.Digits <= 9:
+			return 4, nil
+		}
+		return 8, nil
+	}
+	if item.SignSeparate {
+		return pic.Size + 1, nil
+	}
+	return pic.Size, nil
+}
@@ -0,0 +1,157 @@
+package copybook
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//itemLayout is the position of an item used to compare parse results
+type itemLayout struct {
+	Name   string
+	Offset int
+	Size   int
+	Occurs int
+	Usage  Usage
+}
+
+func layouts(items []*Item) []itemLayout {
+	var result []itemLayout
+	for _, item := range items {
+		result = append(result, itemLayout{item.Name, item.Offset, item.Size, item.Occurs, item.Usage})
+	}
+	return result
+}
+
+func TestParse(t *testing.T) {
+	file, err := os.Open("../internal/copybooktest/customer.cpy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	cb, err := Parse(file, FixedFormat)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(cb.Records) != 2 {
+		t.Fatalf("Parse() got %d records, want 2", len(cb.Records))
+	}
+
+	record := cb.Records[0]
+	if record.Name != "CUSTOMER-RECORD" || record.Size != 105 || record.Line != 4 {
+		t.Errorf("Parse() record = %s size %d line %d", record.Name, record.Size, record.Line)
+	}
+	want := []itemLayout{
+		{"CUST-ID", 0, 6, 0, Display},
+		{"CUST-NAME", 6, 25, 0, Display},
+		{"CUST-STATUS", 31, 1, 0, Display},
+		{"BALANCE", 32, 5, 0, Packed},
+		{"CREDIT-LIMIT", 37, 7, 0, Display},
+		{"VISITS", 44, 2, 0, Binary},
+		{"ADJUSTMENT", 46, 4, 0, Display},
+		{"FILLER", 50, 2, 0, Display},
+		{"PHONES", 52, 11, 2, Display},
+		{"OPENED-DATE", 74, 8, 0, Display},
+		{"OPENED-PARTS", 74, 8, 0, Display},
+		{"TOTALS", 82, 9, 0, Packed},
+		{"PRINT-AMOUNT", 91, 10, 0, Display},
+		{"LEGACY-FLAGS", 101, 4, 0, Display},
+	}
+	if got := layouts(record.Children); !reflect.DeepEqual(got[:len(want)], want) {
+		t.Errorf("Parse() layout = %+v, want %+v", got, want)
+	}
+
+	totals := record.Children[11]
+	if got := layouts(totals.Children); !reflect.DeepEqual(got, []itemLayout{{"ORDER-COUNT", 0, 3, 0, Packed}, {"ORDER-TOTAL", 3, 6, 0, Packed}}) {
+		t.Errorf("Parse() TOTALS = %+v", got)
+	}
+	status := record.Children[2]
+	if !reflect.DeepEqual(status.Conditions, []Condition{{"STATUS-ACTIVE", []string{"'A'"}}, {"STATUS-CLOSED", []string{"'C'", "'X'"}}}) {
+		t.Errorf("Parse() conditions = %+v", status.Conditions)
+	}
+	if balance := record.Children[3].Picture; balance.Digits != 9 || balance.Scale != 2 || !balance.Signed {
+		t.Errorf("Parse() BALANCE picture = %+v", balance)
+	}
+
+	trailer := cb.Records[1]
+	if trailer.Redefines != "CUSTOMER-RECORD" || trailer.Size != 10 {
+		t.Errorf("Parse() trailer = %+v", trailer)
+	}
+
+	warnings := cb.Warnings()
+	if len(warnings) != 1 || warnings[0].Name != "LEGACY-FLAGS" || warnings[0].Line != 29 {
+		t.Errorf("Warnings() = %v", warnings)
+	}
+}
+
+func TestParseFreeFormat(t *testing.T) {
+	src := `*> free format copybook
+05 ITEM-CODE PIC X(4). *> no level 01
+05 QUANTITY PIC 9(3) USAGE IS COMPUTATIONAL-5.
+05 ITEMS OCCURS 1 TO 5 TIMES DEPENDING ON QUANTITY INDEXED BY ITEM-IDX.
+   10 ITEM-PRICE PIC S9(5)V99 SIGN IS LEADING SEPARATE CHARACTER.
+66 ALIAS RENAMES ITEM-CODE.
+05 AMOUNT PIC 9(20).
+EJECT
+05 RATE COMP-2.
+`
+	cb, err := Parse(strings.NewReader(src), FreeFormat)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(cb.Records) != 1 || cb.Records[0].Name != "RECORD" {
+		t.Fatalf("Parse() records = %+v", cb.Records)
+	}
+	want := []itemLayout{
+		{"ITEM-CODE", 0, 4, 0, Display},
+		{"QUANTITY", 4, 2, 0, Binary},
+		{"ITEMS", 6, 8, 5, Display},
+		{"AMOUNT", 46, 20, 0, Display},
+		{"RATE", 66, 8, 0, Double},
+	}
+	if got := layouts(cb.Records[0].Children); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() layout = %+v, want %+v", got, want)
+	}
+
+	var messages []string
+	for _, warning := range cb.Warnings() {
+		messages = append(messages, warning.Name+": "+warning.Message)
+	}
+	wantMessages := []string{
+		"RECORD: no level 01 item, entries are grouped in RECORD",
+		"RECORD: level 66 RENAMES entry ALIAS on line 6 is ignored",
+		"ITEMS: OCCURS DEPENDING ON QUANTITY is not supported, the maximum of 5 occurrences is used",
+		"ITEM-PRICE: SIGN LEADING SEPARATE is not supported, the item is read as text",
+		"RATE: USAGE COMP-2 is not supported, the item is read as text",
+	}
+	if !reflect.DeepEqual(messages, wantMessages) {
+		t.Errorf("Warnings() = %q, want %q", messages, wantMessages)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{"Level number", "01 REC.\n05 A PIC X.\nA PIC X.", "Expected a level number"},
+		{"Missing picture", "01 REC.\n05 A.", "has no PICTURE clause"},
+		{"Invalid picture", "01 REC.\n05 A PIC X(.", "Missing )"},
+		{"Unknown redefines", "01 REC.\n05 A PIC X.\n05 B REDEFINES C PIC X.", "redefines C"},
+		{"Packed text", "01 REC.\n05 A PIC X(3) COMP-3.", "must have a numeric picture"},
+		{"Unterminated literal", "01 REC.\n05 A PIC X VALUE 'A.", "Unterminated literal"},
+		{"Condition without item", "88 ON VALUE 'Y'.", "has no data item"},
+		{"Invalid usage", "01 REC.\n05 A PIC 9 USAGE IS FAST.", "Invalid usage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.src), FreeFormat)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
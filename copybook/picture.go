@@ -0,0 +1,127 @@
+package copybook
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//Category is the class of data described by a PICTURE clause
+type Category int
+
+const (
+	//Alphanumeric items hold text: PIC X and PIC A
+	Alphanumeric Category = iota
+	//Numeric items hold digits with an optional sign and implied decimal point: PIC S9V9
+	Numeric
+	//Edited items hold numbers formatted for printing: PIC ZZ9.99, PIC $,$$9.99CR
+	Edited
+	//National items hold UTF-16 text: PIC N and PIC G
+	National
+)
+
+func (c Category) String() string {
+	switch c {
+	case Numeric:
+		return "numeric"
+	case Edited:
+		return "edited"
+	case National:
+		return "national"
+	}
+	return "alphanumeric"
+}
+
+//Picture is a parsed PICTURE clause
+type Picture struct {
+	//Text is the picture string as written in the copybook
+	Text     string
+	Category Category
+	//Size is the number of character positions of the item when its usage is DISPLAY
+	Size int
+	//Digits is the number of digit positions of a numeric item, including Scale
+	Digits int
+	//Scale is the number of digits after the implied decimal point V
+	Scale int
+	//Signed is true when the picture starts with S
+	Signed bool
+	//Scaling is the number of P positions, which assume zeros that are not stored
+	Scaling int
+}
+
+//ParsePicture parses a PICTURE character string such as X(10), S9(7)V99 or ZZ,ZZ9.99-
+func ParsePicture(text string) (Picture, error) {
+	pic := Picture{Text: text, Category: Numeric}
+	upper := strings.ToUpper(text)
+	alphanumeric, edited, national, decimalPoint := false, false, false, false
+
+	for i := 0; i < len(upper); {
+		symbol := upper[i : i+1]
+		if strings.HasPrefix(upper[i:], "CR") || strings.HasPrefix(upper[i:], "DB") {
+			symbol = upper[i : i+2]
+		}
+		i += len(symbol)
+
+		count := 1
+		if i < len(upper) && upper[i] == '(' {
+			end := strings.IndexByte(upper[i:], ')')
+			if end < 0 {
+				return Picture{}, errors.Errorf("copybook.ParsePicture: Missing ) in picture %s", text)
+			}
+			n, err := strconv.Atoi(upper[i+1 : i+end])
+			if err != nil || n < 1 {
+				return Picture{}, errors.Errorf("copybook.ParsePicture: Invalid repeat count in picture %s", text)
+			}
+			count = n
+			i += end + 1
+		}
+
+		switch symbol {
+		case "X", "A":
+			alphanumeric = true
+			pic.Size += count
+		case "N", "G":
+			national = true
+			pic.Size += count
+		case "9":
+			pic.Digits += count
+			pic.Size += count
+			if decimalPoint {
+				pic.Scale += count
+			}
+		case "S":
+			if i != 1 || count != 1 {
+				return Picture{}, errors.Errorf("copybook.ParsePicture: S must be the first symbol of picture %s", text)
+			}
+			pic.Signed = true
+		case "V":
+			if decimalPoint || count != 1 {
+				return Picture{}, errors.Errorf("copybook.ParsePicture: More than one V in picture %s", text)
+			}
+			decimalPoint = true
+		case "P":
+			pic.Scaling += count
+		case "Z", "*", "+", "-", "$", ".", ",", "B", "0", "/", "CR", "DB":
+			edited = true
+			pic.Size += count * len(symbol)
+		default:
+			return Picture{}, errors.Errorf("copybook.ParsePicture: Invalid symbol %s in picture %s", symbol, text)
+		}
+	}
+
+	switch {
+	case pic.Size == 0:
+		return Picture{}, errors.Errorf("copybook.ParsePicture: Picture %s has no character positions", text)
+	case national:
+		pic.Category = National
+	case alphanumeric:
+		pic.Category = Alphanumeric
+	case edited:
+		pic.Category = Edited
+	}
+	if pic.Category != Numeric {
+		pic.Digits, pic.Scale, pic.Signed, pic.Scaling = 0, 0, false, 0
+	}
+	return pic, nil
+}
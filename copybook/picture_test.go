@@ -0,0 +1,40 @@
+package copybook
+
+import "testing"
+
+func TestParsePicture(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    Picture
+		wantErr bool
+	}{
+		{"X(10)", Picture{Category: Alphanumeric, Size: 10}, false},
+		{"xxx", Picture{Category: Alphanumeric, Size: 3}, false},
+		{"A(2)X", Picture{Category: Alphanumeric, Size: 3}, false},
+		{"9(3)", Picture{Category: Numeric, Size: 3, Digits: 3}, false},
+		{"S9(7)V99", Picture{Category: Numeric, Size: 9, Digits: 9, Scale: 2, Signed: true}, false},
+		{"SV9(3)", Picture{Category: Numeric, Size: 3, Digits: 3, Scale: 3, Signed: true}, false},
+		{"99PPP", Picture{Category: Numeric, Size: 2, Digits: 2, Scaling: 3}, false},
+		{"ZZ,ZZ9.99CR", Picture{Category: Edited, Size: 11}, false},
+		{"$(4)9.99-", Picture{Category: Edited, Size: 9}, false},
+		{"N(5)", Picture{Category: National, Size: 5}, false},
+		{"9(0)", Picture{}, true},
+		{"9(3", Picture{}, true},
+		{"9S", Picture{}, true},
+		{"9V9V9", Picture{}, true},
+		{"Q", Picture{}, true},
+		{"S", Picture{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParsePicture(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePicture() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			tt.want.Text = tt.text
+			if err == nil && got != tt.want {
+				t.Errorf("ParsePicture() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
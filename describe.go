@@ -0,0 +1,186 @@
+package flatfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*Layout describes the record layout defined by the flatfile tags of a struct
+
+Nested structs are described by a group field followed by their own fields, named with the path from the record, for example Address.City.
+Columns are absolute positions in the record. The fields of a group which occurs more than once are described at their first occurrence.
+*/
+type Layout struct {
+	Name   string      `json:"name"`
+	Length int         `json:"length"`
+	Fields []FieldSpec `json:"fields"`
+}
+
+//FieldSpec describes one field of a Layout
+type FieldSpec struct {
+	Name  string `json:"name"`
+	Start int    `json:"start"`
+	//End is the last column of the field, including all occurrences
+	End int `json:"end"`
+	//Length is the length of one occurrence
+	Length int `json:"length"`
+	//Type is the Go type of one occurrence, or group for a nested struct
+	Type string `json:"type"`
+	//Occurs is zero for fields which do not repeat
+	Occurs int `json:"occurs,omitempty"`
+	//Condition is in the form col-len-value or an expression, with absolute columns
+	Condition string `json:"condition,omitempty"`
+	Encoding  string `json:"encoding,omitempty"`
+	Decimals  int    `json:"decimals,omitempty"`
+	Format    string `json:"format,omitempty"`
+}
+
+//DescribeLayout returns the layout of the struct or pointer to struct v
+func DescribeLayout(v interface{}) (*Layout, error) {
+	vType := reflect.TypeOf(v)
+	if vType != nil && vType.Kind() == reflect.Ptr {
+		vType = vType.Elem()
+	}
+	if vType == nil || vType.Kind() != reflect.Struct {
+		return nil, errors.Errorf("flatfile.DescribeLayout: %s is not a struct or pointer to struct", reflect.TypeOf(v))
+	}
+	length, err := recordLength(vType)
+	if err != nil {
+		return nil, errors.Wrap(err, "flatfile.DescribeLayout: Failed to describe layout")
+	}
+	layout := &Layout{Name: vType.Name(), Length: length}
+	if err := layout.describe(vType, "", 0); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+//describe appends the fields of struct type vType, whose first column is at offset+1 in the record
+func (l *Layout) describe(vType reflect.Type, prefix string, offset int) error {
+	for i, field := range layoutOf(vType).fields {
+		//the fields of untagged embedded structs are described as fields of vType
+		if field.embedded {
+			if err := l.describe(embeddedStruct(vType.Field(i).Type), prefix, offset); err != nil {
+				return err
+			}
+		}
+		if !field.tagged {
+			continue
+		}
+		structField := vType.Field(i)
+		if field.tagErr != nil {
+			return errors.Wrapf(field.tagErr, "flatfile.DescribeLayout: Failed to parse tag of field %s%s", prefix, structField.Name)
+		}
+		ffpTag := &field.tag
+
+		elemType := structField.Type
+		for elemType.Kind() == reflect.Ptr || elemType.Kind() == reflect.Array || elemType.Kind() == reflect.Slice {
+			elemType = elemType.Elem()
+		}
+		spec := FieldSpec{
+			Name:     prefix + structField.Name,
+			Start:    offset + ffpTag.col,
+			End:      offset + ffpTag.col - 1 + ffpTag.length*occurrences(ffpTag, structField.Type),
+			Length:   ffpTag.length,
+			Type:     elemType.String(),
+			Encoding: ffpTag.encoding,
+			Decimals: ffpTag.decimals,
+			Format:   ffpTag.format,
+		}
+		if ffpTag.occurs > 0 || structField.Type.Kind() == reflect.Array {
+			spec.Occurs = occurrences(ffpTag, structField.Type)
+		}
+		if ffpTag.override != "" {
+			spec.Type = ffpTag.override
+		}
+		if ffpTag.condChk {
+			spec.Condition = fmt.Sprintf("%d-%d-%s", offset+ffpTag.condCol, ffpTag.condLen, ffpTag.condVal)
+		}
+		if ffpTag.cond != nil {
+			spec.Condition = ffpTag.cond.format(offset)
+		}
+		group := elemType.Kind() == reflect.Struct && !(elemType == timeType && ffpTag.format != "")
+		if group {
+			spec.Type = "group"
+		}
+		l.Fields = append(l.Fields, spec)
+
+		if group {
+			if err := l.describe(elemType, spec.Name+".", spec.Start-1); err != nil {
+				return err
+			}
+		}
+		if ffpTag.union {
+			l.describeVariants(elemType, spec.Name+".", ffpTag.disc, offset)
+		}
+	}
+	return nil
+}
+
+//describeVariants sets the condition of the variants of a union of type unionType to the values of the discriminator selecting them
+func (l *Layout) describeVariants(unionType reflect.Type, prefix string, disc *condition, offset int) {
+	for i, member := range layoutOf(unionType).fields {
+		name := prefix + unionType.Field(i).Name
+		for j := range l.Fields {
+			if member.tagged && l.Fields[j].Name == name {
+				l.Fields[j].Condition = variantCondition(disc, member.tag.variants).format(offset)
+			}
+		}
+	}
+}
+
+//specColumns are the headings of the Markdown and CSV specs
+var specColumns = []string{"Field", "Start", "End", "Length", "Type", "Occurs", "Condition", "Encoding", "Decimals", "Format"}
+
+//row returns the values of a field in the order of specColumns
+func (f *FieldSpec) row() []string {
+	optional := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	return []string{f.Name, strconv.Itoa(f.Start), strconv.Itoa(f.End), strconv.Itoa(f.Length), f.Type, optional(f.Occurs), f.Condition, f.Encoding, optional(f.Decimals), f.Format}
+}
+
+//WriteMarkdown writes the layout as a Markdown table preceded by a heading with its name and length
+func (l *Layout) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\nRecord length: %d\n\n", l.Name, l.Length)
+	b.WriteString("| " + strings.Join(specColumns, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(specColumns)) + "\n")
+	for i := range l.Fields {
+		row := l.Fields[i].row()
+		for j := range row {
+			row[j] = strings.ReplaceAll(row[j], "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return errors.Wrap(err, "flatfile.Layout.WriteMarkdown: Failed to write layout")
+}
+
+//WriteCSV writes the layout as CSV with a header row
+func (l *Layout) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write(specColumns)
+	for i := range l.Fields {
+		writer.Write(l.Fields[i].row())
+	}
+	writer.Flush()
+	return errors.Wrap(writer.Error(), "flatfile.Layout.WriteCSV: Failed to write layout")
+}
+
+//WriteJSON writes the layout as indented JSON
+func (l *Layout) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errors.Wrap(encoder.Encode(l), "flatfile.Layout.WriteJSON: Failed to write layout")
+}
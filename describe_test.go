@@ -0,0 +1,130 @@
+package flatfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type describeAddress struct {
+	City    string `flatfile:"1,8"`
+	Country string `flatfile:"9,2"`
+}
+
+type describeItem struct {
+	Code  string `flatfile:"1,2"`
+	Count int    `flatfile:"3,3,cond=1-2-AB"`
+}
+
+type describeRecord struct {
+	Name     string           `flatfile:"1,5"`
+	Opened   time.Time        `flatfile:"6,8,fmt=20060102"`
+	Address  *describeAddress `flatfile:"14,10"`
+	Phones   [2]uint16        `flatfile:"24,3"`
+	Items    []describeItem   `flatfile:"30,5,occurs=2"`
+	Balance  float64          `flatfile:"40,4,enc=packed,dec=2"`
+	Grade    byte             `flatfile:"44,1,ovr=byte"`
+	Bonus    int8             `flatfile:"45,2,cond=1-1-A"`
+	Comment  string
+	internal string `flatfile:"47,3"`
+}
+
+func TestDescribeLayout(t *testing.T) {
+	want := &Layout{Name: "describeRecord", Length: 49, Fields: []FieldSpec{
+		{Name: "Name", Start: 1, End: 5, Length: 5, Type: "string"},
+		{Name: "Opened", Start: 6, End: 13, Length: 8, Type: "time.Time", Format: "20060102"},
+		{Name: "Address", Start: 14, End: 23, Length: 10, Type: "group"},
+		{Name: "Address.City", Start: 14, End: 21, Length: 8, Type: "string"},
+		{Name: "Address.Country", Start: 22, End: 23, Length: 2, Type: "string"},
+		{Name: "Phones", Start: 24, End: 29, Length: 3, Type: "uint16", Occurs: 2},
+		{Name: "Items", Start: 30, End: 39, Length: 5, Type: "group", Occurs: 2},
+		{Name: "Items.Code", Start: 30, End: 31, Length: 2, Type: "string"},
+		{Name: "Items.Count", Start: 32, End: 34, Length: 3, Type: "int", Condition: "30-2-AB"},
+		{Name: "Balance", Start: 40, End: 43, Length: 4, Type: "float64", Encoding: "packed", Decimals: 2},
+		{Name: "Grade", Start: 44, End: 44, Length: 1, Type: "byte"},
+		{Name: "Bonus", Start: 45, End: 46, Length: 2, Type: "int8", Condition: "1-1-A"},
+		{Name: "internal", Start: 47, End: 49, Length: 3, Type: "string"},
+	}}
+
+	for _, v := range []interface{}{describeRecord{}, &describeRecord{}} {
+		got, err := DescribeLayout(v)
+		if err != nil {
+			t.Fatalf("DescribeLayout() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DescribeLayout() = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestDescribeLayoutErrors(t *testing.T) {
+	type badTag struct {
+		Name string `flatfile:"1"`
+	}
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"Not a struct", 1},
+		{"Nil", nil},
+		{"Bad tag", badTag{}},
+		{"Bad nested tag", struct {
+			Nested badTag `flatfile:"1,1"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DescribeLayout(tt.v); err == nil {
+				t.Errorf("DescribeLayout() expected error")
+			}
+		})
+	}
+}
+
+func TestLayoutWrite(t *testing.T) {
+	layout := &Layout{Name: "customer", Length: 8, Fields: []FieldSpec{
+		{Name: "Name", Start: 1, End: 3, Length: 3, Type: "string"},
+		{Name: "Amount", Start: 4, End: 8, Length: 5, Type: "int64", Occurs: 1, Condition: "1-1-|", Encoding: "zoned"},
+	}}
+
+	var markdown bytes.Buffer
+	if err := layout.WriteMarkdown(&markdown); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	wantMarkdown := `## customer
+
+Record length: 8
+
+| Field | Start | End | Length | Type | Occurs | Condition | Encoding | Decimals | Format |
+| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |
+| Name | 1 | 3 | 3 | string |  |  |  |  |  |
+| Amount | 4 | 8 | 5 | int64 | 1 | 1-1-\| | zoned |  |  |
+`
+	if markdown.String() != wantMarkdown {
+		t.Errorf("WriteMarkdown() = %s, want %s", markdown.String(), wantMarkdown)
+	}
+
+	var csv bytes.Buffer
+	if err := layout.WriteCSV(&csv); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	wantCSV := "Field,Start,End,Length,Type,Occurs,Condition,Encoding,Decimals,Format\nName,1,3,3,string,,,,,\nAmount,4,8,5,int64,1,1-1-|,zoned,,\n"
+	if csv.String() != wantCSV {
+		t.Errorf("WriteCSV() = %q, want %q", csv.String(), wantCSV)
+	}
+
+	var jsonSpec bytes.Buffer
+	if err := layout.WriteJSON(&jsonSpec); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if !strings.Contains(jsonSpec.String(), `"encoding": "zoned"`) || strings.Contains(jsonSpec.String(), `"format"`) {
+		t.Errorf("WriteJSON() = %s", jsonSpec.String())
+	}
+	var decoded Layout
+	if err := json.Unmarshal(jsonSpec.Bytes(), &decoded); err != nil || !reflect.DeepEqual(&decoded, layout) {
+		t.Errorf("json.Unmarshal(WriteJSON()) = %+v, %v", decoded, err)
+	}
+}
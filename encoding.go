@@ -0,0 +1,343 @@
+package flatfile
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+//The functions in this file convert the numeric storage formats used by COBOL programs.
+//They are selected with the enc (encoding) tag option:
+// display  digits with an optional leading sign, the default
+// zoned    digits with the sign overpunched on the last digit, PIC S9 DISPLAY
+// packed   two digits per byte with the sign in the last half byte, COMP-3
+// binary   big endian two's complement, COMP, COMP-4 and COMP-5
+// ubinary  big endian unsigned, COMP of an unsigned PIC 9
+//The dec (decimals) tag option gives the number of implied decimal places, PIC 9V99, and requires a float field.
+
+//maxDecimalDigits is the number of digits which always fit in an int64
+const maxDecimalDigits = 18
+
+//decimal is a decoded number before it is scaled and assigned to a field
+type decimal struct {
+	magnitude uint64
+	negative  bool
+}
+
+//int64 returns the value of d, or an error if it does not fit in an int64
+func (d decimal) int64() (int64, error) {
+	if d.negative {
+		if d.magnitude > 1<<63 {
+			return 0, strconv.ErrRange
+		}
+		return -int64(d.magnitude), nil
+	}
+	if d.magnitude > math.MaxInt64 {
+		return 0, strconv.ErrRange
+	}
+	return int64(d.magnitude), nil
+}
+
+//float64 returns the value of d with decimals implied decimal places, correctly rounded
+func (d decimal) float64(decimals int) float64 {
+	var f float64
+	if d.magnitude <= 1<<53 && decimals <= 22 {
+		//the magnitude and the power of ten are both exact, so a single division rounds once
+		f = float64(d.magnitude) / math.Pow10(decimals)
+	} else {
+		//larger magnitudes would be rounded twice, parsing the digits rounds once
+		f, _ = strconv.ParseFloat(strconv.FormatUint(d.magnitude, 10)+"e-"+strconv.Itoa(decimals), 64)
+	}
+	if d.negative {
+		f = -f
+	}
+	return f
+}
+
+//decodeNumber decodes fieldData using the encoding option of ffpTag
+func decodeNumber(fieldData []byte, ffpTag *flatfileTag) (decimal, error) {
+	switch ffpTag.encoding {
+	case "zoned":
+		return decodeZoned(fieldData)
+	case "packed":
+		return decodePacked(fieldData)
+	case "binary":
+		return decodeBinary(fieldData, true)
+	case "ubinary":
+		return decodeBinary(fieldData, false)
+	}
+	n, err := ParseInt(fieldData, 64)
+	if err != nil {
+		return decimal{}, err
+	}
+	if n < 0 {
+		return decimal{magnitude: uint64(-n), negative: true}, nil
+	}
+	return decimal{magnitude: uint64(n)}, nil
+}
+
+//decodeZoned decodes ASCII zoned decimal data
+//The last byte may carry the sign: { and A to I are +0 to +9, } and J to R are -0 to -9. p to y are also accepted as -0 to -9.
+func decodeZoned(b []byte) (decimal, error) {
+	if len(b) == 0 {
+		return decimal{}, errors.Wrap(strconv.ErrSyntax, "flatfile.decodeZoned: Empty zoned decimal")
+	}
+	if len(b) > maxDecimalDigits {
+		return decimal{}, errors.Wrapf(strconv.ErrRange, "flatfile.decodeZoned: Zoned decimal %q has more than %d digits", b, maxDecimalDigits)
+	}
+
+	var d decimal
+	for i, c := range b {
+		var digit byte
+		switch {
+		case c >= '0' && c <= '9':
+			digit = c - '0'
+		case i < len(b)-1:
+			return decimal{}, errors.Wrapf(strconv.ErrSyntax, "flatfile.decodeZoned: Invalid zoned decimal %q", b)
+		case c == '{':
+			digit = 0
+		case c >= 'A' && c <= 'I':
+			digit = c - 'A' + 1
+		case c == '}':
+			d.negative = true
+		case c >= 'J' && c <= 'R':
+			digit = c - 'J' + 1
+			d.negative = true
+		case c >= 'p' && c <= 'y':
+			digit = c - 'p'
+			d.negative = true
+		default:
+			return decimal{}, errors.Wrapf(strconv.ErrSyntax, "flatfile.decodeZoned: Invalid zoned decimal sign %q", c)
+		}
+		d.magnitude = d.magnitude*10 + uint64(digit)
+	}
+	return d, nil
+}
+
+//decodePacked decodes packed decimal data
+//Each byte holds two digits except the last, which holds a digit and the sign: C, A, E and F are positive, D and B are negative.
+func decodePacked(b []byte) (decimal, error) {
+	if len(b) == 0 {
+		return decimal{}, errors.Wrap(strconv.ErrSyntax, "flatfile.decodePacked: Empty packed decimal")
+	}
+	if 2*len(b)-1 > maxDecimalDigits {
+		return decimal{}, errors.Wrapf(strconv.ErrRange, "flatfile.decodePacked: Packed decimal of %d bytes has more than %d digits", len(b), maxDecimalDigits)
+	}
+
+	var d decimal
+	for i, c := range b {
+		high, low := c>>4, c&0x0f
+		if high > 9 {
+			return decimal{}, errors.Wrapf(strconv.ErrSyntax, "flatfile.decodePacked: Invalid packed decimal % X", b)
+		}
+		d.magnitude = d.magnitude*10 + uint64(high)
+		if i < len(b)-1 {
+			if low > 9 {
+				return decimal{}, errors.Wrapf(strconv.ErrSyntax, "flatfile.decodePacked: Invalid packed decimal % X", b)
+			}
+			d.magnitude = d.magnitude*10 + uint64(low)
+			continue
+		}
+		switch low {
+		case 0x0a, 0x0c, 0x0e, 0x0f:
+		case 0x0b, 0x0d:
+			d.negative = true
+		default:
+			return decimal{}, errors.Wrapf(strconv.ErrSyntax, "flatfile.decodePacked: Invalid packed decimal sign %X", low)
+		}
+	}
+	return d, nil
+}
+
+//decodeBinary decodes big endian binary data of 1 to 8 bytes
+func decodeBinary(b []byte, signed bool) (decimal, error) {
+	if len(b) == 0 || len(b) > 8 {
+		return decimal{}, errors.Wrapf(strconv.ErrSyntax, "flatfile.decodeBinary: Binary fields must be 1 to 8 bytes long, not %d", len(b))
+	}
+
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+	if signed && b[0]&0x80 != 0 {
+		//sign extend and negate the two's complement value
+		u |= math.MaxUint64 << (8 * uint(len(b)))
+		return decimal{magnitude: -u, negative: true}, nil
+	}
+	return decimal{magnitude: u}, nil
+}
+
+//assignNumber assigns fieldData decoded using the encoding and decimals options to an integer or float field
+func assignNumber(kind reflect.Kind, field reflect.Value, fieldData []byte, ffpTag *flatfileTag) error {
+	d, err := decodeNumber(fieldData, ffpTag)
+	if err != nil {
+		return errors.Wrap(err, "flatfile.assignNumber error")
+	}
+
+	switch kind {
+	case reflect.Float32, reflect.Float64:
+		f := d.float64(ffpTag.decimals)
+		if field.OverflowFloat(f) {
+			return errors.Wrapf(strconv.ErrRange, "flatfile.assignNumber: %v overflows %s", f, kind)
+		}
+		field.SetFloat(f)
+		return nil
+	}
+
+	if ffpTag.decimals > 0 {
+		return errors.Errorf("flatfile.assignNumber: Decimals option requires a float field, not %s", kind)
+	}
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := d.int64()
+		if err != nil || field.OverflowInt(n) {
+			return errors.Wrapf(strconv.ErrRange, "flatfile.assignNumber: Value overflows %s", kind)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if d.negative && d.magnitude != 0 {
+			return errors.Wrapf(strconv.ErrRange, "flatfile.assignNumber: Negative value assigned to %s", kind)
+		}
+		if field.OverflowUint(d.magnitude) {
+			return errors.Wrapf(strconv.ErrRange, "flatfile.assignNumber: Value overflows %s", kind)
+		}
+		field.SetUint(d.magnitude)
+	default:
+		return errors.Errorf("flatfile.assignNumber: Encoding and decimals options require a numeric field, not %s", kind)
+	}
+	return nil
+}
+
+//encodeNumeric writes an integer or float field into dst using the encoding and decimals options
+func encodeNumeric(field reflect.Value, dst []byte, ffpTag *flatfileTag) error {
+	var d decimal
+	signed := true
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := math.Round(field.Float() * math.Pow10(ffpTag.decimals))
+		if math.IsNaN(f) || math.Abs(f) >= 1<<63 {
+			return ErrValueTooLong
+		}
+		d = decimal{magnitude: uint64(math.Abs(f)), negative: f < 0}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := field.Int()
+		d = decimal{magnitude: uint64(n), negative: n < 0}
+		if n < 0 {
+			d.magnitude = -d.magnitude
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		d = decimal{magnitude: field.Uint()}
+		signed = false
+	default:
+		return errors.Errorf("flatfile.encodeNumeric: Encoding and decimals options require a numeric field, not %s", field.Kind())
+	}
+	if ffpTag.decimals > 0 && field.Kind() != reflect.Float32 && field.Kind() != reflect.Float64 {
+		return errors.Errorf("flatfile.encodeNumeric: Decimals option requires a float field, not %s", field.Kind())
+	}
+
+	switch ffpTag.encoding {
+	case "zoned":
+		return encodeZoned(dst, d, signed)
+	case "packed":
+		return encodePacked(dst, d, signed)
+	case "binary", "ubinary":
+		return encodeBinary(dst, d, ffpTag.encoding == "binary")
+	}
+	if d.negative {
+		n, err := d.int64()
+		if err != nil {
+			return ErrValueTooLong
+		}
+		return EncodeInt(dst, n)
+	}
+	return EncodeUint(dst, d.magnitude)
+}
+
+//encodeZoned writes d into dst as ASCII zoned decimal
+//Signed values carry the sign overpunched on the last digit, unsigned values are plain digits.
+func encodeZoned(dst []byte, d decimal, signed bool) error {
+	if err := encodeDigits(dst, d.magnitude); err != nil {
+		return err
+	}
+	if signed {
+		last := &dst[len(dst)-1]
+		digit := *last - '0'
+		switch {
+		case d.negative && digit == 0:
+			*last = '}'
+		case d.negative:
+			*last = 'J' + digit - 1
+		case digit == 0:
+			*last = '{'
+		default:
+			*last = 'A' + digit - 1
+		}
+	}
+	return nil
+}
+
+//encodeDigits writes n into dst right aligned and padded with zeros
+func encodeDigits(dst []byte, n uint64) error {
+	for i := len(dst) - 1; i >= 0; i-- {
+		dst[i] = byte('0' + n%10)
+		n /= 10
+	}
+	if n != 0 || len(dst) == 0 {
+		return ErrValueTooLong
+	}
+	return nil
+}
+
+//encodePacked writes d into dst as packed decimal with sign C or D, or F when unsigned
+func encodePacked(dst []byte, d decimal, signed bool) error {
+	if len(dst) == 0 {
+		return ErrValueTooLong
+	}
+	n := d.magnitude
+	sign := byte(0x0f)
+	if signed {
+		sign = 0x0c
+		if d.negative {
+			sign = 0x0d
+		}
+	}
+	dst[len(dst)-1] = byte(n%10)<<4 | sign
+	n /= 10
+	for i := len(dst) - 2; i >= 0; i-- {
+		dst[i] = byte(n/10%10)<<4 | byte(n%10)
+		n /= 100
+	}
+	if n != 0 {
+		return ErrValueTooLong
+	}
+	return nil
+}
+
+//encodeBinary writes d into dst as a big endian binary number of 1 to 8 bytes
+func encodeBinary(dst []byte, d decimal, signed bool) error {
+	if len(dst) == 0 || len(dst) > 8 {
+		return errors.Errorf("flatfile.encodeBinary: Binary fields must be 1 to 8 bytes long, not %d", len(dst))
+	}
+	bits := 8 * uint(len(dst))
+	u := d.magnitude
+	switch {
+	case !signed && d.negative && d.magnitude != 0:
+		return ErrValueTooLong
+	case !signed && bits < 64 && u >= 1<<bits:
+		return ErrValueTooLong
+	case signed && d.negative && u > 1<<(bits-1):
+		return ErrValueTooLong
+	case signed && !d.negative && u >= 1<<(bits-1):
+		return ErrValueTooLong
+	}
+	if d.negative {
+		u = -u
+	}
+	for i := len(dst) - 1; i >= 0; i-- {
+		dst[i] = byte(u)
+		u >>= 8
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package flatfile
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type encodedRecord struct {
+	Zoned    int64   `flatfile:"1,5,enc=zoned"`
+	Packed   float64 `flatfile:"6,4,enc=packed,dec=2"`
+	Binary   int16   `flatfile:"10,2,enc=binary"`
+	Unsigned uint32  `flatfile:"12,4,enc=ubinary"`
+	Display  float32 `flatfile:"16,5,dec=3"`
+	Counts   [2]uint `flatfile:"21,2,enc=packed"`
+}
+
+func TestDecodeNumber(t *testing.T) {
+	tests := []struct {
+		encoding string
+		data     string
+		want     decimal
+		wantErr  error
+	}{
+		{"display", "-0042", decimal{magnitude: 42, negative: true}, nil},
+		{"display", "4 2", decimal{}, strconv.ErrSyntax},
+		{"zoned", "0042", decimal{magnitude: 42}, nil},
+		{"zoned", "004B", decimal{magnitude: 42}, nil},
+		{"zoned", "004{", decimal{magnitude: 40}, nil},
+		{"zoned", "004K", decimal{magnitude: 42, negative: true}, nil},
+		{"zoned", "004}", decimal{magnitude: 40, negative: true}, nil},
+		{"zoned", "004r", decimal{magnitude: 42, negative: true}, nil},
+		{"zoned", "0A42", decimal{}, strconv.ErrSyntax},
+		{"zoned", "004!", decimal{}, strconv.ErrSyntax},
+		{"zoned", "", decimal{}, strconv.ErrSyntax},
+		{"zoned", "0123456789012345678", decimal{}, strconv.ErrRange},
+		{"packed", "\x12\x34\x5c", decimal{magnitude: 12345}, nil},
+		{"packed", "\x12\x34\x5d", decimal{magnitude: 12345, negative: true}, nil},
+		{"packed", "\x12\x34\x5f", decimal{magnitude: 12345}, nil},
+		{"packed", "\x0d", decimal{negative: true}, nil},
+		{"packed", "\x1a\x34\x5c", decimal{}, strconv.ErrSyntax},
+		{"packed", "\x12\x34\x51", decimal{}, strconv.ErrSyntax},
+		{"packed", "\x12\x34\x56\x78\x90\x12\x34\x56\x78\x9c", decimal{}, strconv.ErrRange},
+		{"binary", "\x00\x2a", decimal{magnitude: 42}, nil},
+		{"binary", "\xff\xd6", decimal{magnitude: 42, negative: true}, nil},
+		{"binary", "\x80\x00\x00\x00\x00\x00\x00\x00", decimal{magnitude: 1 << 63, negative: true}, nil},
+		{"ubinary", "\xff\xd6", decimal{magnitude: 65494}, nil},
+		{"binary", "\x00\x00\x00\x00\x00\x00\x00\x00\x00", decimal{}, strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding+" "+strconv.Quote(tt.data), func(t *testing.T) {
+			got, err := decodeNumber([]byte(tt.data), &flatfileTag{encoding: tt.encoding})
+			if tt.wantErr != nil {
+				if cause := errors.Cause(err); cause != tt.wantErr && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("decodeNumber() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("decodeNumber() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalFloat64(t *testing.T) {
+	tests := []struct {
+		d        decimal
+		decimals int
+		want     string
+	}{
+		{decimal{magnitude: 123456}, 2, "1234.56"},
+		{decimal{magnitude: 42, negative: true}, 3, "-0.042"},
+		{decimal{magnitude: 1 << 53}, 0, "9007199254740992"},
+		//digits beyond the precision of a float64 are rounded once
+		{decimal{magnitude: 999999999999984161}, 4, "99999999999998.4161"},
+		{decimal{magnitude: 999999999999976242, negative: true}, 1, "-99999999999997624.2"},
+	}
+	for _, tt := range tests {
+		want, err := strconv.ParseFloat(tt.want, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tt.d.float64(tt.decimals); got != want {
+			t.Errorf("decimal.float64(%+v, %d) = %v, want %v", tt.d, tt.decimals, got, want)
+		}
+	}
+}
+
+func TestUnmarshalEncoded(t *testing.T) {
+	data := []byte("0042K\x01\x23\x45\x6d\xff\xd6\xff\xff\xff\xfe12345\x01\x2f\x00\x3f")
+	got := encodedRecord{}
+	if err := Unmarshal(data, &got, 0, 0, false); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := encodedRecord{Zoned: -422, Packed: -1234.56, Binary: -42, Unsigned: 4294967294, Display: 12.345, Counts: [2]uint{12, 3}}
+	if got != want {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+
+	encoded, err := Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Errorf("Marshal() = %q, want %q", encoded, data)
+	}
+}
+
+func TestEncodedErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		v    interface{}
+	}{
+		{"Decimals on integer", "123", &struct {
+			Value int `flatfile:"1,3,dec=1"`
+		}{}},
+		{"Encoding on string", "123", &struct {
+			Value string `flatfile:"1,3,enc=zoned"`
+		}{}},
+		{"Negative into unsigned", "12L", &struct {
+			Value uint `flatfile:"1,3,enc=zoned"`
+		}{}},
+		{"Overflow", "\x01\x00", &struct {
+			Value int8 `flatfile:"1,2,enc=binary"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte(tt.data), tt.v, 0, 0, false); err == nil {
+				t.Errorf("Unmarshal() expected error")
+			}
+			if _, err := Marshal(tt.v); err == nil && tt.name != "Negative into unsigned" && tt.name != "Overflow" {
+				t.Errorf("Marshal() expected error")
+			}
+		})
+	}
+}
+
+func TestEncodeNumeric(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    string
+		wantErr bool
+	}{
+		{"Zoned positive", struct {
+			V int `flatfile:"1,4,enc=zoned"`
+		}{120}, "012{", false},
+		{"Zoned unsigned", struct {
+			V uint `flatfile:"1,4,enc=zoned"`
+		}{123}, "0123", false},
+		{"Zoned too long", struct {
+			V int `flatfile:"1,2,enc=zoned"`
+		}{-123}, "", true},
+		{"Packed unsigned", struct {
+			V uint16 `flatfile:"1,2,enc=packed"`
+		}{123}, "\x12\x3f", false},
+		{"Packed too long", struct {
+			V int `flatfile:"1,2,enc=packed"`
+		}{1234}, "", true},
+		{"Packed rounding", struct {
+			V float32 `flatfile:"1,3,enc=packed,dec=2"`
+		}{-0.125}, "\x00\x01\x3d", false},
+		{"Binary min", struct {
+			V int8 `flatfile:"1,1,enc=binary"`
+		}{-128}, "\x80", false},
+		{"Binary too long", struct {
+			V int `flatfile:"1,1,enc=binary"`
+		}{128}, "", true},
+		{"Unsigned binary negative", struct {
+			V int `flatfile:"1,1,enc=ubinary"`
+		}{-1}, "", true},
+		{"Display decimals", struct {
+			V float64 `flatfile:"1,6,dec=2"`
+		}{-12.5}, "-01250", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Marshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && string(got) != tt.want {
+				t.Errorf("Marshal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
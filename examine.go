@@ -2,29 +2,103 @@ package flatfile
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"reflect"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // Examine traverses all elements of a type and uses the reflect pkg to print type and kind
 func Examine(v interface{}) {
-	examiner(reflect.TypeOf(v), 0)
+	ExamineTo(os.Stdout, v)
+}
+
+/*ExamineTo writes the type and kind of v and of all nested struct fields, pointers, arrays and slices to w
+
+The flatfile tag of each field is written with the absolute columns it covers in the record.
+Fields nested in a struct field without a flatfile tag are not decoded, so only their tags are written.
+*/
+func ExamineTo(w io.Writer, v interface{}) error {
+	e := &examiner{w: w, visiting: map[reflect.Type]bool{}}
+	e.examine(reflect.TypeOf(v), 0, 0, true)
+	return errors.Wrap(e.err, "flatfile.ExamineTo: Failed to write")
 }
 
-// Below code is sourced from Jon Bodner's blog: https://medium.com/capital-one-tech/learning-to-use-go-reflection-822a0aed74b7
+// Below code is based on Jon Bodner's blog: https://medium.com/capital-one-tech/learning-to-use-go-reflection-822a0aed74b7
 // Direct link to Gist: https://gist.github.com/jonbodner/1727d0825d73541db8d6fcb859515735
-func examiner(t reflect.Type, depth int) {
-	fmt.Println(strings.Repeat("\t", depth), "Type is", t.Name(), "and kind is", t.Kind())
+type examiner struct {
+	w   io.Writer
+	err error
+	//visiting holds the struct types being examined, to stop at recursive types
+	visiting map[reflect.Type]bool
+}
+
+//println writes a line indented by depth, keeping the first write error
+func (e *examiner) println(depth int, a ...interface{}) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = fmt.Fprintln(e.w, append([]interface{}{strings.Repeat("\t", depth)}, a...)...)
+}
+
+//examine writes type t. offset is the number of columns before the data t is decoded from, resolved is false when t is not decoded.
+func (e *examiner) examine(t reflect.Type, depth int, offset int, resolved bool) {
+	if t == nil {
+		e.println(depth, "Type is nil")
+		return
+	}
+	e.println(depth, "Type is", t.Name(), "and kind is", t.Kind())
 	switch t.Kind() {
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Ptr, reflect.Slice:
-		fmt.Println(strings.Repeat("\t", depth+1), "Contained type:")
-		examiner(t.Elem(), depth+1)
+		e.println(depth+1, "Contained type:")
+		e.examine(t.Elem(), depth+1, offset, resolved)
 	case reflect.Struct:
+		if t == timeType {
+			return
+		}
+		if e.visiting[t] {
+			e.println(depth+1, "Recursive type", t.Name(), "is not examined again")
+			return
+		}
+		e.visiting[t] = true
+		defer delete(e.visiting, t)
+
+		layout := layoutOf(t)
 		for i := 0; i < t.NumField(); i++ {
 			f := t.Field(i)
-			fmt.Println(strings.Repeat("\t", depth+1), "Field", i+1, "name is", f.Name, "type is", f.Type.Name(), "and kind is", f.Type.Kind())
+			e.println(depth+1, "Field", i+1, "name is", f.Name, "type is", f.Type.Name(), "and kind is", f.Type.Kind())
 			if f.Tag != "" {
-				fmt.Println(strings.Repeat("\t", depth+2), "Tag is", f.Tag)
+				e.println(depth+2, "Tag is", f.Tag)
+			}
+			field := &layout.fields[i]
+			fieldResolved := resolved && (field.tagged || field.embedded) && field.tagErr == nil
+			switch {
+			case field.tagErr != nil:
+				e.println(depth+2, "Invalid flatfile tag:", field.tagErr)
+			case fieldResolved && field.embedded:
+				e.println(depth+2, "Embedded fields are flattened")
+			case fieldResolved:
+				ffpTag := &field.tag
+				start := offset + ffpTag.col
+				end := start - 1 + ffpTag.length*occurrences(ffpTag, f.Type)
+				if n := occurrences(ffpTag, f.Type); n > 1 || ffpTag.occurs > 0 {
+					e.println(depth+2, "Columns", start, "to", end, "with", n, "occurrences of length", ffpTag.length)
+				} else {
+					e.println(depth+2, "Columns", start, "to", end)
+				}
+			}
+			switch f.Type.Kind() {
+			case reflect.Array, reflect.Chan, reflect.Map, reflect.Ptr, reflect.Slice, reflect.Struct:
+				fieldOffset := 0
+				if fieldResolved {
+					fieldOffset = offset + field.tag.col - 1
+				}
+				if field.embedded {
+					fieldOffset = offset
+				}
+				e.examine(f.Type, depth+2, fieldOffset, fieldResolved)
 			}
 		}
 	}
@@ -1,6 +1,10 @@
 package flatfile
 
-import "testing"
+import (
+	"io"
+	"strings"
+	"testing"
+)
 
 func TestExamine(t *testing.T) {
 	type NestedStruct struct {
@@ -14,3 +18,68 @@ func TestExamine(t *testing.T) {
 
 	Examine([]ExamineStruct{})
 }
+
+type examineNode struct {
+	Value string       `flatfile:"1,2"`
+	Next  *examineNode `flatfile:"3,2"`
+}
+
+func TestExamineTo(t *testing.T) {
+	type Address struct {
+		City string `flatfile:"2,3"`
+	}
+	type Record struct {
+		Name      string     `flatfile:"1,5"`
+		Addresses [2]Address `flatfile:"6,4"`
+		Untagged  Address
+		Bad       int `flatfile:"1"`
+	}
+
+	var b strings.Builder
+	if err := ExamineTo(&b, &Record{}); err != nil {
+		t.Fatalf("ExamineTo() error = %v", err)
+	}
+	want := ` Type is  and kind is ptr
+	 Contained type:
+	 Type is Record and kind is struct
+		 Field 1 name is Name type is string and kind is string
+			 Tag is flatfile:"1,5"
+			 Columns 1 to 5
+		 Field 2 name is Addresses type is  and kind is array
+			 Tag is flatfile:"6,4"
+			 Columns 6 to 13 with 2 occurrences of length 4
+			 Type is  and kind is array
+				 Contained type:
+				 Type is Address and kind is struct
+					 Field 1 name is City type is string and kind is string
+						 Tag is flatfile:"2,3"
+						 Columns 7 to 9
+		 Field 3 name is Untagged type is Address and kind is struct
+			 Type is Address and kind is struct
+				 Field 1 name is City type is string and kind is string
+					 Tag is flatfile:"2,3"
+		 Field 4 name is Bad type is int and kind is int
+			 Tag is flatfile:"1"
+`
+	if got := b.String(); !strings.HasPrefix(got, want) || !strings.Contains(got, "Invalid flatfile tag:") {
+		t.Errorf("ExamineTo() = %s, want %s", got, want)
+	}
+
+	b.Reset()
+	if err := ExamineTo(&b, examineNode{}); err != nil {
+		t.Fatalf("ExamineTo() error = %v", err)
+	}
+	if !strings.Contains(b.String(), "Recursive type examineNode is not examined again") {
+		t.Errorf("ExamineTo() = %s", b.String())
+	}
+
+	if err := ExamineTo(failingWriter{}, Record{}); err == nil {
+		t.Errorf("ExamineTo() expected write error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
@@ -1,6 +1,7 @@
 package flatfile
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -16,6 +17,26 @@ type flatfileTag struct {
 	condLen  int
 	condVal  string
 	condChk  bool
+	//cond is set instead of condCol, condLen and condVal when the condition is an expression, condText is its text
+	cond     *condition
+	condText string
+	encoding string
+	decimals int
+	format   string
+	//validation rules, checked by validateField
+	required    bool
+	min, max    string
+	oneOf       []string
+	patternText string
+	pattern     *regexp.Regexp
+	//union fields decode the variant selected by disc, the field name or range in discText, see checkUnion
+	union    bool
+	disc     *condition
+	discText string
+	//variants are the values of the discriminator selecting a variant of a union
+	variants []string
+	//optional pointers are left nil when their data is blank instead of being allocated
+	optional bool
 }
 
 var parseFuncMap = map[string]func(string, *flatfileTag) error{
@@ -29,10 +50,83 @@ var parseFuncMap = map[string]func(string, *flatfileTag) error{
 	"override":  parseOverrideOption,
 	"cond":      parseConditionOption,
 	"condition": parseConditionOption,
+	"enc":       parseEncodingOption,
+	"encoding":  parseEncodingOption,
+	"dec":       parseDecimalsOption,
+	"decimals":  parseDecimalsOption,
+	"fmt":       parseFormatOption,
+	"format":    parseFormatOption,
+	"min":       parseMinOption,
+	"max":       parseMaxOption,
+	"oneof":     parseOneOfOption,
+	"pattern":   parsePatternOption,
+	"disc":      parseDiscriminatorOption,
+	"variant":   parseVariantOption,
 }
 
 //condition=1-10-TENLETTERS
 
+//Tag is the parsed value of a flatfile struct tag
+type Tag struct {
+	Column   int
+	Length   int
+	Occurs   int
+	Override string
+	//Encoding is the storage format of a numeric field: display, zoned, packed, binary or ubinary. Empty means display
+	Encoding string
+	//Decimals is the number of implied decimal places of a numeric field
+	Decimals int
+	//Format is the layout of a time.Time field, as used by time.Parse
+	Format string
+	//Required, Min, Max, OneOf and Pattern are the validation rules of the field
+	Required bool
+	Min, Max string
+	OneOf    []string
+	Pattern  string
+	//Condition is nil when the tag has no condition option
+	Condition *TagCondition
+	//Union is true for a union whose variant is selected by Discriminator, a field name or a range in the form col:len
+	Union         bool
+	Discriminator string
+	//Variants are the values of the discriminator selecting a variant of a union
+	Variants []string
+	//Optional pointers are left nil when their data is blank
+	Optional bool
+}
+
+//TagCondition is the condition option of a flatfile struct tag
+//The field is only unmarshalled when the data at Column with Length is equal to Value
+type TagCondition struct {
+	Column int
+	Length int
+	Value  string
+	//Expression is set instead of Column, Length and Value when the condition is not in the form col-len-value
+	Expression string
+}
+
+//ParseTag parses the value of a flatfile struct tag, for example "col=1,len=10"
+func ParseTag(fieldTag string) (Tag, error) {
+	ffpTag := &flatfileTag{}
+	if err := parseFlatfileTag(fieldTag, ffpTag); err != nil {
+		return Tag{}, err
+	}
+	return ffpTag.export(), nil
+}
+
+//export converts a flatfileTag to a Tag
+func (ffpTag *flatfileTag) export() Tag {
+	tag := Tag{Column: ffpTag.col, Length: ffpTag.length, Occurs: ffpTag.occurs, Override: ffpTag.override, Encoding: ffpTag.encoding, Decimals: ffpTag.decimals, Format: ffpTag.format,
+		Required: ffpTag.required, Min: ffpTag.min, Max: ffpTag.max, OneOf: ffpTag.oneOf, Pattern: ffpTag.patternText,
+		Union: ffpTag.union, Discriminator: ffpTag.discText, Variants: ffpTag.variants, Optional: ffpTag.optional}
+	if ffpTag.condChk {
+		tag.Condition = &TagCondition{Column: ffpTag.condCol, Length: ffpTag.condLen, Value: ffpTag.condVal}
+	}
+	if ffpTag.cond != nil {
+		tag.Condition = &TagCondition{Expression: ffpTag.condText}
+	}
+	return tag
+}
+
 //validOptions is a list of the keys loaded from parseFuncMap. This is used purely to display options to user
 var validOptions []string
 
@@ -50,7 +144,7 @@ func init() {
 func parseFlatfileTag(fieldTag string, ffpTag *flatfileTag) error {
 	var err error
 	//split tag by comma to get column and length data
-	params := strings.Split(fieldTag, ",")
+	params := splitTagParams(fieldTag)
 	//column and length parameters must be provided
 	if len(params) < 2 {
 		return errors.Errorf("flatfile.parseFlatfileTag: Not enough ffp tag params provided.\nColumn and length parameters must be provided.\nMust be in form `flatfile:\"col,len\"`")
@@ -58,9 +152,10 @@ func parseFlatfileTag(fieldTag string, ffpTag *flatfileTag) error {
 
 	for idx, param := range params {
 		//check whether or not tag is using named options
-		if strings.Contains(param, "=") {
-			options := strings.Split(param, "=")
-			if len(options) != 2 {
+		if namedOption(param) {
+			options := strings.SplitN(param, "=", 2)
+			//only patterns and conditions may contain =
+			if options[0] != "pattern" && !isConditionOption(options[0]) && strings.Contains(options[1], "=") {
 				return errors.Errorf("flatfile.parseFlatfileTag: Invalid formatting of named option '%v'\nNamed options should be in the form option=value\nValid options:%v", options, validOptions)
 			}
 			if funcVal, exists := parseFuncMap[options[0]]; exists {
@@ -68,6 +163,12 @@ func parseFlatfileTag(fieldTag string, ffpTag *flatfileTag) error {
 			} else {
 				return errors.Errorf("flatfile.parseFlatfileTag: Invalid tag parameter %s\nValid options: %v", options[0], validOptions)
 			}
+		} else if param == "required" {
+			ffpTag.required = true
+		} else if param == "union" {
+			ffpTag.union = true
+		} else if param == "optional" {
+			ffpTag.optional = true
 		} else {
 			//assume user is using positional options
 			switch idx {
@@ -94,6 +195,50 @@ func parseFlatfileTag(fieldTag string, ffpTag *flatfileTag) error {
 	return nil
 }
 
+/*splitTagParams splits a tag into its options
+
+Options are separated by commas, except for commas in quoted values of a condition, which is the fifth positional
+option or a cond or condition option.
+*/
+func splitTagParams(fieldTag string) []string {
+	var params []string
+	for _, param := range strings.Split(fieldTag, ",") {
+		if last := len(params) - 1; last >= 0 && strings.Count(params[last], "'")%2 == 1 && conditionParam(params[last], last) {
+			params[last] += "," + param
+			continue
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+//conditionParam returns true when the tag option at index idx is a condition
+func conditionParam(param string, idx int) bool {
+	if namedOption(param) {
+		return isConditionOption(param[:strings.IndexByte(param, '=')])
+	}
+	return idx == 4
+}
+
+//namedOption returns true when param is in the form option=value
+//Condition expressions such as 1:2=H1 may contain = without being named options.
+func namedOption(param string) bool {
+	name, _, ok := strings.Cut(param, "=")
+	if !ok || name == "" {
+		return false
+	}
+	for _, c := range name {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isConditionOption(name string) bool {
+	return name == "cond" || name == "condition"
+}
+
 func parseColumnOption(param string, ffpTag *flatfileTag) error {
 	col, colerr := strconv.Atoi(param)
 	if colerr != nil {
@@ -153,10 +298,19 @@ func isValidOverride(override string) bool {
 	return false
 }
 
+//parseConditionOption parses a condition in the form col-len-value, where the value may contain hyphens,
+//or a condition expression such as 1:2 in H1|H2, see condition
 func parseConditionOption(param string, ffpTag *flatfileTag) error {
-	condParams := strings.Split(param, "-")
-	if len(condParams) != 3 {
-		return errors.Errorf("flatfile.parseConditionOption: Expected 3 tag condition parameters but got %d options: %s", len(condParams), param)
+	condParams := strings.SplitN(param, "-", 3)
+	//expressions are recognised by their ranges, field names and operators, values in the form col-len-value may contain hyphens
+	if len(condParams) != 3 || strings.ContainsAny(condParams[0], ":=<>!( ") {
+		cond, err := parseCondition(param)
+		if err != nil {
+			return errors.Wrapf(err, "flatfile.parseConditionOption: Invalid condition %s", param)
+		}
+		ffpTag.cond = cond
+		ffpTag.condText = param
+		return nil
 	}
 
 	condCol, colerr := strconv.Atoi(condParams[0])
@@ -169,6 +323,10 @@ func parseConditionOption(param string, ffpTag *flatfileTag) error {
 		return errors.Wrapf(lenerr, "flatfile.parseConditionOption: Error parsing tag condition len parameter %s", param)
 	}
 
+	if condCol < 1 || condLen < 1 {
+		return errors.Errorf("flatfile.parseConditionOption: Out of range error. Condition column and length must be greater than 0: %s", param)
+	}
+
 	condVal := condParams[2]
 
 	ffpTag.condCol = condCol
@@ -177,3 +335,87 @@ func parseConditionOption(param string, ffpTag *flatfileTag) error {
 	ffpTag.condChk = true
 	return nil
 }
+
+func parseEncodingOption(param string, ffpTag *flatfileTag) error {
+	switch param {
+	case "display",
+		"zoned",
+		"packed",
+		"binary",
+		"ubinary":
+		ffpTag.encoding = param
+		return nil
+	}
+	return errors.Errorf("flatfile.parseEncodingOption: Invalid encoding %s. Valid encodings: display, zoned, packed, binary, ubinary", param)
+}
+
+func parseDecimalsOption(param string, ffpTag *flatfileTag) error {
+	decimals, decerr := strconv.Atoi(param)
+	if decerr != nil {
+		return errors.Wrapf(decerr, "flatfile.parseDecimalsOption: Error parsing tag decimals parameter %s", param)
+	}
+
+	if decimals < 0 || decimals > 18 {
+		return errors.Errorf("flatfile.parseDecimalsOption: Out of range error. Decimals parameter must be between 0 and 18")
+	}
+
+	ffpTag.decimals = decimals
+	return nil
+}
+
+//parseFormatOption sets the time.Parse layout of a time.Time field
+//Layouts cannot contain commas, which separate tag options.
+func parseFormatOption(param string, ffpTag *flatfileTag) error {
+	if param == "" {
+		return errors.New("flatfile.parseFormatOption: Format parameter cannot be empty")
+	}
+	ffpTag.format = param
+	return nil
+}
+
+func parseMinOption(param string, ffpTag *flatfileTag) error {
+	if param == "" {
+		return errors.New("flatfile.parseMinOption: Min parameter cannot be empty")
+	}
+	ffpTag.min = param
+	return nil
+}
+
+func parseMaxOption(param string, ffpTag *flatfileTag) error {
+	if param == "" {
+		return errors.New("flatfile.parseMaxOption: Max parameter cannot be empty")
+	}
+	ffpTag.max = param
+	return nil
+}
+
+//parseOneOfOption sets the allowed values of a field, separated by |
+func parseOneOfOption(param string, ffpTag *flatfileTag) error {
+	if param == "" {
+		return errors.New("flatfile.parseOneOfOption: Oneof parameter cannot be empty")
+	}
+	ffpTag.oneOf = strings.Split(param, "|")
+	return nil
+}
+
+//parsePatternOption compiles a regular expression which must match the whole value of a field
+//Patterns cannot contain commas, which separate tag options.
+func parsePatternOption(param string, ffpTag *flatfileTag) error {
+	pattern, err := regexp.Compile("^(?:" + param + ")$")
+	if err != nil {
+		return errors.Wrap(err, "flatfile.parsePatternOption: Invalid pattern")
+	}
+	ffpTag.patternText = param
+	ffpTag.pattern = pattern
+	return nil
+}
+
+//validated returns true when the field has validation rules
+func (ffpTag *flatfileTag) validated() bool {
+	return ffpTag.required || ffpTag.min != "" || ffpTag.max != "" || ffpTag.oneOf != nil || ffpTag.pattern != nil
+}
+
+//numeric returns true when the field is decoded using the encoding and decimals options
+func (ffpTag *flatfileTag) numeric() bool {
+	return (ffpTag.encoding != "" && ffpTag.encoding != "display") || ffpTag.decimals > 0
+}
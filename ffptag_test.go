@@ -2,6 +2,7 @@ package flatfile
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -35,6 +36,11 @@ func TestFfpTagOptions_parseFfpTag(t *testing.T) {
 		{"col=1,len=3,occ=2,ovr=byte,cond=1-2", &flatfileTag{}, &flatfileTag{col: 1, length: 1, occurs: 0, override: "", condChk: false, condCol: 0, condLen: 0, condVal: ""}, true},
 		{"col=1,len=3,occ=2,ovr=byte,cond=one-2-to", &flatfileTag{}, &flatfileTag{col: 1, length: 1, occurs: 0, override: "", condChk: false, condCol: 0, condLen: 0, condVal: ""}, true},
 		{"col=1,len=3,occ=2,ovr=byte,cond=1-two-to", &flatfileTag{}, &flatfileTag{col: 1, length: 1, occurs: 0, override: "", condChk: false, condCol: 0, condLen: 0, condVal: ""}, true},
+		{"col=1,len=3,cond=1-4-H-01", &flatfileTag{}, &flatfileTag{col: 1, length: 3, occurs: 0, override: "", condChk: true, condCol: 1, condLen: 4, condVal: "H-01"}, false},
+		{"col=1,len=3,cond=0-4-H", &flatfileTag{}, &flatfileTag{}, true},
+		{"col=1,len=3,cond=1:0 = H", &flatfileTag{}, &flatfileTag{}, true},
+		{"col=1,len=3,cond=1:2 ~ H", &flatfileTag{}, &flatfileTag{}, true},
+		{"col=1,len=3,cond=1:2 = 'H", &flatfileTag{}, &flatfileTag{}, true},
 	}
 
 	for idx, tt := range tests {
@@ -116,3 +122,38 @@ func TestFfpTagParsePosSyntaxErr_parseFfpTag(t *testing.T) {
 	t.Log(testVal)
 	t.Log(err)
 }
+
+func TestParseTag(t *testing.T) {
+	var tests = []struct {
+		tagValue string
+		want     Tag
+		isError  bool
+	}{
+		{"1,10", Tag{Column: 1, Length: 10}, false},
+		{"col=5,len=2,occurs=3,ovr=byte", Tag{Column: 5, Length: 2, Occurs: 3, Override: "byte"}, false},
+		{"1,1,2,rune,1-10-tenletters", Tag{Column: 1, Length: 1, Occurs: 2, Override: "rune", Condition: &TagCondition{Column: 1, Length: 10, Value: "tenletters"}}, false},
+		{"1,4,enc=packed,dec=2", Tag{Column: 1, Length: 4, Encoding: "packed", Decimals: 2}, false},
+		{"col=3,len=2,encoding=binary,decimals=0", Tag{Column: 3, Length: 2, Encoding: "binary"}, false},
+		{"1,8,fmt=20060102", Tag{Column: 1, Length: 8, Format: "20060102"}, false},
+		{"1,10,format=2006-01-02", Tag{Column: 1, Length: 10, Format: "2006-01-02"}, false},
+		{"1,2,required,min=1,max=99,oneof=1|2,pattern=[0-9]=?", Tag{Column: 1, Length: 2, Required: true, Min: "1", Max: "99", OneOf: []string{"1", "2"}, Pattern: "[0-9]=?"}, false},
+		{"1", Tag{}, true},
+		{"1,4,enc=ebcdic", Tag{}, true},
+		{"1,4,min==1", Tag{}, true},
+		{"1,8,format=", Tag{}, true},
+		{"1,4,dec=19", Tag{}, true},
+		{"1,4,dec=two", Tag{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tagValue, func(t *testing.T) {
+			got, err := ParseTag(tt.tagValue)
+			if (err != nil) != tt.isError {
+				t.Fatalf("ParseTag(%v) error = %v, isError %v", tt.tagValue, err, tt.isError)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTag(%v) got: %+v want: %+v", tt.tagValue, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package flatfile
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"reflect"
@@ -13,12 +14,25 @@ import (
 type FlatFile struct {
 	reader       *bufio.Reader
 	objectLayout interface{}
+	//recordLength is the length of every record when records are not separated by line breaks. Zero when records are lines.
+	recordLength int
+	//mapped holds the contents of a memory mapped file. Records are sliced from it instead of being read from reader.
+	mapped []byte
+	//offset is the position of the next record in mapped
+	offset int
+	//records is the number of records read so far
+	records int64
+	decoder decoder
+	//skipped handles records which fail to decode, nil when Read returns their error
+	skipped *recordErrors
 }
 
 //New returns a new FlatFile reader object
-func New(reader *bufio.Reader, objectLayout interface{}) (*FlatFile, error) {
+//opts configure the record length, decoding, and records which fail to decode, see WithRejectWriter
+func New(reader *bufio.Reader, objectLayout interface{}, opts ...Option) (*FlatFile, error) {
 	if reflect.TypeOf(objectLayout).Kind() == reflect.Ptr {
-		return &FlatFile{reader: reader, objectLayout: objectLayout}, nil
+		o := newOptions(opts)
+		return &FlatFile{reader: reader, objectLayout: objectLayout, recordLength: o.recordLength, decoder: o.decoder, skipped: o.newRecordErrors()}, nil
 	}
 
 	return nil, errors.Wrap(fmt.Errorf("flatfile.New: %s is not a pointer", reflect.TypeOf(objectLayout)), "")
@@ -26,16 +40,99 @@ func New(reader *bufio.Reader, objectLayout interface{}) (*FlatFile, error) {
 
 //Read will read a line from a bufio.Reader and call flatfile.Unmarshal to convert the read in data into FlatFile.objectLayout
 func (f *FlatFile) Read() (err error) {
-	var line []byte
-	var buffLine []byte
-	prefix := true
-	for prefix {
-		buffLine, prefix, err = f.reader.ReadLine()
-		if err == io.EOF {
+	return f.ReadContext(context.Background())
+}
+
+//ReadContext is like Read but stops reading when ctx is cancelled or its deadline is exceeded
+//The returned error wraps ctx.Err() and reports how many records were read before cancellation
+//
+//Records which fail to decode are skipped when WithRejectWriter, WithErrorSink or WithErrorBudget is provided.
+func (f *FlatFile) ReadContext(ctx context.Context) (err error) {
+	for {
+		line, err := f.readRecord(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = f.decoder.unmarshal(line, f.objectLayout, 0, 0, false)
+		if err == nil || f.skipped == nil {
 			return err
 		}
+		if err := f.skipped.add(f.records, line, err); err != nil {
+			return errors.Wrap(err, "flatfile.FlatFile.Read")
+		}
+	}
+}
+
+//readRecord reads the next record from FlatFile.reader
+//Records are lines unless FlatFile.recordLength is set, in which case every record is exactly recordLength bytes
+func (f *FlatFile) readRecord(ctx context.Context) ([]byte, error) {
+	if f.recordLength > 0 {
+		return f.readFixed(ctx)
+	}
+	return f.readLine(ctx)
+}
+
+//readFixed reads a record of FlatFile.recordLength bytes
+func (f *FlatFile) readFixed(ctx context.Context) ([]byte, error) {
+	if err := f.cancelled(ctx); err != nil {
+		return nil, err
+	}
+	if f.mapped != nil {
+		return f.sliceMapped()
+	}
+	record := make([]byte, f.recordLength)
+	n, err := io.ReadFull(f.reader, record)
+	if err == io.ErrUnexpectedEOF {
+		return nil, errors.Errorf("flatfile.readFixed: Truncated record. Read %d of %d bytes after %d records", n, f.recordLength, f.records)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.records++
+	return record, nil
+}
+
+//readLine reads a complete line from FlatFile.reader
+//bufio.Reader.ReadLine returns partial lines when a line exceeds the buffer size, these are appended until the end of the line is reached
+//ctx is checked before every read so that scanning very long lines also stops promptly
+func (f *FlatFile) readLine(ctx context.Context) ([]byte, error) {
+	var line []byte
+	for {
+		if err := f.cancelled(ctx); err != nil {
+			return nil, err
+		}
+		buffLine, prefix, err := f.reader.ReadLine()
+		if err != nil {
+			return nil, err
+		}
 		line = append(line, buffLine...)
+		if !prefix {
+			f.records++
+			return line, nil
+		}
 	}
+}
 
-	return Unmarshal(line, f.objectLayout, 0, 0, false)
+//sliceMapped returns the next record of FlatFile.mapped without copying it
+func (f *FlatFile) sliceMapped() ([]byte, error) {
+	if f.offset >= len(f.mapped) {
+		return nil, io.EOF
+	}
+	end := f.offset + f.recordLength
+	if end > len(f.mapped) {
+		return nil, errors.Errorf("flatfile.readFixed: Truncated record. Read %d of %d bytes after %d records", len(f.mapped)-f.offset, f.recordLength, f.records)
+	}
+	record := f.mapped[f.offset:end:end]
+	f.offset = end
+	f.records++
+	return record, nil
+}
+
+//cancelled returns an error wrapping ctx.Err() once ctx is done
+func (f *FlatFile) cancelled(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return errors.Wrapf(err, "flatfile: Read cancelled after %d records", f.records)
+	}
+	return nil
 }
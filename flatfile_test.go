@@ -2,6 +2,8 @@ package flatfile
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -70,3 +72,29 @@ func TestFlatFileRead(t *testing.T) {
 		})
 	}
 }
+
+func TestFlatFileReadContext(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("DATA!DATA!1\nDATA!DATA!2\n"))
+	got := &testType{}
+	file, err := New(reader, got)
+	if err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := file.ReadContext(ctx); err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+	cancel()
+
+	err = file.ReadContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("flatfile.ReadContext() got error %v want %v", err, context.Canceled)
+	}
+	if err != nil && !strings.Contains(err.Error(), "after 1 records") {
+		t.Errorf("flatfile.ReadContext() error %q should report the number of records read", err)
+	}
+	if got.Number != 1 {
+		t.Errorf("flatfile.ReadContext() should not unmarshal after cancellation got %v", got)
+	}
+}
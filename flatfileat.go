@@ -0,0 +1,166 @@
+package flatfile
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+//indexSuffix is appended to a file name to get the name of its sidecar index file
+const indexSuffix = ".ffidx"
+
+//FlatFileAt is an abstraction for a flat file which reads records by record number
+//
+//For fixed length records (WithRecordLength) the offset of a record is computed directly.
+//For line delimited records a sparse Index of line offsets is used.
+type FlatFileAt struct {
+	reader       io.ReaderAt
+	size         int64
+	objectLayout interface{}
+	recordLength int
+	index        *Index
+	//closer is set when the FlatFileAt owns the underlying file
+	closer io.Closer
+}
+
+//NewAt returns a FlatFileAt reading the first size bytes of reader into objectLayout
+//For line delimited records the Index is built by scanning reader once. Use Open to persist the index between runs.
+func NewAt(reader io.ReaderAt, size int64, objectLayout interface{}, opts ...Option) (*FlatFileAt, error) {
+	if reflect.TypeOf(objectLayout).Kind() != reflect.Ptr {
+		return nil, errors.Errorf("flatfile.NewAt: %s is not a pointer", reflect.TypeOf(objectLayout))
+	}
+
+	o := newOptions(opts)
+	f := &FlatFileAt{reader: reader, size: size, objectLayout: objectLayout, recordLength: o.recordLength}
+	if f.recordLength == 0 {
+		index, err := BuildIndex(reader, size, o.stride())
+		if err != nil {
+			return nil, errors.Wrap(err, "flatfile.NewAt: Failed to build index")
+		}
+		f.index = index
+	}
+	return f, nil
+}
+
+//Open opens the named file for reading records by record number into objectLayout
+//
+//For line delimited records the Index is loaded from a sidecar file named name + ".ffidx".
+//The sidecar is rebuilt and rewritten when it is missing or was built for a file of a different size or modification time.
+func Open(name string, objectLayout interface{}, opts ...Option) (*FlatFileAt, error) {
+	if reflect.TypeOf(objectLayout).Kind() != reflect.Ptr {
+		return nil, errors.Errorf("flatfile.Open: %s is not a pointer", reflect.TypeOf(objectLayout))
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "flatfile.Open: Failed to open file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "flatfile.Open: Failed to stat file")
+	}
+
+	o := newOptions(opts)
+	f := &FlatFileAt{reader: file, size: info.Size(), objectLayout: objectLayout, recordLength: o.recordLength, closer: file}
+	if f.recordLength == 0 {
+		f.index, err = loadIndex(file, name+indexSuffix, info, o.stride())
+		if err != nil {
+			file.Close()
+			return nil, errors.Wrap(err, "flatfile.Open: Failed to load index")
+		}
+	}
+	return f, nil
+}
+
+//loadIndex reads the index persisted at path, rebuilding and persisting it when it does not match info
+func loadIndex(r io.ReaderAt, path string, info os.FileInfo, stride int) (*Index, error) {
+	if sidecar, err := os.Open(path); err == nil {
+		index, err := ReadIndex(sidecar)
+		sidecar.Close()
+		if err == nil && index.Size == info.Size() && index.ModTime == info.ModTime().UnixNano() && index.Stride == int64(stride) {
+			return index, nil
+		}
+	}
+
+	index, err := BuildIndex(r, info.Size(), stride)
+	if err != nil {
+		return nil, err
+	}
+	index.ModTime = info.ModTime().UnixNano()
+
+	//persisting the index is best effort, the index is rebuilt when the file cannot be written, for example in a read only directory
+	sidecar, err := os.Create(path)
+	if err != nil {
+		return index, nil
+	}
+	_, err = index.WriteTo(sidecar)
+	if closeErr := sidecar.Close(); err != nil || closeErr != nil {
+		//a partly written index would be rejected by ReadIndex, remove it rather than reading it again
+		os.Remove(path)
+	}
+	return index, nil
+}
+
+//Records returns the number of records in the file
+func (f *FlatFileAt) Records() int64 {
+	if f.recordLength > 0 {
+		return (f.size + int64(f.recordLength) - 1) / int64(f.recordLength)
+	}
+	return f.index.Records
+}
+
+//ReadAt reads record number n, starting at 1, and calls flatfile.Unmarshal to convert it into FlatFileAt.objectLayout
+//io.EOF is returned when n is greater than the number of records
+func (f *FlatFileAt) ReadAt(n int64) error {
+	if n < 1 {
+		return errors.Errorf("flatfile.ReadAt: Record number must be at least 1 but got %d", n)
+	}
+	if n > f.Records() {
+		return io.EOF
+	}
+
+	record, err := f.record(n)
+	if err != nil {
+		return errors.Wrapf(err, "flatfile.ReadAt: Failed to read record %d", n)
+	}
+	return errors.Wrapf(Unmarshal(record, f.objectLayout, 0, 0, false), "flatfile.ReadAt: Failed to decode record %d", n)
+}
+
+//record returns the raw bytes of record number n
+func (f *FlatFileAt) record(n int64) ([]byte, error) {
+	if f.recordLength > 0 {
+		record := make([]byte, f.recordLength)
+		read, err := f.reader.ReadAt(record, (n-1)*int64(f.recordLength))
+		if read == len(record) {
+			return record, nil
+		}
+		if err == io.EOF {
+			return nil, errors.Errorf("flatfile.record: Truncated record. Read %d of %d bytes", read, f.recordLength)
+		}
+		return nil, err
+	}
+
+	//start reading at the closest indexed record and skip forward to record n
+	i := (n - 1) / f.index.Stride
+	offset := f.index.Offsets[i]
+	file := &FlatFile{reader: bufio.NewReader(io.NewSectionReader(f.reader, offset, f.size-offset))}
+	for skip := (n - 1) % f.index.Stride; ; skip-- {
+		line, err := file.readLine(context.Background())
+		if err != nil || skip == 0 {
+			return line, err
+		}
+	}
+}
+
+//Close closes the underlying file when the FlatFileAt was created with Open
+func (f *FlatFileAt) Close() error {
+	if f.closer != nil {
+		return f.closer.Close()
+	}
+	return nil
+}
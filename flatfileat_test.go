@@ -0,0 +1,136 @@
+package flatfile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func flatFileAtTestData(n int, lineBreaks bool) string {
+	var data strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&data, "RECORD%04d%d", i, i%10)
+		if lineBreaks {
+			data.WriteString(strings.Repeat(" ", i%7) + "\n")
+		}
+	}
+	return data.String()
+}
+
+func TestFlatFileAtReadAt(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data string
+		opts []Option
+	}{
+		{desc: "Lines", data: flatFileAtTestData(100, true), opts: []Option{WithIndexStride(8)}},
+		{desc: "Lines without trailing line break", data: strings.TrimSuffix(flatFileAtTestData(100, true), "\n"), opts: []Option{WithIndexStride(10)}},
+		{desc: "Fixed length", data: flatFileAtTestData(100, false), opts: []Option{WithRecordLength(11)}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := &testType{}
+			file, err := NewAt(strings.NewReader(tC.data), int64(len(tC.data)), got, tC.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+			if file.Records() != 100 {
+				t.Errorf("FlatFileAt.Records() got: %d want: 100", file.Records())
+			}
+
+			for _, n := range []int64{1, 8, 9, 17, 50, 99, 100, 3} {
+				if err := file.ReadAt(n); err != nil {
+					t.Fatalf("Unexpected error %s", err)
+				}
+				if want := (testType{Data: fmt.Sprintf("RECORD%04d", n), Number: int(n % 10)}); *got != want {
+					t.Errorf("FlatFileAt.ReadAt(%d) got: %v want: %v", n, *got, want)
+				}
+			}
+
+			if err := file.ReadAt(101); err != io.EOF {
+				t.Errorf("FlatFileAt.ReadAt(101) got error %v want %v", err, io.EOF)
+			}
+			if err := file.ReadAt(0); err == nil {
+				t.Error("FlatFileAt.ReadAt(0) should return out of range error")
+			}
+		})
+	}
+}
+
+func TestFlatFileAtNew_Err(t *testing.T) {
+	if _, err := NewAt(strings.NewReader(""), 0, testType{}); err == nil {
+		t.Errorf("Expected not a pointer error")
+	}
+}
+
+func TestFlatFileAtOpen(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "records.txt")
+	if err := os.WriteFile(name, []byte(flatFileAtTestData(20, true)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &testType{}
+	file, err := Open(name, got, WithIndexStride(4))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if err := file.ReadAt(14); err != nil || got.Data != "RECORD0014" {
+		t.Errorf("FlatFileAt.ReadAt(14) got: %v err: %v", got, err)
+	}
+	file.Close()
+
+	sidecar, err := os.Open(name + indexSuffix)
+	if err != nil {
+		t.Fatalf("Open should persist the index: %s", err)
+	}
+	index, err := ReadIndex(sidecar)
+	sidecar.Close()
+	if err != nil || index.Records != 20 || index.Stride != 4 {
+		t.Fatalf("ReadIndex() got: %v err: %v", index, err)
+	}
+
+	//a stale index must be rebuilt once the file changes
+	if err := os.WriteFile(name, []byte(flatFileAtTestData(30, true)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(name, later, later); err != nil {
+		t.Fatal(err)
+	}
+	file, err = Open(name, got, WithIndexStride(4))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	defer file.Close()
+	if file.Records() != 30 {
+		t.Errorf("FlatFileAt.Records() got: %d want: 30", file.Records())
+	}
+	if err := file.ReadAt(27); err != nil || got.Data != "RECORD0027" {
+		t.Errorf("FlatFileAt.ReadAt(27) got: %v err: %v", got, err)
+	}
+}
+
+func TestFlatFileAtOpenUnwritableIndex(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "records.txt")
+	if err := os.WriteFile(name, []byte(flatFileAtTestData(20, true)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	//a directory in the way of the index file stands in for a read only directory
+	if err := os.Mkdir(name+indexSuffix, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &testType{}
+	file, err := Open(name, got, WithIndexStride(4))
+	if err != nil {
+		t.Fatalf("Open() should not fail when the index cannot be persisted: %s", err)
+	}
+	defer file.Close()
+	if err := file.ReadAt(14); err != nil || got.Data != "RECORD0014" {
+		t.Errorf("FlatFileAt.ReadAt(14) got: %v err: %v", got, err)
+	}
+}
@@ -1,3 +1,7 @@
 module github.com/ahmedalhulaibi/flatfile
 
-go 1.12
+go 1.23
+
+require github.com/pkg/errors v0.9.1
+
+require gopkg.in/yaml.v3 v3.0.1
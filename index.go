@@ -0,0 +1,127 @@
+package flatfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+//defaultIndexStride is the number of records between offsets in an Index when WithIndexStride is not provided
+const defaultIndexStride = 1024
+
+//indexChunk is the number of offsets ReadIndex reads at once
+const indexChunk = 4096
+
+//indexMagic identifies a persisted Index
+var indexMagic = [8]byte{'F', 'F', 'I', 'D', 'X', 0, 0, 1}
+
+//Index is a sparse index of the byte offsets of records in a line delimited flat file
+//The offset of every Stride-th record is kept, starting with the first record
+type Index struct {
+	//Size is the size of the indexed file in bytes
+	Size int64
+	//ModTime is the modification time of the indexed file in Unix nanoseconds, zero when unknown
+	ModTime int64
+	//Stride is the number of records between offsets
+	Stride int64
+	//Records is the number of records in the indexed file
+	Records int64
+	//Offsets holds the byte offset of records 1, 1+Stride, 1+2*Stride, ...
+	Offsets []int64
+}
+
+//BuildIndex scans the first size bytes of r and returns an Index of the offsets of every stride-th line
+func BuildIndex(r io.ReaderAt, size int64, stride int) (*Index, error) {
+	if stride < 1 {
+		return nil, errors.Errorf("flatfile.BuildIndex: Stride must be at least 1 but got %d", stride)
+	}
+
+	index := &Index{Size: size, Stride: int64(stride)}
+	if size == 0 {
+		return index, nil
+	}
+	index.Offsets = append(index.Offsets, 0)
+	index.Records = 1
+
+	buf := make([]byte, shardScanSize)
+	for offset := int64(0); offset < size; {
+		chunk := buf
+		if remaining := size - offset; remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		n, err := r.ReadAt(chunk, offset)
+		if n == 0 && err != nil {
+			return nil, errors.Wrapf(err, "flatfile.BuildIndex: Failed to read at offset %d", offset)
+		}
+
+		chunk = chunk[:n]
+		for pos := 0; ; pos++ {
+			idx := bytes.IndexByte(chunk[pos:], '\n')
+			if idx < 0 {
+				break
+			}
+			pos += idx
+			//a line break at the very end of the file does not start another record
+			next := offset + int64(pos) + 1
+			if next == size {
+				break
+			}
+			if index.Records%index.Stride == 0 {
+				index.Offsets = append(index.Offsets, next)
+			}
+			index.Records++
+		}
+		offset += int64(n)
+	}
+	return index, nil
+}
+
+//WriteTo writes the index to w in a binary format which can be read by ReadIndex
+func (index *Index) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	buf.Write(indexMagic[:])
+	for _, value := range []int64{index.Size, index.ModTime, index.Stride, index.Records, int64(len(index.Offsets))} {
+		binary.Write(&buf, binary.LittleEndian, value)
+	}
+	binary.Write(&buf, binary.LittleEndian, index.Offsets)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), errors.Wrap(err, "flatfile.Index.WriteTo: Failed to write index")
+}
+
+//ReadIndex reads an index written by Index.WriteTo
+func ReadIndex(r io.Reader) (*Index, error) {
+	var magic [8]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, errors.Wrap(err, "flatfile.ReadIndex: Failed to read index header")
+	}
+	if magic != indexMagic {
+		return nil, errors.New("flatfile.ReadIndex: Not a flatfile index")
+	}
+
+	index := &Index{}
+	var offsets int64
+	for _, value := range []*int64{&index.Size, &index.ModTime, &index.Stride, &index.Records, &offsets} {
+		if err := binary.Read(r, binary.LittleEndian, value); err != nil {
+			return nil, errors.Wrap(err, "flatfile.ReadIndex: Failed to read index header")
+		}
+	}
+	//every record holds at least its line break, so a file cannot have more records than bytes
+	if index.Size < 0 || index.Stride < 1 || index.Records < 0 || index.Records > index.Size ||
+		offsets != (index.Records+index.Stride-1)/index.Stride {
+		return nil, errors.New("flatfile.ReadIndex: Corrupt index header")
+	}
+
+	//offsets are read in chunks so a count larger than the bytes which remain fails without allocating for all of them
+	index.Offsets = make([]int64, 0, min(int(offsets), indexChunk))
+	for remaining := offsets; remaining > 0; remaining -= indexChunk {
+		chunk := make([]int64, min(int(remaining), indexChunk))
+		if err := binary.Read(r, binary.LittleEndian, chunk); err != nil {
+			return nil, errors.Wrap(err, "flatfile.ReadIndex: Failed to read index offsets")
+		}
+		index.Offsets = append(index.Offsets, chunk...)
+	}
+	return index, nil
+}
@@ -0,0 +1,66 @@
+package flatfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestBuildIndex(t *testing.T) {
+	data := "A\nBB\nCCC\nDDDD\nEEEEE\n"
+	index, err := BuildIndex(strings.NewReader(data), int64(len(data)), 2)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	want := []int64{0, 5, 14}
+	if index.Records != 5 || len(index.Offsets) != len(want) {
+		t.Fatalf("BuildIndex() got: %v want offsets: %v", index, want)
+	}
+	for i := range want {
+		if index.Offsets[i] != want[i] {
+			t.Errorf("BuildIndex() got offsets: %v want: %v", index.Offsets, want)
+		}
+	}
+
+	if _, err := BuildIndex(strings.NewReader(data), int64(len(data)), 0); err == nil {
+		t.Error("BuildIndex() should return error when stride is less than 1")
+	}
+}
+
+func TestIndexWriteToReadIndex(t *testing.T) {
+	index := &Index{Size: 100, ModTime: 12345, Stride: 3, Records: 7, Offsets: []int64{0, 30, 60}}
+
+	var buf bytes.Buffer
+	if _, err := index.WriteTo(&buf); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	got, err := ReadIndex(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if got.Size != index.Size || got.ModTime != index.ModTime || got.Stride != index.Stride || got.Records != index.Records || len(got.Offsets) != 3 || got.Offsets[2] != 60 {
+		t.Errorf("ReadIndex() got: %v want: %v", got, index)
+	}
+
+	if _, err := ReadIndex(strings.NewReader("NOTANINDEX")); err == nil {
+		t.Error("ReadIndex() should return error when reading data which is not an index")
+	}
+	if _, err := ReadIndex(bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err == nil {
+		t.Error("ReadIndex() should return error when reading a truncated index")
+	}
+
+	//corrupt headers must not allocate for offsets which are not there
+	for _, header := range [][]int64{
+		{100, 0, 1, 1 << 40, 1 << 40},
+		{1 << 62, 0, 1, 1 << 60, 1 << 60},
+		{100, 0, 1, -1, 0},
+	} {
+		var corrupt bytes.Buffer
+		corrupt.Write(indexMagic[:])
+		binary.Write(&corrupt, binary.LittleEndian, header)
+		if _, err := ReadIndex(&corrupt); err == nil {
+			t.Errorf("ReadIndex() should return error when reading the corrupt header %v", header)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+//Package copybooktest declares the structs generated by cmd/copybook2go from customer.cpy, which are used to test the generator
+package copybooktest
+
+//go:generate go run ../../cmd/copybook2go -output customer.go customer.cpy
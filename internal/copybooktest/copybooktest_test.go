@@ -0,0 +1,59 @@
+package copybooktest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmedalhulaibi/flatfile"
+)
+
+//record is a CUSTOMER-RECORD as written by a COBOL program
+var record = []byte("000042AMY       LEE            A\x00\x12\x34\x56\x7d0001250\x00\x2a012J  " +
+	"H4165551234W9055556789" + "20240131" + "\x00\x01\x2f\x00\x00\x98\x76\x54\x3c" + " 1,234.50-" + "FLAG")
+
+func TestUnmarshalGenerated(t *testing.T) {
+	if length, err := flatfile.RecordLength(CustomerRecord{}); err != nil || length != len(record) {
+		t.Fatalf("RecordLength() = %d, %v, want %d", length, err, len(record))
+	}
+
+	got := CustomerRecord{}
+	if err := flatfile.Unmarshal(record, &got, 0, 0, false); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := CustomerRecord{
+		CustId:      42,
+		CustName:    CustName{FirstName: "AMY       ", LastName: "LEE            "},
+		CustStatus:  "A",
+		Balance:     -12345.67,
+		CreditLimit: 12.5,
+		Visits:      42,
+		Adjustment:  -12.1,
+		Phones:      [2]Phones{{"H", 4165551234}, {"W", 9055556789}},
+		OpenedDate:  "20240131",
+		OpenedParts: OpenedParts{OpenedYear: 2024, OpenedMonth: 1, OpenedDay: 31},
+		Totals:      Totals{OrderCount: 12, OrderTotal: 98765.43},
+		PrintAmount: " 1,234.50-",
+		LegacyFlags: "FLAG",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+
+	data, err := flatfile.Marshal(got)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != string(record) {
+		t.Errorf("Marshal() = %q, want %q", data, record)
+	}
+}
+
+func TestUnmarshalTrailer(t *testing.T) {
+	got := CustomerTrailer{}
+	if err := flatfile.Unmarshal([]byte("TRAILR\x00\x01\x86\xa0"), &got, 0, 0, false); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.TrailerId != "TRAILR" || got.RecordCount != 100000 {
+		t.Errorf("Unmarshal() = %+v", got)
+	}
+}
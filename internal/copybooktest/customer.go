@@ -0,0 +1,56 @@
+// Code generated by copybook2go from customer.cpy; DO NOT EDIT.
+
+package copybooktest
+
+// CustomerRecord is record CUSTOMER-RECORD on line 4 of customer.cpy. The record length is 105.
+type CustomerRecord struct {
+	CustId   uint64   `flatfile:"1,6"`
+	CustName CustName `flatfile:"7,25"`
+	// 88 STATUS-ACTIVE VALUE 'A'
+	// 88 STATUS-CLOSED VALUE 'C' 'X'
+	CustStatus  string    `flatfile:"32,1"`
+	Balance     float64   `flatfile:"33,5,enc=packed,dec=2"`
+	CreditLimit float64   `flatfile:"38,7,dec=2"`
+	Visits      int64     `flatfile:"45,2,enc=binary"`
+	Adjustment  float64   `flatfile:"47,4,enc=zoned,dec=1"`
+	Phones      [2]Phones `flatfile:"53,11"`
+	OpenedDate  string    `flatfile:"75,8"`
+	// OPENED-PARTS redefines OPENED-DATE. Both fields are decoded from columns 75 to 82.
+	OpenedParts OpenedParts `flatfile:"75,8"`
+	Totals      Totals      `flatfile:"83,9"`
+	PrintAmount string      `flatfile:"92,10"`
+	// Unsupported: SYNC is not supported, alignment bytes are not added to the layout
+	LegacyFlags string `flatfile:"102,4"`
+}
+
+// CustName is group CUST-NAME on line 6 of customer.cpy.
+type CustName struct {
+	FirstName string `flatfile:"1,10"`
+	LastName  string `flatfile:"11,15"`
+}
+
+// Phones is group PHONES on line 17 of customer.cpy.
+type Phones struct {
+	PhoneType   string `flatfile:"1,1"`
+	PhoneNumber uint64 `flatfile:"2,10"`
+}
+
+// OpenedParts is group OPENED-PARTS on line 21 of customer.cpy.
+type OpenedParts struct {
+	OpenedYear  uint64 `flatfile:"1,4"`
+	OpenedMonth uint64 `flatfile:"5,2"`
+	OpenedDay   uint64 `flatfile:"7,2"`
+}
+
+// Totals is group TOTALS on line 25 of customer.cpy.
+type Totals struct {
+	OrderCount uint64  `flatfile:"1,3,enc=packed"`
+	OrderTotal float64 `flatfile:"4,6,enc=packed,dec=2"`
+}
+
+// CustomerTrailer is record CUSTOMER-TRAILER on line 30 of customer.cpy. The record length is 10.
+// It redefines CUSTOMER-RECORD.
+type CustomerTrailer struct {
+	TrailerId   string `flatfile:"1,6"`
+	RecordCount uint64 `flatfile:"7,4,enc=ubinary"`
+}
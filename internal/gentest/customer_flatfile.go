@@ -0,0 +1,324 @@
+// Code generated by flatfilegen; DO NOT EDIT.
+
+package gentest
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ahmedalhulaibi/flatfile"
+)
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to flatfile.Unmarshal(data, v, 0, 0, false).
+func (v *Customer) UnmarshalFlatfile(data []byte) error {
+	// Name `flatfile:"1,10"`
+	if len(data) > 0 {
+		fieldData1 := data[0:min(10, len(data))]
+		v.Name = string(fieldData1)
+	}
+	// Age `flatfile:"11,3"`
+	if len(data) > 10 {
+		fieldData2 := data[10:min(13, len(data))]
+		if len(fieldData2) < 3 {
+			err := flatfile.ErrCutShort
+			return flatfile.DecodeError("Age", fieldData2, err)
+		}
+		if n, err := flatfile.ParseUint(fieldData2, 8); err != nil {
+			return flatfile.DecodeError("Age", fieldData2, err)
+		} else {
+			v.Age = uint8(n)
+		}
+	}
+	// Score `flatfile:"14,5"`
+	if len(data) > 13 {
+		fieldData3 := data[13:min(18, len(data))]
+		if len(fieldData3) < 5 {
+			err := flatfile.ErrCutShort
+			return flatfile.DecodeError("Score", fieldData3, err)
+		}
+		if n, err := flatfile.ParseInt(fieldData3, 0); err != nil {
+			return flatfile.DecodeError("Score", fieldData3, err)
+		} else {
+			v.Score = int(n)
+		}
+	}
+	// Active `flatfile:"19,1"`
+	if len(data) > 18 {
+		fieldData4 := data[18:min(19, len(data))]
+		if b, err := flatfile.ParseBool(fieldData4); err != nil {
+			return flatfile.DecodeError("Active", fieldData4, err)
+		} else {
+			v.Active = bool(b)
+		}
+	}
+	// Grade `flatfile:"20,1,ovr=byte"`
+	if len(data) > 19 {
+		fieldData5 := data[19:min(20, len(data))]
+		v.Grade = byte(fieldData5[0])
+	}
+	// Initial `flatfile:"21,1,ovr=rune"`
+	if len(data) > 20 {
+		fieldData6 := data[20:min(21, len(data))]
+		if r, err := flatfile.ParseRune(fieldData6); err != nil {
+			return flatfile.DecodeError("Initial", fieldData6, err)
+		} else {
+			v.Initial = rune(r)
+		}
+	}
+	// Status `flatfile:"22,1"`
+	if len(data) > 21 {
+		fieldData7 := data[21:min(22, len(data))]
+		v.Status = Status(fieldData7)
+	}
+	// Ratio `flatfile:"23,6"`
+	if len(data) > 22 {
+		fieldData8 := data[22:min(28, len(data))]
+		if len(fieldData8) < 6 {
+			err := flatfile.ErrCutShort
+			return flatfile.DecodeError("Ratio", fieldData8, err)
+		}
+		if n, err := flatfile.ParseFloat(fieldData8, 32); err != nil {
+			return flatfile.DecodeError("Ratio", fieldData8, err)
+		} else {
+			v.Ratio = float32(n)
+		}
+	}
+	// Address `flatfile:"29,25"`
+	if len(data) > 28 {
+		fieldData9 := data[28:min(53, len(data))]
+		if err := v.Address.UnmarshalFlatfile(fieldData9); err != nil {
+			return flatfile.DecodeError("Address", fieldData9, err)
+		}
+	}
+	// Balance `flatfile:"54,13"`
+	if len(data) > 53 {
+		fieldData10 := data[53:min(66, len(data))]
+		if v.Balance == nil {
+			v.Balance = new(Balance)
+		}
+		if err := flatfile.Unmarshal(fieldData10, v.Balance, 0, 0, false); err != nil {
+			return flatfile.DecodeError("Balance", fieldData10, err)
+		}
+	}
+	// Phones `flatfile:"67,10"`
+	if len(data) > 66 {
+		fieldData11 := data[66:min(86, len(data))]
+		for i12 := 0; i12 < 2; i12++ {
+			if i12*10 >= len(fieldData11) {
+				break
+			}
+			elemData13 := fieldData11[i12*10 : min((i12+1)*10, len(fieldData11))]
+			if len(elemData13) < 10 {
+				err := flatfile.ErrCutShort
+				err = flatfile.DecodeError(fmt.Sprintf("[%d]", i12), elemData13, err)
+				return flatfile.DecodeError("Phones", fieldData11, err)
+			}
+			if n, err := flatfile.ParseInt(elemData13, 64); err != nil {
+				err = flatfile.DecodeError(fmt.Sprintf("[%d]", i12), elemData13, err)
+				return flatfile.DecodeError("Phones", fieldData11, err)
+			} else {
+				v.Phones[i12] = int64(n)
+			}
+		}
+	}
+	// Tags `flatfile:"87,4,occurs=3"`
+	if len(data) > 86 {
+		fieldData14 := data[86:min(98, len(data))]
+		v.Tags = make([]string, 3)
+		for i15 := 0; i15 < 3; i15++ {
+			if i15*4 >= len(fieldData14) {
+				break
+			}
+			elemData16 := fieldData14[i15*4 : min((i15+1)*4, len(fieldData14))]
+			v.Tags[i15] = string(elemData16)
+		}
+	}
+	// Nickname `flatfile:"99,8,optional"`
+	if len(data) > 98 {
+		fieldData17 := data[98:min(106, len(data))]
+		if len(bytes.TrimLeft(fieldData17, " ")) == 0 {
+			v.Nickname = nil
+		} else {
+			if v.Nickname == nil {
+				v.Nickname = new(string)
+			}
+			(*v.Nickname) = string(fieldData17)
+		}
+	}
+	// Discount `flatfile:"107,3,cond=22-1-V"`
+	if string(data[min(21, len(data)):min(22, len(data))]) == "V" {
+		if len(data) > 106 {
+			fieldData18 := data[106:min(109, len(data))]
+			if len(fieldData18) < 3 {
+				err := flatfile.ErrCutShort
+				return flatfile.DecodeError("Discount", fieldData18, err)
+			}
+			if n, err := flatfile.ParseInt(fieldData18, 16); err != nil {
+				return flatfile.DecodeError("Discount", fieldData18, err)
+			} else {
+				v.Discount = int16(n)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to flatfile.Marshal(v).
+func (v Customer) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 109)
+	// Name `flatfile:"1,10"`
+	if err := flatfile.EncodeString(data[0:10], string(v.Name)); err != nil {
+		return nil, fmt.Errorf("Customer.Name: %w", err)
+	}
+	// Age `flatfile:"11,3"`
+	if err := flatfile.EncodeUint(data[10:13], uint64(v.Age)); err != nil {
+		return nil, fmt.Errorf("Customer.Age: %w", err)
+	}
+	// Score `flatfile:"14,5"`
+	if err := flatfile.EncodeInt(data[13:18], int64(v.Score)); err != nil {
+		return nil, fmt.Errorf("Customer.Score: %w", err)
+	}
+	// Active `flatfile:"19,1"`
+	if err := flatfile.EncodeBool(data[18:19], bool(v.Active)); err != nil {
+		return nil, fmt.Errorf("Customer.Active: %w", err)
+	}
+	// Grade `flatfile:"20,1,ovr=byte"`
+	data[19] = byte(v.Grade)
+	// Initial `flatfile:"21,1,ovr=rune"`
+	if err := flatfile.EncodeRune(data[20:21], rune(v.Initial)); err != nil {
+		return nil, fmt.Errorf("Customer.Initial: %w", err)
+	}
+	// Status `flatfile:"22,1"`
+	if err := flatfile.EncodeString(data[21:22], string(v.Status)); err != nil {
+		return nil, fmt.Errorf("Customer.Status: %w", err)
+	}
+	// Ratio `flatfile:"23,6"`
+	if err := flatfile.EncodeFloat(data[22:28], float64(v.Ratio), 32); err != nil {
+		return nil, fmt.Errorf("Customer.Ratio: %w", err)
+	}
+	// Address `flatfile:"29,25"`
+	if record19, err := v.Address.MarshalFlatfile(); err != nil {
+		return nil, fmt.Errorf("Customer.Address: %w", err)
+	} else if len(record19) > len(data[28:53]) {
+		err := flatfile.ErrValueTooLong
+		return nil, fmt.Errorf("Customer.Address: %w", err)
+	} else {
+		copy(data[28:53], record19)
+	}
+	// Balance `flatfile:"54,13"`
+	if v.Balance != nil {
+		if record20, err := flatfile.Marshal((*v.Balance)); err != nil {
+			return nil, fmt.Errorf("Customer.Balance: %w", err)
+		} else if len(record20) > len(data[53:66]) {
+			err := flatfile.ErrValueTooLong
+			return nil, fmt.Errorf("Customer.Balance: %w", err)
+		} else {
+			copy(data[53:66], record20)
+		}
+	}
+	// Phones `flatfile:"67,10"`
+	for i21 := range v.Phones {
+		if err := flatfile.EncodeInt(data[66:86][i21*10:(i21+1)*10], int64(v.Phones[i21])); err != nil {
+			return nil, fmt.Errorf("Customer.Phones: %w", err)
+		}
+	}
+	// Tags `flatfile:"87,4,occurs=3"`
+	if len(v.Tags) > 3 {
+		err := fmt.Errorf("%d elements exceed the 3 occurrences of the field", len(v.Tags))
+		return nil, fmt.Errorf("Customer.Tags: %w", err)
+	}
+	for i22 := range v.Tags {
+		if err := flatfile.EncodeString(data[86:98][i22*4:(i22+1)*4], string(v.Tags[i22])); err != nil {
+			return nil, fmt.Errorf("Customer.Tags: %w", err)
+		}
+	}
+	// Nickname `flatfile:"99,8,optional"`
+	if v.Nickname != nil {
+		if err := flatfile.EncodeString(data[98:106], string((*v.Nickname))); err != nil {
+			return nil, fmt.Errorf("Customer.Nickname: %w", err)
+		}
+	}
+	// Discount `flatfile:"107,3,cond=22-1-V"`
+	if string(data[min(21, len(data)):min(22, len(data))]) == "V" {
+		if err := flatfile.EncodeInt(data[106:109], int64(v.Discount)); err != nil {
+			return nil, fmt.Errorf("Customer.Discount: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to flatfile.Unmarshal(data, v, 0, 0, false).
+func (v *Address) UnmarshalFlatfile(data []byte) error {
+	// Street `flatfile:"1,15"`
+	if len(data) > 0 {
+		fieldData23 := data[0:min(15, len(data))]
+		v.Street = string(fieldData23)
+	}
+	// City `flatfile:"16,10"`
+	if len(data) > 15 {
+		fieldData24 := data[15:min(25, len(data))]
+		v.City = string(fieldData24)
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to flatfile.Marshal(v).
+func (v Address) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 25)
+	// Street `flatfile:"1,15"`
+	if err := flatfile.EncodeString(data[0:15], string(v.Street)); err != nil {
+		return nil, fmt.Errorf("Address.Street: %w", err)
+	}
+	// City `flatfile:"16,10"`
+	if err := flatfile.EncodeString(data[15:25], string(v.City)); err != nil {
+		return nil, fmt.Errorf("Address.City: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to flatfile.Unmarshal(data, v, 0, 0, false).
+func (v *Codes) UnmarshalFlatfile(data []byte) error {
+	// Values `flatfile:"1,1,occurs=2"`
+	if len(data) > 0 {
+		fieldData25 := data[0:min(2, len(data))]
+		for i26 := 0; i26 < 3; i26++ {
+			if i26*1 >= len(fieldData25) {
+				break
+			}
+			elemData27 := fieldData25[i26*1 : min((i26+1)*1, len(fieldData25))]
+			v.Values[i26] = string(elemData27)
+		}
+	}
+	// Suffix `flatfile:"3,2"`
+	if len(data) > 2 {
+		fieldData28 := data[2:min(4, len(data))]
+		v.Suffix = string(fieldData28)
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to flatfile.Marshal(v).
+func (v Codes) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 4)
+	// Values `flatfile:"1,1,occurs=2"`
+	if len(v.Values) > 2 {
+		err := fmt.Errorf("%d elements exceed the 2 occurrences of the field", len(v.Values))
+		return nil, fmt.Errorf("Codes.Values: %w", err)
+	}
+	for i29 := range v.Values {
+		if err := flatfile.EncodeString(data[0:2][i29*1:(i29+1)*1], string(v.Values[i29])); err != nil {
+			return nil, fmt.Errorf("Codes.Values: %w", err)
+		}
+	}
+	// Suffix `flatfile:"3,2"`
+	if err := flatfile.EncodeString(data[2:4], string(v.Suffix)); err != nil {
+		return nil, fmt.Errorf("Codes.Suffix: %w", err)
+	}
+	return data, nil
+}
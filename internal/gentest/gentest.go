@@ -0,0 +1,45 @@
+//Package gentest declares the types used to test the code generated by cmd/flatfilegen against the reflection based Unmarshal and Marshal
+package gentest
+
+//go:generate go run ../../cmd/flatfilegen -type Customer,Address,Codes -output customer_flatfile.go
+
+//Status is a named type with a basic underlying type
+type Status string
+
+//Address is nested in Customer and has generated methods
+type Address struct {
+	Street string `flatfile:"1,15"`
+	City   string `flatfile:"16,10"`
+}
+
+//Balance is nested in Customer without generated methods
+type Balance struct {
+	Amount   float64 `flatfile:"1,10"`
+	Currency string  `flatfile:"11,3"`
+}
+
+//Customer covers every kind of field supported by flatfilegen
+type Customer struct {
+	Name     string   `flatfile:"1,10"`
+	Age      uint8    `flatfile:"11,3"`
+	Score    int      `flatfile:"14,5"`
+	Active   bool     `flatfile:"19,1"`
+	Grade    byte     `flatfile:"20,1,ovr=byte"`
+	Initial  rune     `flatfile:"21,1,ovr=rune"`
+	Status   Status   `flatfile:"22,1"`
+	Ratio    float32  `flatfile:"23,6"`
+	Address  Address  `flatfile:"29,25"`
+	Balance  *Balance `flatfile:"54,13"`
+	Phones   [2]int64 `flatfile:"67,10"`
+	Tags     []string `flatfile:"87,4,occurs=3"`
+	Nickname *string  `flatfile:"99,8,optional"`
+	//Discount is only present for customers with status V
+	Discount int16 `flatfile:"107,3,cond=22-1-V"`
+	Notes    string
+}
+
+//Codes has an array with more elements than the occurrences of its field, which Marshal rejects
+type Codes struct {
+	Values [3]string `flatfile:"1,1,occurs=2"`
+	Suffix string    `flatfile:"3,2"`
+}
@@ -0,0 +1,199 @@
+package gentest
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ahmedalhulaibi/flatfile"
+)
+
+//addressReflect and customerReflect have the same layout as Address and Customer without generated methods
+type addressReflect struct {
+	Street string `flatfile:"1,15"`
+	City   string `flatfile:"16,10"`
+}
+
+type customerReflect struct {
+	Name     string         `flatfile:"1,10"`
+	Age      uint8          `flatfile:"11,3"`
+	Score    int            `flatfile:"14,5"`
+	Active   bool           `flatfile:"19,1"`
+	Grade    byte           `flatfile:"20,1,ovr=byte"`
+	Initial  rune           `flatfile:"21,1,ovr=rune"`
+	Status   Status         `flatfile:"22,1"`
+	Ratio    float32        `flatfile:"23,6"`
+	Address  addressReflect `flatfile:"29,25"`
+	Balance  *Balance       `flatfile:"54,13"`
+	Phones   [2]int64       `flatfile:"67,10"`
+	Tags     []string       `flatfile:"87,4,occurs=3"`
+	Nickname *string        `flatfile:"99,8,optional"`
+	Discount int16          `flatfile:"107,3,cond=22-1-V"`
+	Notes    string
+}
+
+func TestGeneratedMethodsImplementInterfaces(t *testing.T) {
+	var _ flatfile.Unmarshaler = &Customer{}
+	var _ flatfile.Marshaler = Customer{}
+	var _ flatfile.Unmarshaler = &Address{}
+	var _ flatfile.Marshaler = Address{}
+}
+
+func TestUnmarshalFlatfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"All fields", "AMY       019-0042TAxV01.500123 FAKE STREETTORONTO   0000012.50CAD41655512300000000000HOMEWORK    AMYLEE  015", false},
+		{"Condition not met", "BOB       03700004FByR-2.000456 OLD STREET OTTAWA    0000000.00USD61355500000000000001A   B   C   BOBBY   0X5", false},
+		{"Short record", "CAROL     021-0001", false},
+		{"Record ending within a string", "CAROL", false},
+		{"Record ending within a number", "CAROL     021-00", true},
+		{"Invalid number", "DAN       X21", true},
+		{"Invalid array element", "AMY       019-0042TAxV01.500123 FAKE STREETTORONTO   0000012.50CAD41655XX2300000000000HOMEWORK    AMYLEE  015", true},
+		{"Missing array element", "AMY       019-0042TAxV01.500123 FAKE STREETTORONTO   0000012.50CAD4165551230", false},
+		{"Cut short array element", "AMY       019-0042TAxV01.500123 FAKE STREETTORONTO   0000012.50CAD416555123000000", true},
+		{"Invalid conditional number", "AMY       019-0042TAxV01.500123 FAKE STREETTORONTO   0000012.50CAD41655512300000000000HOMEWORK    AMYLEE  0X5", true},
+		{"Invalid nested number", "AMY       019-0042TAxV01.500123 FAKE STREETTORONTO   00000X2.50CAD41655512300000000000HOMEWORK    AMYLEE  015", true},
+		{"Invalid rune", "EVE       019-0042TA\xffV", true},
+		{"Blank optional pointer", "AMY       019-0042TAxV01.500123 FAKE STREETTORONTO   0000012.50CAD41655512300000000000HOMEWORK            015", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//nil pointers are allocated, except optional pointers whose data is blank
+			nickname, nicknameReflect := "", ""
+			generated := &Customer{Nickname: &nickname}
+			reflected := &customerReflect{Nickname: &nicknameReflect}
+
+			err := flatfile.Unmarshal([]byte(tt.data), generated, 0, 0, false)
+			errReflect := flatfile.Unmarshal([]byte(tt.data), reflected, 0, 0, false)
+			if (err != nil) != tt.wantErr || (errReflect != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, reflection error = %v, wantErr %v", err, errReflect, tt.wantErr)
+			}
+			if got, want := fieldError(err), fieldError(errReflect); got != want {
+				t.Errorf("Unmarshal() error = %s, reflection error = %s", got, want)
+			}
+			if got, want := toJSON(t, generated), toJSON(t, reflected); got != want {
+				t.Errorf("Unmarshal() = %s, reflection = %s", got, want)
+			}
+		})
+	}
+}
+
+func TestMarshalFlatfile(t *testing.T) {
+	nickname := "AMYLEE"
+	tests := []struct {
+		name     string
+		customer customerReflect
+		wantErr  bool
+	}{
+		{"All fields", customerReflect{
+			Name: "AMY", Age: 19, Score: -42, Active: true, Grade: 'A', Initial: 'x', Status: "V", Ratio: 1.5,
+			Address: addressReflect{Street: "123 FAKE STREET", City: "TORONTO"}, Balance: &Balance{Amount: 12.5, Currency: "CAD"},
+			Phones: [2]int64{4165551230}, Tags: []string{"HOME", "WORK"}, Nickname: &nickname, Discount: 15, Notes: "ignored",
+		}, false},
+		{"Condition not met", customerReflect{Name: "BOB", Status: "R", Discount: 15, Tags: []string{"A"}}, false},
+		{"Nil pointers", customerReflect{Name: "CAROL"}, false},
+		{"Value too long", customerReflect{Name: "CAROLINE ANNE"}, true},
+		{"Too many slice elements", customerReflect{Tags: []string{"A", "B", "C", "D"}}, true},
+		{"Nested value too long", customerReflect{Address: addressReflect{City: "NIAGARA FALLS"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generated := &Customer{}
+			fromJSON(t, toJSON(t, tt.customer), generated)
+
+			got, err := flatfile.Marshal(generated)
+			want, errReflect := flatfile.Marshal(tt.customer)
+			if (err != nil) != tt.wantErr || (errReflect != nil) != tt.wantErr {
+				t.Fatalf("Marshal() error = %v, reflection error = %v, wantErr %v", err, errReflect, tt.wantErr)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Marshal() = %q, reflection = %q", got, want)
+			}
+		})
+	}
+}
+
+func TestMarshalFlatfileArrayOccurs(t *testing.T) {
+	type codesReflect struct {
+		Values [3]string `flatfile:"1,1,occurs=2"`
+		Suffix string    `flatfile:"3,2"`
+	}
+	for _, values := range [][3]string{{"1", "2", "Z"}, {"1", "2", ""}} {
+		got, err := flatfile.Marshal(Codes{Values: values, Suffix: "ZZ"})
+		want, errReflect := flatfile.Marshal(codesReflect{Values: values, Suffix: "ZZ"})
+		if err == nil || errReflect == nil {
+			t.Errorf("Marshal(%q) = %q, %v, reflection = %q, %v, want errors", values, got, err, want, errReflect)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	nickname := "AMYLEE"
+	want := Customer{
+		Name: "AMY       ", Age: 19, Score: -42, Active: true, Grade: 'A', Initial: 'x', Status: "V", Ratio: 1.5,
+		Address: Address{Street: "123 FAKE STREET", City: "TORONTO   "}, Balance: &Balance{Amount: 12.5, Currency: "CAD"},
+		Phones: [2]int64{4165551230, 0}, Tags: []string{"HOME", "WORK", "    "}, Nickname: &nickname, Discount: 15,
+	}
+	data, err := flatfile.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), want.Name) {
+		t.Errorf("Marshal() = %q", data)
+	}
+
+	gotNickname := ""
+	got := Customer{Balance: &Balance{}, Nickname: &gotNickname}
+	want.Nickname = &nickname
+	if err := got.UnmarshalFlatfile(data); err != nil {
+		t.Fatalf("UnmarshalFlatfile() error = %v", err)
+	}
+	*want.Nickname = "AMYLEE  "
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalFlatfile() = %+v, want %+v", got, want)
+	}
+}
+
+//fieldError describes the FieldError of err, a decode error of the generated methods or of reflection
+func fieldError(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	var fieldErr *flatfile.FieldError
+	if !errors.As(err, &fieldErr) {
+		return "not a FieldError: " + err.Error()
+	}
+	return fieldErr.Error()
+}
+
+func toJSON(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func fromJSON(t *testing.T, data string, v interface{}) {
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnmarshalWithOptions(t *testing.T) {
+	//decoding options are applied with reflection, the generated methods would ignore them
+	var got Customer
+	err := flatfile.UnmarshalWith([]byte("DAN       X21"), &got, flatfile.WithLenient())
+	var fieldErrs flatfile.FieldErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 1 || fieldErrs[0].Field != "Age" {
+		t.Fatalf("UnmarshalWith() error = %v, want FieldErrors for Age", err)
+	}
+	if got.Name != "DAN       " {
+		t.Errorf("UnmarshalWith() got: %+v", got)
+	}
+}
@@ -0,0 +1,97 @@
+package flatfile
+
+import (
+	"reflect"
+	"sync"
+)
+
+//fieldLayout is the parsed flatfile tag of a struct field
+type fieldLayout struct {
+	//tagged is true when the field has a flatfile tag
+	tagged bool
+	rawTag string
+	tag    flatfileTag
+	//tagErr is the error returned when parsing rawTag, reported when the field is unmarshalled
+	tagErr error
+	//embedded is true for an untagged exported embedded struct or pointer to struct, whose fields are flattened into
+	//the struct embedding it with columns relative to the same data
+	embedded bool
+}
+
+//structLayout holds the parsed flatfile tags of the fields of a struct type, indexed by field index
+type structLayout struct {
+	fields []fieldLayout
+}
+
+//layoutCache maps a struct reflect.Type to its *structLayout
+var layoutCache sync.Map
+
+//layoutOf returns the layout of struct type t, parsing its tags on first use
+func layoutOf(t reflect.Type) *structLayout {
+	if cached, ok := layoutCache.Load(t); ok {
+		return cached.(*structLayout)
+	}
+
+	layout := &structLayout{fields: make([]fieldLayout, t.NumField())}
+	for i := range layout.fields {
+		fieldTag, tagFlag := t.Field(i).Tag.Lookup("flatfile")
+		if !tagFlag {
+			layout.fields[i].embedded = flattened(t.Field(i))
+			continue
+		}
+		field := &layout.fields[i]
+		field.tagged = true
+		field.rawTag = fieldTag
+		field.tagErr = parseFlatfileTag(fieldTag, &field.tag)
+		if field.tagErr == nil {
+			field.tagErr = checkRules(&field.tag, t.Field(i).Type)
+		}
+		if field.tagErr == nil {
+			field.tagErr = checkUnion(&field.tag, t.Field(i).Type)
+		}
+		if field.tagErr == nil {
+			field.tagErr = checkOptional(&field.tag, t.Field(i).Type)
+		}
+	}
+	//conditions are bound to the fields they compare once all tags are parsed
+	for i := range layout.fields {
+		if field := &layout.fields[i]; field.tagErr == nil {
+			field.tagErr = field.tag.cond.resolve(t, layout, i)
+		}
+		if field := &layout.fields[i]; field.tagErr == nil {
+			field.tagErr = field.tag.disc.resolve(t, layout, i)
+		}
+	}
+
+	cached, _ := layoutCache.LoadOrStore(t, layout)
+	return cached.(*structLayout)
+}
+
+//flattened returns true when the fields of an untagged struct field are decoded as fields of the struct declaring it
+func flattened(f reflect.StructField) bool {
+	fieldType := embeddedStruct(f.Type)
+	return f.Anonymous && f.IsExported() && fieldType.Kind() == reflect.Struct && fieldType != timeType
+}
+
+//embeddedStruct returns the struct type of an embedded struct or pointer to struct
+func embeddedStruct(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+//embeddedValue returns the embedded struct value field, allocating it when it is a nil pointer and alloc is true
+//ok is false for a nil pointer which is not allocated.
+func embeddedValue(field reflect.Value, alloc bool) (embedded reflect.Value, ok bool) {
+	if field.Kind() != reflect.Ptr {
+		return field, true
+	}
+	if field.IsNil() {
+		if !alloc {
+			return field, false
+		}
+		field.Set(reflect.New(field.Type().Elem()))
+	}
+	return field.Elem(), true
+}
@@ -0,0 +1,83 @@
+package flatfile
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+//MappedFile is a read only flat file of fixed length records which is memory mapped where the platform supports it
+//
+//Records read with NewMappedReader are slices of the mapping and are not copied.
+//On platforms without memory mapping, or when mapping fails, records are read with buffered I/O instead.
+type MappedFile struct {
+	file *os.File
+	size int64
+	//data is the mapped contents of file, nil when the file is not mapped
+	data []byte
+}
+
+//OpenMapped opens and memory maps the named file
+func OpenMapped(name string) (*MappedFile, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "flatfile.OpenMapped: Failed to open file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "flatfile.OpenMapped: Failed to stat file")
+	}
+
+	m := &MappedFile{file: file, size: info.Size()}
+	if m.size > 0 {
+		//a file which cannot be mapped is still read using buffered I/O
+		if data, err := mapFile(file, m.size); err == nil {
+			m.data = data
+		}
+	}
+	return m, nil
+}
+
+//Mapped reports whether the file is memory mapped
+func (m *MappedFile) Mapped() bool {
+	return m.data != nil
+}
+
+//Size returns the size of the file in bytes
+func (m *MappedFile) Size() int64 {
+	return m.size
+}
+
+//Close unmaps and closes the file
+//Strings decoded with WithUnsafeStrings must not be used after Close.
+func (m *MappedFile) Close() error {
+	var err error
+	if m.data != nil {
+		err = unmapFile(m.data)
+		m.data = nil
+	}
+	if closeErr := m.file.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Wrap(err, "flatfile.MappedFile.Close: Failed to close file")
+}
+
+//NewMappedReader returns a Reader which decodes fixed length records of type T from m
+//WithRecordLength must be provided. Combine with WithUnsafeStrings to decode string fields without allocating.
+func NewMappedReader[T any](m *MappedFile, opts ...Option) *Reader[T] {
+	o := newOptions(opts)
+	var file *FlatFile
+	if m.data != nil {
+		file = &FlatFile{mapped: m.data, recordLength: o.recordLength}
+	} else {
+		file = o.newFlatFile(io.NewSectionReader(m.file, 0, m.size))
+	}
+
+	reader := newReader[T](file, 1, o)
+	if o.recordLength < 1 && reader.err == nil {
+		reader.err = errors.New("flatfile.NewMappedReader: WithRecordLength must be provided")
+	}
+	return reader
+}
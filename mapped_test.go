@@ -0,0 +1,79 @@
+package flatfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func mappedTestFile(t *testing.T, data string) *MappedFile {
+	name := filepath.Join(t.TempDir(), "records.txt")
+	if err := os.WriteFile(name, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := OpenMapped(name)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+func TestMappedReader(t *testing.T) {
+	m := mappedTestFile(t, flatFileAtTestData(50, false))
+	if runtime.GOOS == "linux" && !m.Mapped() {
+		t.Error("OpenMapped() should memory map the file on linux")
+	}
+
+	for _, opts := range [][]Option{{WithRecordLength(11)}, {WithRecordLength(11), WithUnsafeStrings()}} {
+		records, err := NewMappedReader[testType](m, opts...).ReadAll()
+		if err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+		if len(records) != 50 {
+			t.Fatalf("NewMappedReader() got %d records want 50", len(records))
+		}
+		for i, record := range records {
+			if want := (testType{Data: fmt.Sprintf("RECORD%04d", i+1), Number: (i + 1) % 10}); record != want {
+				t.Errorf("NewMappedReader() got: %v want: %v", record, want)
+			}
+		}
+	}
+}
+
+func TestMappedReaderFallback(t *testing.T) {
+	m := mappedTestFile(t, flatFileAtTestData(5, false))
+	//simulate a platform without memory mapping
+	unmapFile(m.data)
+	m.data = nil
+
+	records, err := NewMappedReader[testType](m, WithRecordLength(11), WithUnsafeStrings()).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if len(records) != 5 || records[4].Data != "RECORD0005" {
+		t.Errorf("NewMappedReader() got: %v", records)
+	}
+}
+
+func TestMappedReaderTruncatedErr(t *testing.T) {
+	m := mappedTestFile(t, strings.TrimSuffix(flatFileAtTestData(3, false), "3"))
+	records, err := NewMappedReader[testType](m, WithRecordLength(11)).ReadAll()
+	if err == nil {
+		t.Error("NewMappedReader() should return truncated record error")
+	}
+	if len(records) != 2 {
+		t.Errorf("NewMappedReader() got %d records before the error want 2", len(records))
+	}
+	t.Log(err)
+}
+
+func TestMappedReaderRecordLengthErr(t *testing.T) {
+	m := mappedTestFile(t, flatFileAtTestData(1, false))
+	if _, err := NewMappedReader[testType](m).ReadAll(); err == nil {
+		t.Error("NewMappedReader() should return error when record length is not provided")
+	}
+}
@@ -0,0 +1,283 @@
+package flatfile
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+//ErrValueTooLong is returned when a value does not fit in the columns of its field
+var ErrValueTooLong = errors.New("flatfile: value does not fit in field")
+
+//Unmarshaler is implemented by types which decode a record themselves, such as types with methods generated by cmd/flatfilegen
+//Unmarshal calls UnmarshalFlatfile instead of using reflection when all fields are unmarshalled.
+//Options such as WithLenient, WithNumericPolicy and WithUnsafeStrings are applied with reflection instead.
+type Unmarshaler interface {
+	UnmarshalFlatfile(data []byte) error
+}
+
+//Marshaler is implemented by types which encode a record themselves, such as types with methods generated by cmd/flatfilegen
+//Marshal calls MarshalFlatfile instead of using reflection.
+type Marshaler interface {
+	MarshalFlatfile() ([]byte, error)
+}
+
+/*Marshal encodes v, a struct or pointer to struct, into a record using the layout defined by its flatfile tags
+
+The record is RecordLength(v) bytes long. Columns not covered by a field are spaces.
+
+Strings are left aligned and padded with spaces. Numbers are right aligned and padded with zeros.
+Bools are written as T or F in fields of length 1, otherwise as true or false.
+Nil pointers and slice elements beyond the length of the slice are left blank.
+
+A field with a condition is only written when the condition is met by the fields written before it.
+ErrValueTooLong is returned when a value does not fit in its field.
+*/
+func Marshal(v interface{}) ([]byte, error) {
+	if m, ok := v.(Marshaler); ok {
+		return m.MarshalFlatfile()
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, errors.Errorf("flatfile.Marshal: %s is not a struct or pointer to struct", reflect.TypeOf(v))
+	}
+	return marshalStruct(value)
+}
+
+//marshalStruct encodes the fields of a struct value into a new record
+func marshalStruct(value reflect.Value) ([]byte, error) {
+	vType := value.Type()
+	length, err := recordLength(vType)
+	if err != nil {
+		return nil, errors.Wrap(err, "flatfile.Marshal: Failed to determine record length")
+	}
+
+	data := bytes.Repeat([]byte{' '}, length)
+	if err := encodeStruct(value, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+//encodeStruct encodes the fields of a struct value into data, the fields of untagged embedded structs included
+func encodeStruct(value reflect.Value, data []byte) error {
+	vType := value.Type()
+	for i, field := range layoutOf(vType).fields {
+		if field.embedded {
+			if embedded, ok := embeddedValue(value.Field(i), false); ok {
+				if err := encodeStruct(embedded, data); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !field.tagged {
+			continue
+		}
+		ffpTag := &field.tag
+		if !conditionMet(ffpTag, data, value) {
+			continue
+		}
+
+		if ffpTag.union {
+			if err := checkVariants(value.Field(i)); err != nil {
+				return errors.Wrapf(err, "flatfile.Marshal: Failed to marshal field %s", vType.Field(i).Name)
+			}
+		}
+
+		lowerBound := ffpTag.col - 1
+		upperBound := lowerBound + ffpTag.length*occurrences(ffpTag, vType.Field(i).Type)
+		if err := encodeBasedOnKind(value.Field(i), data[lowerBound:upperBound], ffpTag); err != nil {
+			return errors.Wrapf(err, "flatfile.Marshal: Failed to marshal field %s", vType.Field(i).Name)
+		}
+	}
+	return nil
+}
+
+//conditionMet returns true when the field has no condition or the condition holds for data and the fields of the struct value v
+//Unlike ShouldUnmarshal, a condition in the form col-len-value outside of data is not met. Schemas pass an invalid v.
+func conditionMet(ffpTag *flatfileTag, data []byte, v reflect.Value) bool {
+	if ffpTag.condChk && ffpTag.condCol-1+ffpTag.condLen > len(data) {
+		return false
+	}
+	return shouldUnmarshal(ffpTag, data, v)
+}
+
+//encodeBasedOnKind writes field into dst based on kind
+func encodeBasedOnKind(field reflect.Value, dst []byte, ffpTag *flatfileTag) error {
+	if ffpTag.numeric() {
+		switch field.Kind() {
+		case reflect.Struct, reflect.Ptr, reflect.Array, reflect.Slice:
+			//the encoding and decimals options apply to the elements
+		default:
+			return encodeNumeric(field, dst, ffpTag)
+		}
+	}
+	switch field.Kind() {
+	case reflect.Bool:
+		return EncodeBool(dst, field.Bool())
+	case reflect.Uint8:
+		if ffpTag.override == "byte" {
+			dst[0] = byte(field.Uint())
+			return nil
+		}
+		return EncodeUint(dst, field.Uint())
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return EncodeUint(dst, field.Uint())
+	case reflect.Int32:
+		if ffpTag.override == "rune" {
+			return EncodeRune(dst, rune(field.Int()))
+		}
+		return EncodeInt(dst, field.Int())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+		return EncodeInt(dst, field.Int())
+	case reflect.Float32:
+		return EncodeFloat(dst, field.Float(), 32)
+	case reflect.Float64:
+		return EncodeFloat(dst, field.Float(), 64)
+	case reflect.String:
+		return EncodeString(dst, field.String())
+	case reflect.Struct:
+		if field.Type() == timeType && ffpTag.format != "" {
+			if !field.CanInterface() {
+				return errors.New("flatfile.encodeBasedOnKind: Unexported time.Time fields cannot be marshalled")
+			}
+			return EncodeString(dst, field.Interface().(time.Time).Format(ffpTag.format))
+		}
+		var record []byte
+		var err error
+		if m, ok := marshaler(field); ok {
+			record, err = m.MarshalFlatfile()
+		} else {
+			record, err = marshalStruct(field)
+		}
+		if err != nil {
+			return err
+		}
+		if len(record) > len(dst) {
+			return ErrValueTooLong
+		}
+		copy(dst, record)
+	case reflect.Ptr:
+		if !field.IsNil() {
+			return encodeBasedOnKind(field.Elem(), dst, ffpTag)
+		}
+	case reflect.Array, reflect.Slice:
+		if field.Len()*ffpTag.length > len(dst) {
+			return errors.Errorf("flatfile.encodeBasedOnKind: %d elements exceed the %d occurrences of the field", field.Len(), len(dst)/ffpTag.length)
+		}
+		for i := 0; i < field.Len(); i++ {
+			lowerBound := i * ffpTag.length
+			upperBound := lowerBound + ffpTag.length
+			if err := encodeBasedOnKind(field.Index(i), dst[lowerBound:upperBound], ffpTag); err != nil {
+				return errors.Wrapf(err, "flatfile.encodeBasedOnKind: Failed to marshal element %d", i)
+			}
+		}
+	default:
+		return errors.Errorf("flatfile.encodeBasedOnKind: Unsupported kind %s", field.Kind())
+	}
+	return nil
+}
+
+//marshaler returns the Marshaler implemented by field, if any
+func marshaler(field reflect.Value) (Marshaler, bool) {
+	if !field.CanInterface() {
+		return nil, false
+	}
+	m, ok := field.Interface().(Marshaler)
+	return m, ok
+}
+
+//EncodeString writes s into dst left aligned and padded with spaces
+func EncodeString(dst []byte, s string) error {
+	if len(s) > len(dst) {
+		return ErrValueTooLong
+	}
+	n := copy(dst, s)
+	fill(dst[n:], ' ')
+	return nil
+}
+
+//EncodeInt writes n into dst right aligned and padded with zeros, after the sign of negative numbers
+func EncodeInt(dst []byte, n int64) error {
+	var buf [20]byte
+	return encodeNumber(dst, strconv.AppendInt(buf[:0], n, 10))
+}
+
+//EncodeUint writes n into dst right aligned and padded with zeros
+func EncodeUint(dst []byte, n uint64) error {
+	var buf [20]byte
+	return encodeNumber(dst, strconv.AppendUint(buf[:0], n, 10))
+}
+
+//EncodeFloat writes f into dst in decimal notation, right aligned and padded with zeros, after the sign of negative numbers
+//NaN and infinite values must fill dst exactly.
+func EncodeFloat(dst []byte, f float64, bitSize int) error {
+	var buf [32]byte
+	number := strconv.AppendFloat(buf[:0], f, 'f', -1, bitSize)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		if len(number) != len(dst) {
+			return ErrValueTooLong
+		}
+	}
+	return encodeNumber(dst, number)
+}
+
+//EncodeBool writes b into dst as T or F when dst is 1 byte long, otherwise as true or false
+func EncodeBool(dst []byte, b bool) error {
+	if len(dst) == 1 {
+		dst[0] = 'F'
+		if b {
+			dst[0] = 'T'
+		}
+		return nil
+	}
+
+	//ParseBool does not accept padding so the value must fill dst
+	if s := strconv.FormatBool(b); len(s) == len(dst) {
+		copy(dst, s)
+		return nil
+	}
+	return errors.Errorf("flatfile.EncodeBool: %v cannot be written to a field of length %d", b, len(dst))
+}
+
+//EncodeRune writes the UTF-8 encoding of r into dst left aligned and padded with spaces
+func EncodeRune(dst []byte, r rune) error {
+	if utf8.RuneLen(r) > len(dst) {
+		return ErrValueTooLong
+	}
+	n := utf8.EncodeRune(dst, r)
+	fill(dst[n:], ' ')
+	return nil
+}
+
+//encodeNumber writes number into dst right aligned, with zeros between the sign and the digits
+func encodeNumber(dst []byte, number []byte) error {
+	if len(number) > len(dst) {
+		return ErrValueTooLong
+	}
+	sign := 0
+	if number[0] == '-' {
+		dst[0] = '-'
+		sign = 1
+	}
+	padding := len(dst) - len(number)
+	fill(dst[sign:sign+padding], '0')
+	copy(dst[sign+padding:], number[sign:])
+	return nil
+}
+
+func fill(dst []byte, c byte) {
+	for i := range dst {
+		dst[i] = c
+	}
+}
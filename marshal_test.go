@@ -0,0 +1,177 @@
+package flatfile
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type marshalAddress struct {
+	City    string `flatfile:"1,8"`
+	Country string `flatfile:"9,2"`
+}
+
+type marshalRecord struct {
+	Name     string          `flatfile:"1,5"`
+	Age      uint8           `flatfile:"6,3"`
+	Balance  int             `flatfile:"9,5"`
+	Active   bool            `flatfile:"14,1"`
+	Grade    byte            `flatfile:"15,1,ovr=byte"`
+	Initial  rune            `flatfile:"16,1,ovr=rune"`
+	Ratio    float64         `flatfile:"17,5"`
+	Address  marshalAddress  `flatfile:"22,10"`
+	Previous *marshalAddress `flatfile:"32,10"`
+	Phones   [2]uint16       `flatfile:"42,3"`
+	Tags     []string        `flatfile:"48,2,occurs=2"`
+	Bonus    int8            `flatfile:"52,2,cond=14-1-T"`
+	Comment  string
+}
+
+//selfMarshaler encodes itself without reflection
+type selfMarshaler struct {
+	Value string `flatfile:"1,3"`
+}
+
+func (s selfMarshaler) MarshalFlatfile() ([]byte, error) {
+	return []byte("SELF"), nil
+}
+
+func TestMarshal(t *testing.T) {
+	previous := &marshalAddress{City: "OTTAWA", Country: "CA"}
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    string
+		wantErr error
+	}{
+		{"All fields", marshalRecord{
+			Name: "AMY", Age: 19, Balance: -42, Active: true, Grade: 'A', Initial: 'x', Ratio: 1.25,
+			Address: marshalAddress{City: "TORONTO", Country: "CA"}, Previous: previous,
+			Phones: [2]uint16{416, 905}, Tags: []string{"AB", "CD"}, Bonus: 7, Comment: "ignored",
+		}, "AMY  019-0042TAx01.25TORONTO CAOTTAWA  CA416905ABCD07", nil},
+		{"Pointer to struct", &marshalRecord{Name: "BOB", Tags: []string{"AB"}}, "BOB  00000000F\x00\x0000000                    000000AB    ", nil},
+		{"Condition not met", marshalRecord{Bonus: 7}, "     00000000F\x00\x0000000                    000000      ", nil},
+		{"String too long", marshalRecord{Name: "CAROLINE"}, "", ErrValueTooLong},
+		{"Number too long", marshalRecord{Age: 255, Balance: 123456}, "", ErrValueTooLong},
+		{"Nested too long", marshalRecord{Address: marshalAddress{City: "NIAGARAFALLS"}}, "", ErrValueTooLong},
+		{"Marshaler", selfMarshaler{Value: "ABC"}, "SELF", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.v)
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("Marshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && string(got) != tt.want {
+				t.Errorf("Marshal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"Not a struct", 1},
+		{"Nil pointer", (*marshalRecord)(nil)},
+		{"Too many slice elements", marshalRecord{Tags: []string{"A", "B", "C"}}},
+		{"Bool does not fit", struct {
+			Flag bool `flatfile:"1,3"`
+		}{}},
+		{"Unsupported kind", struct {
+			Values map[string]int `flatfile:"1,3"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Marshal(tt.v); err == nil {
+				t.Errorf("Marshal() expected error")
+			}
+		})
+	}
+}
+
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	want := marshalRecord{
+		Name: "AMY  ", Age: 19, Balance: -42, Active: true, Grade: 'A', Initial: 'x', Ratio: 1.25,
+		Address: marshalAddress{City: "TORONTO ", Country: "CA"}, Previous: &marshalAddress{City: "OTTAWA  ", Country: "CA"},
+		Phones: [2]uint16{416, 905}, Tags: []string{"AB", "CD"}, Bonus: 7,
+	}
+	data, err := Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	got := marshalRecord{Previous: &marshalAddress{}}
+	if err := Unmarshal(data, &got, 0, 0, false); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Name != want.Name || got.Age != want.Age || got.Balance != want.Balance || got.Active != want.Active ||
+		got.Grade != want.Grade || got.Initial != want.Initial || got.Ratio != want.Ratio || got.Address != want.Address ||
+		*got.Previous != *want.Previous || got.Phones != want.Phones || len(got.Tags) != 2 || got.Tags[1] != "CD" || got.Bonus != want.Bonus {
+		t.Errorf("Unmarshal(Marshal()) = %+v, want %+v", got, want)
+	}
+}
+
+type timeRecord struct {
+	Opened time.Time `flatfile:"1,8,fmt=20060102"`
+	Closed time.Time `flatfile:"9,6,format=150405"`
+}
+
+func TestTimeRoundTrip(t *testing.T) {
+	var got timeRecord
+	if err := Unmarshal([]byte("19991231235958"), &got, 0, 0, false); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if want := time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC); !got.Opened.Equal(want) {
+		t.Errorf("Unmarshal() Opened = %v, want %v", got.Opened, want)
+	}
+	if want := time.Date(0, 1, 1, 23, 59, 58, 0, time.UTC); !got.Closed.Equal(want) {
+		t.Errorf("Unmarshal() Closed = %v, want %v", got.Closed, want)
+	}
+
+	data, err := Marshal(got)
+	if err != nil || string(data) != "19991231235958" {
+		t.Errorf("Marshal() = %q, %v", data, err)
+	}
+	if err := Unmarshal([]byte("1999123X235958"), &got, 0, 0, false); err == nil {
+		t.Errorf("Unmarshal() expected error for an invalid date")
+	}
+}
+
+func TestEncodeNumbers(t *testing.T) {
+	tests := []struct {
+		name    string
+		length  int
+		encode  func([]byte) error
+		want    string
+		wantErr bool
+	}{
+		{"Int", 5, func(b []byte) error { return EncodeInt(b, 42) }, "00042", false},
+		{"Negative int", 5, func(b []byte) error { return EncodeInt(b, -42) }, "-0042", false},
+		{"Int too long", 2, func(b []byte) error { return EncodeInt(b, -42) }, "", true},
+		{"Uint", 3, func(b []byte) error { return EncodeUint(b, 7) }, "007", false},
+		{"Float", 6, func(b []byte) error { return EncodeFloat(b, -1.5, 64) }, "-001.5", false},
+		{"Float32", 5, func(b []byte) error { return EncodeFloat(b, 0.1, 32) }, "000.1", false},
+		{"NaN", 3, func(b []byte) error { return EncodeFloat(b, math.NaN(), 64) }, "NaN", false},
+		{"Padded NaN", 4, func(b []byte) error { return EncodeFloat(b, math.NaN(), 64) }, "", true},
+		{"Bool", 5, func(b []byte) error { return EncodeBool(b, false) }, "false", false},
+		{"Rune", 3, func(b []byte) error { return EncodeRune(b, 'é') }, "é ", false},
+		{"Rune too long", 1, func(b []byte) error { return EncodeRune(b, 'é') }, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := make([]byte, tt.length)
+			err := tt.encode(dst)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encode error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && string(dst) != tt.want {
+				t.Errorf("encode = %q, want %q", dst, tt.want)
+			}
+		})
+	}
+}
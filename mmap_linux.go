@@ -0,0 +1,16 @@
+package flatfile
+
+import (
+	"os"
+	"syscall"
+)
+
+//mapFile maps size bytes of file into memory for reading
+func mapFile(file *os.File, size int64) ([]byte, error) {
+	return syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+//unmapFile releases memory returned by mapFile
+func unmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
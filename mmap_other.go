@@ -0,0 +1,17 @@
+//go:build !linux
+
+package flatfile
+
+import (
+	"errors"
+	"os"
+)
+
+//mapFile is not supported on this platform. MappedFile falls back to buffered reads.
+func mapFile(file *os.File, size int64) ([]byte, error) {
+	return nil, errors.New("flatfile.mapFile: Memory mapping is not supported on this platform")
+}
+
+func unmapFile(data []byte) error {
+	return nil
+}
@@ -0,0 +1,176 @@
+package flatfile
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//NumericClass describes the text of a numeric field. Classes are combined, for example " -0012" is space padded, zero padded and has a leading sign.
+//Digits with an optional decimal point and no padding or sign have no class.
+type NumericClass uint8
+
+const (
+	//NumericBlank is a field of spaces only
+	NumericBlank NumericClass = 1 << iota
+	//NumericZeroPadded has leading zeros before another digit, for example 0012
+	NumericZeroPadded
+	//NumericSpacePadded has spaces before or after the number, for example "  12" or "12  "
+	NumericSpacePadded
+	//NumericLeadingSign has a + or - before the digits
+	NumericLeadingSign
+	//NumericTrailingSign has a + or - after the digits, as written by COBOL, for example 12-
+	NumericTrailingSign
+	//NumericInvalid is not a number, for example "12 3" or "1X". Exponents and a second decimal point are invalid.
+	NumericInvalid
+)
+
+//Policies for WithNumericPolicy
+const (
+	//NumericStrict accepts what strconv accepts: zero padding and leading signs
+	NumericStrict = NumericZeroPadded | NumericLeadingSign
+	//NumericLenient also decodes blank fields as zero, trims spaces around the number and accepts trailing signs
+	NumericLenient = NumericStrict | NumericBlank | NumericSpacePadded | NumericTrailingSign
+)
+
+var numericClassNames = []string{"blank", "zero padded", "space padded", "leading sign", "trailing sign", "invalid"}
+
+func (c NumericClass) String() string {
+	if c == 0 {
+		return "plain"
+	}
+	var names []string
+	for i, name := range numericClassNames {
+		if c&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+//ClassifyNumeric returns the class of the text of a numeric field
+func ClassifyNumeric(b []byte) NumericClass {
+	start, end := 0, len(b)
+	for start < end && b[start] == ' ' {
+		start++
+	}
+	for end > start && b[end-1] == ' ' {
+		end--
+	}
+	if start == end {
+		return NumericBlank
+	}
+
+	var class NumericClass
+	if start > 0 || end < len(b) {
+		class |= NumericSpacePadded
+	}
+	digits := b[start:end]
+	switch {
+	case digits[0] == '+' || digits[0] == '-':
+		class |= NumericLeadingSign
+		digits = digits[1:]
+	case digits[len(digits)-1] == '+' || digits[len(digits)-1] == '-':
+		class |= NumericTrailingSign
+		digits = digits[:len(digits)-1]
+	}
+	if len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9' {
+		class |= NumericZeroPadded
+	}
+
+	point, n := false, 0
+	for _, c := range digits {
+		switch {
+		case c >= '0' && c <= '9':
+			n++
+		case c == '.' && !point:
+			point = true
+		default:
+			return class | NumericInvalid
+		}
+	}
+	if n == 0 {
+		return class | NumericInvalid
+	}
+	return class
+}
+
+//NumericError is a numeric field which the policy of WithNumericPolicy does not accept, or which fails to parse
+type NumericError struct {
+	Data  string
+	Class NumericClass
+	//Err is the error of strconv when the field is accepted but fails to parse, for example when it is out of range
+	Err error
+}
+
+func (e *NumericError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("flatfile: Numeric field %q (%s) failed to parse: %v", e.Data, e.Class, e.Err)
+	}
+	return fmt.Sprintf("flatfile: Numeric field %q is not accepted: %s", e.Data, e.Class)
+}
+
+func (e *NumericError) Unwrap() error {
+	return e.Err
+}
+
+//normalizeNumber returns fieldData in the form parsed by strconv when its class is accepted by the policy of the decoder
+//blank is true when a blank field is accepted, the field is then zero.
+func (d *decoder) normalizeNumber(fieldData []byte) (normalized []byte, class NumericClass, blank bool, err error) {
+	class = ClassifyNumeric(fieldData)
+	if class&NumericInvalid != 0 || class&^d.numericPolicy != 0 {
+		return nil, class, false, &NumericError{Data: string(fieldData), Class: class}
+	}
+	if class == NumericBlank {
+		return nil, class, true, nil
+	}
+
+	normalized = fieldData
+	if class&NumericSpacePadded != 0 {
+		start, end := 0, len(normalized)
+		for normalized[start] == ' ' {
+			start++
+		}
+		for normalized[end-1] == ' ' {
+			end--
+		}
+		normalized = normalized[start:end]
+	}
+	if class&NumericTrailingSign != 0 {
+		last := len(normalized) - 1
+		normalized = append([]byte{normalized[last]}, normalized[:last]...)
+	}
+	return normalized, class, false, nil
+}
+
+//assignPolicyNumber assigns a number field which is not encoded after checking its class against the policy of the decoder
+func (d *decoder) assignPolicyNumber(kind reflect.Kind, field reflect.Value, fieldData []byte, ffpTag *flatfileTag) error {
+	normalized, class, blank, err := d.normalizeNumber(fieldData)
+	if err != nil {
+		return err
+	}
+	if blank {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	policy := *d
+	policy.numericPolicySet = false
+	//the normalized data is shorter than the field when padding is removed
+	normalizedTag := *ffpTag
+	normalizedTag.length = len(normalized)
+	if err := policy.assignBasedOnKind(kind, field, normalized, &normalizedTag); err != nil {
+		cause := errors.Cause(err)
+		if numErr, ok := cause.(*strconv.NumError); ok {
+			if numErr.Err == strconv.ErrSyntax {
+				class |= NumericInvalid
+			}
+			cause = numErr.Err
+		}
+		return &NumericError{Data: string(fieldData), Class: class, Err: cause}
+	}
+	return nil
+}
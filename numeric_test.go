@@ -0,0 +1,114 @@
+package flatfile
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestClassifyNumeric(t *testing.T) {
+	tests := []struct {
+		data string
+		want NumericClass
+	}{
+		{"123", 0},
+		{"12.5", 0},
+		{"0", 0},
+		{"", NumericBlank},
+		{"    ", NumericBlank},
+		{"0012", NumericZeroPadded},
+		{"  12", NumericSpacePadded},
+		{"12  ", NumericSpacePadded},
+		{"-12", NumericLeadingSign},
+		{"+12", NumericLeadingSign},
+		{"12-", NumericTrailingSign},
+		{" -0012", NumericSpacePadded | NumericZeroPadded | NumericLeadingSign},
+		{"012+ ", NumericSpacePadded | NumericZeroPadded | NumericTrailingSign},
+		{"12 3", NumericInvalid},
+		{"1X", NumericInvalid},
+		{"-", NumericLeadingSign | NumericInvalid},
+		{"1.2.3", NumericInvalid},
+		{"1e5", NumericInvalid},
+		{"-12-", NumericLeadingSign | NumericInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			if got := ClassifyNumeric([]byte(tt.data)); got != tt.want {
+				t.Errorf("ClassifyNumeric(%q) = %s, want %s", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericClassString(t *testing.T) {
+	if got := (NumericSpacePadded | NumericLeadingSign).String(); got != "space padded, leading sign" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := NumericClass(0).String(); got != "plain" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+type policyRecord struct {
+	Int   int     `flatfile:"1,5"`
+	Uint  uint8   `flatfile:"6,4"`
+	Float float64 `flatfile:"10,6"`
+}
+
+func TestWithNumericPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    NumericClass
+		data      string
+		want      policyRecord
+		wantClass NumericClass
+		wantErr   error
+	}{
+		{"Strict", NumericStrict, "-001200120012.5", policyRecord{-12, 12, 12.5}, 0, nil},
+		{"Strict blank", NumericStrict, "     ", policyRecord{}, NumericBlank, nil},
+		{"Strict space padded", NumericStrict, "   12", policyRecord{}, NumericSpacePadded, nil},
+		{"Strict trailing sign", NumericStrict, "0012-", policyRecord{}, NumericZeroPadded | NumericTrailingSign, nil},
+		{"Invalid", NumericLenient, "12 3 ", policyRecord{}, NumericSpacePadded | NumericInvalid, nil},
+		{"Lenient", NumericLenient, "  12- 12   12.5", policyRecord{-12, 12, 12.5}, 0, nil},
+		{"Lenient blank", NumericLenient, "               ", policyRecord{}, 0, nil},
+		{"No padding", NumericLeadingSign, "-12340012", policyRecord{Int: -1234}, NumericZeroPadded, nil},
+		{"Out of range", NumericLenient, "00001 256", policyRecord{Int: 1}, NumericSpacePadded, strconv.ErrRange},
+		{"Decimal point in int", NumericLenient, "1.5  ", policyRecord{}, NumericInvalid | NumericSpacePadded, strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record policyRecord
+			err := UnmarshalWith([]byte(tt.data), &record, WithNumericPolicy(tt.policy))
+			if tt.wantClass == 0 && tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("UnmarshalWith() unexpected error %s", err)
+				}
+			} else {
+				var numErr *NumericError
+				if !errors.As(err, &numErr) {
+					t.Fatalf("UnmarshalWith() error = %v, want NumericError", err)
+				}
+				if numErr.Class != tt.wantClass || numErr.Err != tt.wantErr {
+					t.Errorf("NumericError = %s, %v want %s, %v", numErr.Class, numErr.Err, tt.wantClass, tt.wantErr)
+				}
+			}
+			if record != tt.want {
+				t.Errorf("UnmarshalWith() got: %v want: %v", record, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericPolicyLenient(t *testing.T) {
+	var record policyRecord
+	err := UnmarshalWith([]byte("12 3   X 12.5  "), &record, WithNumericPolicy(NumericStrict), WithLenient())
+	want := []string{"Int decode 12 3 ", "Uint decode   X ", "Float decode 12.5  "}
+	if got := describeFieldErrors(t, err); strings.Join(got, ";") != strings.Join(want, ";") {
+		t.Errorf("UnmarshalWith() = %v, want %v", got, want)
+	}
+	if !strings.Contains(err.Error(), `"  X " is not accepted: space padded, invalid`) {
+		t.Errorf("UnmarshalWith() error = %s", err)
+	}
+}
@@ -0,0 +1,187 @@
+package flatfile
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+//Option configures how records are read by a Reader
+type Option func(*options)
+
+//options holds the configuration applied by Option values
+type options struct {
+	bufferSize   int
+	recordLength int
+	indexStride  int
+	ctx          context.Context
+	workers      int
+	batchSize    int
+	unordered    bool
+	decoder      decoder
+	errorSink    func(*RecordError)
+	reject       *rejectWriter
+	//errorBudget is the number of records which may fail to decode, or -1 when there is no limit
+	errorBudget int
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{ctx: context.Background(), errorBudget: -1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+//newBufferedReader wraps r in a bufio.Reader using the configured buffer size
+func (o *options) newBufferedReader(r io.Reader) *bufio.Reader {
+	if o.bufferSize > 0 {
+		return bufio.NewReaderSize(r, o.bufferSize)
+	}
+	return bufio.NewReader(r)
+}
+
+//WithBufferSize sets the size of the buffer used to read the underlying io.Reader
+//Lines longer than the buffer are still read in full
+func WithBufferSize(size int) Option {
+	return func(o *options) {
+		o.bufferSize = size
+	}
+}
+
+//WithContext stops reading when ctx is cancelled or its deadline is exceeded
+//The error reported once reading stops wraps ctx.Err() and includes how many records were read
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+//WithRecordLength reads records of exactly length bytes which are not separated by line breaks
+//By default every line is a record. RecordLength returns the length of a record layout.
+func WithRecordLength(length int) Option {
+	return func(o *options) {
+		o.recordLength = length
+	}
+}
+
+//WithIndexStride sets how many records apart the offsets kept in an Index are
+//A smaller stride makes FlatFileAt.ReadAt faster at the cost of a larger index. The default is 1024.
+func WithIndexStride(stride int) Option {
+	return func(o *options) {
+		o.indexStride = stride
+	}
+}
+
+//stride returns the configured index stride or the default
+func (o *options) stride() int {
+	if o.indexStride > 0 {
+		return o.indexStride
+	}
+	return defaultIndexStride
+}
+
+//newFlatFile returns a FlatFile reading r using the configured buffer size and framing
+func (o *options) newFlatFile(r io.Reader) *FlatFile {
+	return &FlatFile{reader: o.newBufferedReader(r), recordLength: o.recordLength}
+}
+
+//WithWorkers sets the number of goroutines a ParallelReader uses to decode records
+//The default is runtime.GOMAXPROCS(0)
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		o.workers = workers
+	}
+}
+
+//WithBatchSize sets the number of records a ParallelReader hands to a worker at a time
+func WithBatchSize(size int) Option {
+	return func(o *options) {
+		o.batchSize = size
+	}
+}
+
+//WithUnordered lets a ParallelReader return batches of records as soon as they are decoded instead of in file order
+func WithUnordered() Option {
+	return func(o *options) {
+		o.unordered = true
+	}
+}
+
+//WithUnsafeStrings makes string fields share memory with the record they were decoded from instead of copying it
+//
+//This avoids an allocation per string field. Records read by NewMappedReader are slices of the memory mapped file,
+//so strings decoded from them must not be used after the MappedFile is closed. Copy any string which must outlive it,
+//for example with strings.Clone.
+func WithUnsafeStrings() Option {
+	return func(o *options) {
+		o.decoder.aliasStrings = true
+	}
+}
+
+//WithLenient decodes every field of a record instead of stopping at the first field which fails to decode
+//
+//Fields which fail to decode are left at their zero value. The record is still reported as failed, with FieldErrors
+//listing every field which failed along with any validation rule which is not satisfied.
+func WithLenient() Option {
+	return func(o *options) {
+		o.decoder.lenient = true
+	}
+}
+
+/*WithNumericPolicy sets the classes of numeric text which decode, see NumericClass
+
+Fields of other classes fail with a NumericError, as do fields which are out of range. NumericStrict accepts what
+strconv accepts, NumericLenient also decodes blank fields as zero, trims spaces and accepts trailing signs.
+Without a policy numbers are parsed by strconv and fail with its errors. The policy does not apply to numbers
+with the encoding or decimals options.
+*/
+func WithNumericPolicy(accept NumericClass) Option {
+	return func(o *options) {
+		o.decoder.numericPolicy = accept
+		o.decoder.numericPolicySet = true
+	}
+}
+
+//WithErrorSink makes a Reader, ParallelReader or FlatFile pass records which fail to decode to sink and continue with the next record
+//By default reading stops at the first record which fails to decode. sink is called on the goroutine calling Next.
+func WithErrorSink(sink func(*RecordError)) Option {
+	return func(o *options) {
+		o.errorSink = sink
+	}
+}
+
+//WithErrorBudget skips at most n records which fail to decode, passing them to the sink of WithErrorSink if any
+//Reading stops once one more record fails, with an error wrapping its RecordError. There is no limit by default.
+func WithErrorBudget(n int) Option {
+	return func(o *options) {
+		o.errorBudget = n
+	}
+}
+
+/*WithRejectWriter writes records which fail to decode to w and continues with the next record
+
+RejectRaw writes the records as they were read so that partners can fix them and they can be read again,
+RejectJSON writes the record number and the reasons it failed along with the data. A record which exceeds
+the error budget of WithErrorBudget stops reading and is not written. Records are written as they are skipped,
+the error returned by w stops reading.
+*/
+func WithRejectWriter(w io.Writer, format RejectFormat) Option {
+	return func(o *options) {
+		o.reject = &rejectWriter{w: w, format: format}
+	}
+}
+
+//newRecordErrors returns how records which fail to decode are handled, or nil when reading stops at the first one
+func (o *options) newRecordErrors() *recordErrors {
+	if o.errorSink == nil && o.reject == nil && o.errorBudget < 0 {
+		return nil
+	}
+	errs := &recordErrors{sink: o.errorSink, budget: o.errorBudget}
+	if o.reject != nil {
+		reject := *o.reject
+		reject.lineBreaks = o.recordLength == 0
+		errs.reject = &reject
+	}
+	return errs
+}
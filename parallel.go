@@ -0,0 +1,281 @@
+package flatfile
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+//defaultBatchSize is the number of records per batch when WithBatchSize is not provided
+const defaultBatchSize = 256
+
+//ParallelReader reads records of type T from a flat file and decodes them on multiple goroutines
+//
+//Lines are read on a single goroutine and split into batches (WithBatchSize) which are decoded by a pool of workers (WithWorkers).
+//Records are returned in file order unless WithUnordered is provided.
+//At most two batches per worker are held in memory at any time.
+//
+//Close must be called when the reader is not read to the end.
+type ParallelReader[T any] struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	results chan parallelBatch[T]
+	//tokens limits the number of batches which have been read but not yet returned by Next
+	tokens    chan struct{}
+	unordered bool
+	decoder   decoder
+	//pending holds batches decoded ahead of the next batch in file order
+	pending map[int64]parallelBatch[T]
+	nextSeq int64
+	batch   parallelBatch[T]
+	pos     int
+	//failed is the index of the next skipped record of batch to report
+	failed  int
+	record  T
+	records int64
+	err     error
+	wg      sync.WaitGroup
+	//skipped handles records which fail to decode, nil when reading stops at the first one
+	skipped *recordErrors
+}
+
+//parallelBatch is a group of consecutive lines and the records decoded from them
+//err is set when reading or decoding failed after the lines or records in the batch
+type parallelBatch[T any] struct {
+	seq int64
+	//firstRecord is the record number of the first line in the batch
+	firstRecord int64
+	lines       [][]byte
+	records     []T
+	//skipped holds the records which failed to decode when errors are skipped
+	skipped []skippedRecord
+	err     error
+}
+
+//skippedRecord is a line of a batch which failed to decode
+//pos is the number of records of the batch decoded before it
+type skippedRecord struct {
+	pos    int
+	number int64
+	line   []byte
+	err    error
+}
+
+//NewParallelReader returns a ParallelReader which decodes records of type T from r
+func NewParallelReader[T any](r io.Reader, opts ...Option) *ParallelReader[T] {
+	o := newOptions(opts)
+	workers := o.workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	batchSize := o.batchSize
+	if batchSize < 1 {
+		batchSize = defaultBatchSize
+	}
+
+	ctx, cancel := context.WithCancel(o.ctx)
+	p := &ParallelReader[T]{
+		ctx:       ctx,
+		cancel:    cancel,
+		results:   make(chan parallelBatch[T], workers),
+		tokens:    make(chan struct{}, 2*workers),
+		unordered: o.unordered,
+		decoder:   o.decoder,
+		pending:   make(map[int64]parallelBatch[T]),
+		skipped:   o.newRecordErrors(),
+	}
+	if t := reflect.TypeOf((*T)(nil)).Elem(); t.Kind() != reflect.Struct {
+		p.err = errors.Errorf("flatfile.NewParallelReader: %s is not a struct", t)
+		cancel()
+		close(p.results)
+		return p
+	}
+
+	jobs := make(chan parallelBatch[T], workers)
+	p.wg.Add(1)
+	go p.read(o.newFlatFile(r), jobs, batchSize)
+
+	var decoders sync.WaitGroup
+	decoders.Add(workers)
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			defer decoders.Done()
+			p.decode(jobs)
+		}()
+	}
+	go func() {
+		decoders.Wait()
+		close(p.results)
+	}()
+	return p
+}
+
+//read splits the lines read from file into batches and sends them to the workers
+func (p *ParallelReader[T]) read(file *FlatFile, jobs chan<- parallelBatch[T], batchSize int) {
+	defer p.wg.Done()
+	defer close(jobs)
+	for seq := int64(0); ; seq++ {
+		select {
+		case p.tokens <- struct{}{}:
+		case <-p.ctx.Done():
+			return
+		}
+
+		batch := parallelBatch[T]{seq: seq, firstRecord: file.records + 1, lines: make([][]byte, 0, batchSize)}
+		eof := false
+		for len(batch.lines) < batchSize {
+			line, err := file.readRecord(p.ctx)
+			if err != nil {
+				eof = true
+				if err != io.EOF {
+					batch.err = err
+				}
+				break
+			}
+			batch.lines = append(batch.lines, line)
+		}
+		if len(batch.lines) > 0 || batch.err != nil {
+			select {
+			case jobs <- batch:
+			case <-p.ctx.Done():
+				return
+			}
+		}
+		if eof {
+			return
+		}
+	}
+}
+
+//decode unmarshals every line of the batches received from jobs
+//Decoding of a batch stops at the first error unless errors are skipped, records decoded before it are kept
+func (p *ParallelReader[T]) decode(jobs <-chan parallelBatch[T]) {
+	for batch := range jobs {
+		batch.records = make([]T, 0, len(batch.lines))
+		for i, line := range batch.lines {
+			var record T
+			if err := p.decoder.unmarshal(line, &record, 0, 0, false); err != nil {
+				if p.skipped != nil {
+					batch.skipped = append(batch.skipped, skippedRecord{pos: len(batch.records), number: batch.firstRecord + int64(i), line: line, err: err})
+					continue
+				}
+				batch.err = errors.Wrapf(err, "flatfile.ParallelReader: Failed to decode record %d", batch.firstRecord+int64(i))
+				break
+			}
+			batch.records = append(batch.records, record)
+		}
+		batch.lines = nil
+
+		select {
+		case p.results <- batch:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+//receive returns the next batch to be returned by Next
+//It returns false when all batches have been received
+func (p *ParallelReader[T]) receive() (parallelBatch[T], bool) {
+	for {
+		if batch, ok := p.pending[p.nextSeq]; ok && !p.unordered {
+			delete(p.pending, p.nextSeq)
+			p.nextSeq++
+			<-p.tokens
+			return batch, true
+		}
+
+		batch, ok := <-p.results
+		if !ok {
+			return batch, false
+		}
+		if p.unordered {
+			<-p.tokens
+			return batch, true
+		}
+		p.pending[batch.seq] = batch
+	}
+}
+
+//Next returns the next decoded record, which is then available through Record
+//It returns false when there are no more records or an error occurred. Err reports the error, if any.
+//Records which fail to decode are skipped when WithRejectWriter, WithErrorSink or WithErrorBudget is provided.
+func (p *ParallelReader[T]) Next() bool {
+	for p.err == nil {
+		if err := p.ctx.Err(); err != nil {
+			p.stop(errors.Wrapf(err, "flatfile.ParallelReader: Read cancelled after %d records", p.records))
+			break
+		}
+		//skipped records are reported in file order, between the records decoded around them
+		if p.failed < len(p.batch.skipped) && p.batch.skipped[p.failed].pos == p.pos {
+			skipped := p.batch.skipped[p.failed]
+			p.failed++
+			if err := p.skipped.add(skipped.number, skipped.line, skipped.err); err != nil {
+				p.stop(errors.Wrap(err, "flatfile.ParallelReader"))
+			}
+			continue
+		}
+		if p.pos < len(p.batch.records) {
+			p.record = p.batch.records[p.pos]
+			p.pos++
+			p.records++
+			return true
+		}
+		if p.batch.err != nil {
+			p.stop(p.batch.err)
+			break
+		}
+
+		batch, ok := p.receive()
+		if !ok {
+			p.stop(io.EOF)
+			break
+		}
+		p.batch, p.pos, p.failed = batch, 0, 0
+	}
+	return false
+}
+
+//stop records err and releases the goroutines used by the reader
+func (p *ParallelReader[T]) stop(err error) {
+	p.err = err
+	p.batch = parallelBatch[T]{}
+	p.cancel()
+}
+
+//Record returns the most recent record returned by Next
+func (p *ParallelReader[T]) Record() T {
+	return p.record
+}
+
+//Err returns the first error encountered by the ParallelReader. io.EOF is not reported as an error.
+func (p *ParallelReader[T]) Err() error {
+	if p.err == io.EOF {
+		return nil
+	}
+	return p.err
+}
+
+//ReadAll reads all remaining records
+func (p *ParallelReader[T]) ReadAll() ([]T, error) {
+	defer p.Close()
+	var records []T
+	for p.Next() {
+		records = append(records, p.Record())
+	}
+	return records, p.Err()
+}
+
+//Close stops reading and waits for the goroutines used by the reader to exit
+//Close does not close the underlying io.Reader
+func (p *ParallelReader[T]) Close() error {
+	p.cancel()
+	p.wg.Wait()
+	return nil
+}
@@ -0,0 +1,148 @@
+package flatfile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func parallelTestData(n int) string {
+	var data strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&data, "RECORD%04d%d\n", i, i%10)
+	}
+	return data.String()
+}
+
+func TestParallelReaderOrdered(t *testing.T) {
+	reader := NewParallelReader[testType](strings.NewReader(parallelTestData(1000)), WithWorkers(4), WithBatchSize(7))
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if len(records) != 1000 {
+		t.Fatalf("ParallelReader.ReadAll() got %d records want 1000", len(records))
+	}
+	for i, record := range records {
+		want := testType{Data: fmt.Sprintf("RECORD%04d", i), Number: i % 10}
+		if record != want {
+			t.Fatalf("ParallelReader.ReadAll() record %d got: %v want: %v", i, record, want)
+		}
+	}
+}
+
+func TestParallelReaderUnordered(t *testing.T) {
+	reader := NewParallelReader[testType](strings.NewReader(parallelTestData(1000)), WithWorkers(4), WithBatchSize(7), WithUnordered())
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if len(records) != 1000 {
+		t.Fatalf("ParallelReader.ReadAll() got %d records want 1000", len(records))
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Data < records[j].Data })
+	for i, record := range records {
+		if record.Data != fmt.Sprintf("RECORD%04d", i) {
+			t.Fatalf("ParallelReader.ReadAll() record %d got: %v", i, record)
+		}
+	}
+}
+
+func TestParallelReaderErr(t *testing.T) {
+	data := strings.Split(parallelTestData(100), "\n")
+	data[50] = "RECORD0050X"
+
+	reader := NewParallelReader[testType](strings.NewReader(strings.Join(data, "\n")), WithWorkers(3), WithBatchSize(4))
+	records, err := reader.ReadAll()
+	if err == nil {
+		t.Error("ParallelReader.ReadAll() should return error when failing to parse int")
+	}
+	if len(records) != 50 {
+		t.Errorf("ParallelReader.ReadAll() got %d records before the error want 50", len(records))
+	}
+	t.Log(err)
+}
+
+func TestParallelReaderClose(t *testing.T) {
+	reader := NewParallelReader[testType](strings.NewReader(parallelTestData(1000)), WithWorkers(2), WithBatchSize(1))
+	if !reader.Next() {
+		t.Fatalf("Unexpected error %v", reader.Err())
+	}
+	if err := reader.Close(); err != nil {
+		t.Errorf("Unexpected error %s", err)
+	}
+	if reader.Next() {
+		t.Error("ParallelReader.Next() should return false after Close")
+	}
+}
+
+func TestParallelReaderContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader := NewParallelReader[testType](strings.NewReader(parallelTestData(1000)), WithContext(ctx), WithWorkers(2), WithBatchSize(10))
+	defer reader.Close()
+
+	count := 0
+	for reader.Next() {
+		count++
+		if count == 25 {
+			cancel()
+		}
+	}
+	if count != 25 {
+		t.Errorf("ParallelReader.Next() read %d records after cancellation want 25", count)
+	}
+	if !errors.Is(reader.Err(), context.Canceled) {
+		t.Errorf("ParallelReader.Err() got %v want %v", reader.Err(), context.Canceled)
+	}
+	t.Log(reader.Err())
+}
+
+func TestParallelReaderNotAStructErr(t *testing.T) {
+	reader := NewParallelReader[string](strings.NewReader("1"))
+	defer reader.Close()
+	if reader.Next() {
+		t.Error("ParallelReader.Next() should return false when T is not a struct")
+	}
+	if reader.Err() == nil {
+		t.Error("ParallelReader.Err() should return not a struct error")
+	}
+}
+
+func TestParallelReaderErrorSink(t *testing.T) {
+	data := strings.Split(parallelTestData(100), "\n")
+	data[10] = "RECORD0010X"
+	data[11] = "RECORD0011X"
+	data[50] = "RECORD0050X"
+
+	for _, unordered := range []bool{false, true} {
+		var skipped []int64
+		opts := []Option{WithWorkers(3), WithBatchSize(4), WithErrorSink(func(err *RecordError) { skipped = append(skipped, err.Number) })}
+		if unordered {
+			opts = append(opts, WithUnordered())
+		}
+		records, err := NewParallelReader[testType](strings.NewReader(strings.Join(data, "\n")), opts...).ReadAll()
+		if err != nil {
+			t.Errorf("Unexpected error %s", err)
+		}
+		if len(records) != 97 {
+			t.Errorf("ParallelReader.ReadAll() got %d records want 97", len(records))
+		}
+		sort.Slice(skipped, func(i, j int) bool { return skipped[i] < skipped[j] })
+		if fmt.Sprint(skipped) != "[11 12 51]" {
+			t.Errorf("Records passed to the sink got: %v want: [11 12 51]", skipped)
+		}
+	}
+
+	records, err := NewParallelReader[testType](strings.NewReader(strings.Join(data, "\n")), WithWorkers(3), WithBatchSize(4), WithErrorBudget(2)).ReadAll()
+	var recordErr *RecordError
+	if !errors.As(err, &recordErr) || recordErr.Number != 51 {
+		t.Errorf("ParallelReader.ReadAll() error = %v, want the error of record 51", err)
+	}
+	if len(records) != 48 {
+		t.Errorf("ParallelReader.ReadAll() got %d records before the budget was exceeded want 48", len(records))
+	}
+}
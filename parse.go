@@ -0,0 +1,209 @@
+package flatfile
+
+import (
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+//The parsers in this file convert ASCII field data without first converting it to a string.
+//They accept the same input and return the same values and *strconv.NumError errors as their strconv counterparts,
+//but do not allocate unless parsing fails.
+
+//intSize is the size in bits of an int or uint value
+const intSize = 32 << (^uint(0) >> 63)
+
+//ParseInt interprets b as a base 10 signed integer of the given bit size, like strconv.ParseInt(string(b), 10, bitSize)
+//A bitSize of 0 means int.
+func ParseInt(b []byte, bitSize int) (int64, error) {
+	if bitSize == 0 {
+		bitSize = intSize
+	}
+	if len(b) == 0 {
+		return 0, numError("ParseInt", b, strconv.ErrSyntax)
+	}
+
+	neg := false
+	digits := b
+	switch b[0] {
+	case '+':
+		digits = b[1:]
+	case '-':
+		neg = true
+		digits = b[1:]
+	}
+
+	un, err := parseDigits(digits, bitSize)
+	if err != nil && err != strconv.ErrRange {
+		return 0, numError("ParseInt", b, err)
+	}
+
+	cutoff := uint64(1) << uint(bitSize-1)
+	if err == nil && !neg && un >= cutoff {
+		err = strconv.ErrRange
+	}
+	if err == nil && neg && un > cutoff {
+		err = strconv.ErrRange
+	}
+	if err != nil {
+		if neg {
+			return -int64(cutoff), numError("ParseInt", b, err)
+		}
+		return int64(cutoff - 1), numError("ParseInt", b, err)
+	}
+
+	n := int64(un)
+	if neg {
+		n = -n
+	}
+	return n, nil
+}
+
+//ParseUint interprets b as a base 10 unsigned integer of the given bit size, like strconv.ParseUint(string(b), 10, bitSize)
+//A bitSize of 0 means uint.
+func ParseUint(b []byte, bitSize int) (uint64, error) {
+	if bitSize == 0 {
+		bitSize = intSize
+	}
+	if len(b) == 0 {
+		return 0, numError("ParseUint", b, strconv.ErrSyntax)
+	}
+
+	n, err := parseDigits(b, bitSize)
+	if err == strconv.ErrRange {
+		return n, numError("ParseUint", b, err)
+	}
+	if err != nil {
+		return 0, numError("ParseUint", b, err)
+	}
+	return n, nil
+}
+
+//parseDigits converts a non empty sequence of ASCII digits into an unsigned integer of bitSize bits
+//The maximum value for bitSize is returned along with strconv.ErrRange as soon as the value does not fit, as strconv does
+func parseDigits(b []byte, bitSize int) (uint64, error) {
+	if len(b) == 0 {
+		return 0, strconv.ErrSyntax
+	}
+
+	maxVal := uint64(1)<<uint(bitSize) - 1
+	var n uint64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, strconv.ErrSyntax
+		}
+		//n*10 overflows uint64
+		if n >= math.MaxUint64/10+1 {
+			return maxVal, strconv.ErrRange
+		}
+		n1 := n*10 + uint64(c-'0')
+		if n1 < n*10 || n1 > maxVal {
+			return maxVal, strconv.ErrRange
+		}
+		n = n1
+	}
+	return n, nil
+}
+
+//ParseBool returns the boolean value represented by b, like strconv.ParseBool(string(b))
+func ParseBool(b []byte) (bool, error) {
+	switch string(b) {
+	case "1", "t", "T", "true", "TRUE", "True":
+		return true, nil
+	case "0", "f", "F", "false", "FALSE", "False":
+		return false, nil
+	}
+	return false, numError("ParseBool", b, strconv.ErrSyntax)
+}
+
+//ParseRune returns the first rune of b, or an error when b does not start with a valid UTF-8 encoding
+func ParseRune(b []byte) (rune, error) {
+	r, _ := utf8.DecodeRune(b)
+	if r == utf8.RuneError {
+		return 0, errors.Errorf("flatfile.ParseRune: Invalid UTF-8 encoding %q", b)
+	}
+	return r, nil
+}
+
+//float64Pow10 holds the powers of ten which are exactly representable as a float64
+var float64Pow10 = [...]float64{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22}
+
+//float32Pow10 holds the powers of ten which are exactly representable as a float32
+var float32Pow10 = [...]float32{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10}
+
+//ParseFloat converts b to a floating point number of the given bit size, like strconv.ParseFloat(string(b), bitSize)
+//
+//Fixed point decimals such as "-1234.56" with up to 15 significant digits are converted without allocating.
+//Any other input, including exponents, is passed to strconv.ParseFloat.
+func ParseFloat(b []byte, bitSize int) (float64, error) {
+	if f, ok := parseFixedPoint(b, bitSize); ok {
+		return f, nil
+	}
+	return strconv.ParseFloat(string(b), bitSize)
+}
+
+//parseFixedPoint converts a fixed point decimal when the result is exact
+//The mantissa and the power of ten are both exactly representable so a single division is correctly rounded
+func parseFixedPoint(b []byte, bitSize int) (float64, bool) {
+	if len(b) == 0 {
+		return 0, false
+	}
+	neg := false
+	switch b[0] {
+	case '+':
+		b = b[1:]
+	case '-':
+		neg = true
+		b = b[1:]
+	}
+
+	var mantissa uint64
+	digits, fraction := 0, -1
+	for i, c := range b {
+		switch {
+		case c >= '0' && c <= '9':
+			mantissa = mantissa*10 + uint64(c-'0')
+			if mantissa > 0 {
+				digits++
+			}
+			if digits > 15 {
+				return 0, false
+			}
+		case c == '.' && fraction < 0:
+			fraction = len(b) - i - 1
+		default:
+			return 0, false
+		}
+	}
+	if fraction < 0 {
+		fraction = 0
+	}
+	//at least one digit is required
+	if len(b) == 0 || (len(b) == 1 && fraction == 0 && b[0] == '.') {
+		return 0, false
+	}
+
+	var f float64
+	if bitSize == 32 {
+		if mantissa >= 1<<24 || fraction >= len(float32Pow10) {
+			return 0, false
+		}
+		f = float64(float32(mantissa) / float32Pow10[fraction])
+	} else {
+		if fraction >= len(float64Pow10) {
+			return 0, false
+		}
+		f = float64(mantissa) / float64Pow10[fraction]
+	}
+	if neg {
+		f = -f
+	}
+	return f, true
+}
+
+//numError returns the error strconv returns for the same input
+func numError(fn string, b []byte, err error) *strconv.NumError {
+	return &strconv.NumError{Func: fn, Num: string(b), Err: err}
+}
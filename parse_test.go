@@ -0,0 +1,96 @@
+package flatfile
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var parseIntTestValues = []string{
+	"", "0", "1", "-1", "+1", "+", "-", "00123", "-00123", "127", "128", "-128", "-129", "255", "256",
+	"32767", "32768", "-32769", "65535", "65536", "2147483647", "2147483648", "-2147483649", "4294967295", "4294967296",
+	"9223372036854775807", "9223372036854775808", "-9223372036854775808", "-9223372036854775809",
+	"18446744073709551615", "18446744073709551616", "99999999999999999999", "99999999999999999999x",
+	"12 3", " 123", "123 ", "1_000", "0x10", "1e3", "abc", "--1", "+-1", "١٢٣",
+}
+
+func TestParseInt(t *testing.T) {
+	for _, bitSize := range []int{0, 8, 16, 32, 64} {
+		for _, value := range parseIntTestValues {
+			t.Run(fmt.Sprintf("TestParseInt-%d-%q", bitSize, value), func(t *testing.T) {
+				got, gotErr := ParseInt([]byte(value), bitSize)
+				want, wantErr := strconv.ParseInt(value, 10, bitSize)
+				if got != want || !reflect.DeepEqual(gotErr, wantErr) {
+					t.Errorf("ParseInt(%q, %d) got: %d, %v want: %d, %v", value, bitSize, got, gotErr, want, wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestParseUint(t *testing.T) {
+	for _, bitSize := range []int{0, 8, 16, 32, 64} {
+		for _, value := range parseIntTestValues {
+			t.Run(fmt.Sprintf("TestParseUint-%d-%q", bitSize, value), func(t *testing.T) {
+				got, gotErr := ParseUint([]byte(value), bitSize)
+				want, wantErr := strconv.ParseUint(value, 10, bitSize)
+				if got != want || !reflect.DeepEqual(gotErr, wantErr) {
+					t.Errorf("ParseUint(%q, %d) got: %d, %v want: %d, %v", value, bitSize, got, gotErr, want, wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	for _, value := range []string{"", "1", "0", "t", "T", "true", "TRUE", "True", "f", "F", "false", "FALSE", "False", "tRUE", "yes", "2", " 1"} {
+		got, gotErr := ParseBool([]byte(value))
+		want, wantErr := strconv.ParseBool(value)
+		if got != want || !reflect.DeepEqual(gotErr, wantErr) {
+			t.Errorf("ParseBool(%q) got: %v, %v want: %v, %v", value, got, gotErr, want, wantErr)
+		}
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	values := []string{
+		"", "0", "-0", "+0", "1", "-1", ".5", "5.", ".", "-.", "+", "0.1", "0.2", "0.3", "123.45", "-123.45", "0000123.4500",
+		"999999999999999", "9999999999999999", "1234567.891011", "3.4028235e+38", "1e400", "0x1p-2", "inf", "NaN", "1_0.0",
+		"12 3", "1.2.3", "16777216", "16777217", "0.00000000001", "0.0000000000000000000000001", "2.7976931348623157e+308",
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		values = append(values, strconv.FormatFloat(float64(r.Int63n(1e15))/math.Pow10(r.Intn(16)), 'f', -1, 64))
+		values = append(values, fmt.Sprintf("%d.%0*d", r.Intn(100000), r.Intn(6)+1, r.Intn(100000)))
+	}
+
+	for _, bitSize := range []int{32, 64} {
+		for _, value := range values {
+			got, gotErr := ParseFloat([]byte(value), bitSize)
+			want, wantErr := strconv.ParseFloat(value, bitSize)
+			if math.Float64bits(got) != math.Float64bits(want) || !reflect.DeepEqual(gotErr, wantErr) {
+				t.Errorf("ParseFloat(%q, %d) got: %v, %v want: %v, %v", value, bitSize, got, gotErr, want, wantErr)
+			}
+		}
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		parse func()
+	}{
+		{"ParseInt", func() { ParseInt([]byte("-9223372036854775808"), 64) }},
+		{"ParseUint", func() { ParseUint([]byte("18446744073709551615"), 64) }},
+		{"ParseBool", func() { ParseBool([]byte("FALSE")) }},
+		{"ParseFloat", func() { ParseFloat([]byte("-1234567.8901"), 64) }},
+	}
+	for _, tC := range testCases {
+		if allocs := testing.AllocsPerRun(100, tC.parse); allocs != 0 {
+			t.Errorf("%s allocated %v times per run want 0", tC.desc, allocs)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package flatfile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+//Reader reads records of type T from a flat file, one record per line or per WithRecordLength bytes
+//
+//Unlike FlatFile, which unmarshals every line into the same objectLayout, Reader decodes each line into a fresh zero value of T.
+//Values from a previous record never leak into the next one when a field is skipped by a condition or a short line.
+//
+//T must be a struct type with flatfile tags.
+type Reader[T any] struct {
+	ctx     context.Context
+	file    *FlatFile
+	decoder decoder
+	//firstRecord is the record number of the first record read from file
+	firstRecord int64
+	record      T
+	err         error
+	//skipped handles records which fail to decode, nil when reading stops at the first one
+	skipped *recordErrors
+}
+
+//NewReader returns a Reader which decodes records of type T from r
+func NewReader[T any](r io.Reader, opts ...Option) *Reader[T] {
+	o := newOptions(opts)
+	return newReader[T](o.newFlatFile(r), 1, o)
+}
+
+//NewShardReader returns a Reader which decodes records of type T from the byte range of r described by shard
+//Record numbers, including those reported in errors, are counted from the start of the file rather than the shard
+func NewShardReader[T any](r io.ReaderAt, shard Shard, opts ...Option) *Reader[T] {
+	o := newOptions(opts)
+	return newReader[T](o.newFlatFile(io.NewSectionReader(r, shard.Offset, shard.Length)), shard.FirstRecord, o)
+}
+
+func newReader[T any](file *FlatFile, firstRecord int64, o *options) *Reader[T] {
+	reader := &Reader[T]{ctx: o.ctx, file: file, decoder: o.decoder, firstRecord: firstRecord, skipped: o.newRecordErrors()}
+	if t := reflect.TypeOf((*T)(nil)).Elem(); t.Kind() != reflect.Struct {
+		reader.err = errors.Errorf("flatfile.NewReader: %s is not a struct", t)
+	}
+	return reader
+}
+
+//Next reads and decodes the next record, which is then available through Record
+//It returns false when there are no more records or an error occurred. Err reports the error, if any.
+//Records which fail to decode are skipped when WithRejectWriter, WithErrorSink or WithErrorBudget is provided.
+func (r *Reader[T]) Next() bool {
+	for r.err == nil {
+		line, err := r.file.readRecord(r.ctx)
+		if err != nil {
+			r.err = err
+			return false
+		}
+
+		var record T
+		if err := r.decoder.unmarshal(line, &record, 0, 0, false); err != nil {
+			if r.skipped == nil {
+				r.err = errors.Wrapf(err, "flatfile.Reader: Failed to decode record %d", r.RecordNumber())
+				return false
+			}
+			r.err = errors.Wrap(r.skipped.add(r.RecordNumber(), line, err), "flatfile.Reader")
+			continue
+		}
+		r.record = record
+		return true
+	}
+	return false
+}
+
+//RecordError is a record which failed to decode, passed to the sink of WithErrorSink
+type RecordError struct {
+	//Number is the number of the record, starting at 1
+	Number int64
+	//Data is a copy of the record
+	Data []byte
+	Err  error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("flatfile: Failed to decode record %d: %v", e.Number, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+//recordErrors passes records which fail to decode to the reject writer and the error sink until the error budget is spent
+type recordErrors struct {
+	sink   func(*RecordError)
+	reject *rejectWriter
+	//budget is the number of records which may fail, or -1 when there is no limit
+	budget int
+	failed int
+}
+
+//add handles a record which failed to decode. It returns nil when the record is skipped,
+//or an error wrapping the RecordError when the budget is exceeded.
+func (s *recordErrors) add(number int64, data []byte, err error) error {
+	recordErr := &RecordError{Number: number, Data: bytes.Clone(data), Err: err}
+	s.failed++
+	if s.budget >= 0 && s.failed > s.budget {
+		return errors.Wrapf(recordErr, "Error budget of %d records exceeded", s.budget)
+	}
+	if s.reject != nil {
+		if err := s.reject.write(recordErr); err != nil {
+			return err
+		}
+	}
+	if s.sink != nil {
+		s.sink(recordErr)
+	}
+	return nil
+}
+
+//Record returns the most recent record decoded by Next
+func (r *Reader[T]) Record() T {
+	return r.record
+}
+
+//RecordNumber returns the number of the most recent record read by Next, starting at 1
+func (r *Reader[T]) RecordNumber() int64 {
+	return r.firstRecord + r.file.records - 1
+}
+
+//Err returns the first error encountered by the Reader. io.EOF is not reported as an error.
+func (r *Reader[T]) Err() error {
+	if r.err == io.EOF {
+		return nil
+	}
+	return r.err
+}
+
+//ReadAll reads all remaining records
+func (r *Reader[T]) ReadAll() ([]T, error) {
+	var records []T
+	for r.Next() {
+		records = append(records, r.Record())
+	}
+	return records, r.Err()
+}
+
+//Records returns an iterator over the records of type T read from r
+//
+//	for record, err := range flatfile.Records[CustomerRecord](file) {
+//		...
+//	}
+//
+//Iteration stops after the first error, which is yielded with the zero value of T.
+//Breaking out of the loop early stops reading from r.
+func Records[T any](r io.Reader, opts ...Option) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		reader := NewReader[T](r, opts...)
+		for reader.Next() {
+			if !yield(reader.Record(), nil) {
+				return
+			}
+		}
+		if err := reader.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package flatfile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderNext(t *testing.T) {
+	type Record struct {
+		Kind   string `flatfile:"1,1"`
+		Number int    `flatfile:"2,2"`
+		Name   string `flatfile:"4,3,condition=1-1-A"`
+	}
+
+	reader := NewReader[Record](strings.NewReader("A12AMY\nB34BOB\nA05"))
+	want := []Record{
+		{Kind: "A", Number: 12, Name: "AMY"},
+		{Kind: "B", Number: 34},
+		{Kind: "A", Number: 5},
+	}
+
+	got := 0
+	for reader.Next() {
+		if got >= len(want) {
+			t.Fatalf("Reader.Next() returned more records than expected: %v", reader.Record())
+		}
+		if reader.Record() != want[got] {
+			t.Errorf("Reader.Record() got: %v want: %v", reader.Record(), want[got])
+		}
+		got++
+	}
+	if err := reader.Err(); err != nil {
+		t.Errorf("Unexpected error %s", err)
+	}
+	if got != len(want) {
+		t.Errorf("Reader.Next() read %d records want %d", got, len(want))
+	}
+}
+
+func TestReaderReadAll(t *testing.T) {
+	records, err := NewReader[testType](strings.NewReader("DATA!DATA!1\nMORE!DATA!2\n")).ReadAll()
+	if err != nil {
+		t.Errorf("Unexpected error %s", err)
+	}
+	want := []testType{{Data: "DATA!DATA!", Number: 1}, {Data: "MORE!DATA!", Number: 2}}
+	if len(records) != len(want) {
+		t.Fatalf("Reader.ReadAll() got: %v want: %v", records, want)
+	}
+	for i := range want {
+		if records[i] != want[i] {
+			t.Errorf("Reader.ReadAll() got: %v want: %v", records, want)
+		}
+	}
+}
+
+func TestReaderShortLine(t *testing.T) {
+	type Record struct {
+		Code   string `flatfile:"1,3"`
+		Name   string `flatfile:"4,10"`
+		Amount int    `flatfile:"14,3"`
+	}
+
+	//a line ending within a field is decoded from the bytes which remain
+	records, err := NewReader[Record](strings.NewReader("ABCDE\nXYZAMY\n")).ReadAll()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	want := []Record{{Code: "ABC", Name: "DE"}, {Code: "XYZ", Name: "AMY"}}
+	if len(records) != len(want) || records[0] != want[0] || records[1] != want[1] {
+		t.Errorf("Reader.ReadAll() got: %v want: %v", records, want)
+	}
+}
+
+func TestReaderErr(t *testing.T) {
+	records, err := NewReader[testType](strings.NewReader("DATA!DATA!1\nDATA!DATA!X\nDATA!DATA!3")).ReadAll()
+	if err == nil {
+		t.Error("Reader.ReadAll() should return error when failing to parse int")
+	}
+	if len(records) != 1 {
+		t.Errorf("Reader.ReadAll() got %d records before the error want 1", len(records))
+	}
+	t.Log(err)
+}
+
+func TestReaderNotAStructErr(t *testing.T) {
+	reader := NewReader[int](strings.NewReader("1"))
+	if reader.Next() {
+		t.Error("Reader.Next() should return false when T is not a struct")
+	}
+	if reader.Err() == nil {
+		t.Error("Reader.Err() should return not a struct error")
+	}
+	t.Log(reader.Err())
+}
+
+func TestReaderBufferSize(t *testing.T) {
+	records, err := NewReader[testType](strings.NewReader("DATA!DATA!1\nMORE!DATA!2\n"), WithBufferSize(16)).ReadAll()
+	if err != nil {
+		t.Errorf("Unexpected error %s", err)
+	}
+	if len(records) != 2 || records[1].Data != "MORE!DATA!" || records[1].Number != 2 {
+		t.Errorf("Reader.ReadAll() got: %v", records)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	var got []testType
+	for record, err := range Records[testType](strings.NewReader("DATA!DATA!1\nMORE!DATA!2\nLAST!DATA!3")) {
+		if err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+		got = append(got, record)
+	}
+	if len(got) != 3 || got[2].Data != "LAST!DATA!" || got[2].Number != 3 {
+		t.Errorf("Records() got: %v", got)
+	}
+}
+
+func TestRecordsBreak(t *testing.T) {
+	data := strings.NewReader("DATA!DATA!1\nMORE!DATA!2\nLAST!DATA!3\n")
+	count := 0
+	for _, err := range Records[testType](data, WithBufferSize(16)) {
+		if err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+		count++
+		if count == 1 {
+			break
+		}
+	}
+	if count != 1 {
+		t.Errorf("Records() yielded %d records after break want 1", count)
+	}
+	//The remainder of the input must not have been consumed past the buffer
+	if rest, _ := io.ReadAll(data); len(rest) == 0 {
+		t.Error("Records() consumed the whole input after break")
+	}
+}
+
+func TestRecordsErr(t *testing.T) {
+	var errs []error
+	count := 0
+	for _, err := range Records[testType](strings.NewReader("DATA!DATA!1\nDATA!DATA!X\nDATA!DATA!3")) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		count++
+	}
+	if count != 1 || len(errs) != 1 {
+		t.Errorf("Records() got %d records and %d errors want 1 and 1", count, len(errs))
+	}
+}
+
+func TestReaderContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader := NewReader[testType](strings.NewReader("DATA!DATA!1\nMORE!DATA!2\nLAST!DATA!3"), WithContext(ctx))
+
+	count := 0
+	for reader.Next() {
+		count++
+		if count == 2 {
+			cancel()
+		}
+	}
+	if count != 2 {
+		t.Errorf("Reader.Next() read %d records after cancellation want 2", count)
+	}
+	if !errors.Is(reader.Err(), context.Canceled) {
+		t.Errorf("Reader.Err() got %v want %v", reader.Err(), context.Canceled)
+	}
+	t.Log(reader.Err())
+}
+
+func TestRecordsDeadline(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	for _, err := range Records[testType](strings.NewReader("DATA!DATA!1"), WithContext(ctx)) {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Records() got error %v want %v", err, context.DeadlineExceeded)
+		}
+	}
+}
+
+func TestReaderErrorSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		records int
+		skipped []int64
+		wantErr bool
+	}{
+		{"Sink", []Option{}, 3, []int64{2, 4}, false},
+		{"Budget", []Option{WithErrorBudget(2)}, 3, []int64{2, 4}, false},
+		{"Budget exceeded", []Option{WithErrorBudget(1)}, 2, []int64{2}, true},
+		{"Lenient", []Option{WithLenient()}, 3, []int64{2, 4}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var skipped []int64
+			var lines []string
+			opts := append(tt.opts, WithErrorSink(func(err *RecordError) {
+				skipped = append(skipped, err.Number)
+				lines = append(lines, string(err.Data))
+			}))
+			records, err := NewReader[testType](strings.NewReader("DATA!DATA!1\nDATA!DATA!X\nDATA!DATA!3\nDATA!DATA!Y\nDATA!DATA!5"), opts...).ReadAll()
+			if len(records) != tt.records {
+				t.Errorf("Reader.ReadAll() got %d records want %d", len(records), tt.records)
+			}
+			if fmt.Sprint(skipped) != fmt.Sprint(tt.skipped) {
+				t.Errorf("Records passed to the sink got: %v want: %v", skipped, tt.skipped)
+			}
+			if len(lines) > 0 && lines[0] != "DATA!DATA!X" {
+				t.Errorf("RecordError.Data got: %q", lines[0])
+			}
+			var recordErr *RecordError
+			if (err != nil) != tt.wantErr || (err != nil && (!errors.As(err, &recordErr) || recordErr.Number != 4)) {
+				t.Errorf("Reader.ReadAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	records, err := NewReader[testType](strings.NewReader("DATA!DATA!X\nDATA!DATA!2\n"), WithErrorBudget(1)).ReadAll()
+	if err != nil || len(records) != 1 || records[0].Number != 2 {
+		t.Errorf("Reader.ReadAll() with a budget and no sink got: %v, %v", records, err)
+	}
+}
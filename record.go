@@ -0,0 +1,161 @@
+package flatfile
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//Record is a record decoded using a Schema
+//The typed accessors return an error when a field is not in the schema, was not decoded, or has a different type.
+type Record struct {
+	schema *Schema
+	values map[string]any
+}
+
+//Schema returns the schema the record was decoded with
+func (r *Record) Schema() *Schema {
+	return r.schema
+}
+
+//Get returns the value of a field and whether the field was decoded
+func (r *Record) Get(name string) (any, bool) {
+	value, ok := r.values[name]
+	return value, ok
+}
+
+//Map returns the values of the record, with groups converted to nested maps
+func (r *Record) Map() map[string]any {
+	m := make(map[string]any, len(r.values))
+	for name, value := range r.values {
+		switch v := value.(type) {
+		case *Record:
+			m[name] = v.Map()
+		case []*Record:
+			maps := make([]map[string]any, len(v))
+			for i, record := range v {
+				maps[i] = record.Map()
+			}
+			m[name] = maps
+		default:
+			m[name] = value
+		}
+	}
+	return m
+}
+
+//value returns the reflect.Value of a decoded field
+func (r *Record) value(accessor, name string) (reflect.Value, error) {
+	value, ok := r.values[name]
+	if !ok {
+		for i := range r.schema.Fields {
+			if r.schema.Fields[i].Name == name {
+				return reflect.Value{}, errors.Errorf("flatfile.Record.%s: Field %s was not decoded", accessor, name)
+			}
+		}
+		return reflect.Value{}, errors.Errorf("flatfile.Record.%s: Field %s is not in schema %s", accessor, name, r.schema.Name)
+	}
+	return reflect.ValueOf(value), nil
+}
+
+//String returns the value of a string field
+func (r *Record) String(name string) (string, error) {
+	value, err := r.value("String", name)
+	if err != nil {
+		return "", err
+	}
+	if value.Kind() != reflect.String {
+		return "", errors.Errorf("flatfile.Record.String: Field %s is a %s", name, value.Type())
+	}
+	return value.String(), nil
+}
+
+//Int returns the value of a signed integer field, including rune fields
+func (r *Record) Int(name string) (int64, error) {
+	value, err := r.value("Int", name)
+	if err != nil {
+		return 0, err
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	}
+	return 0, errors.Errorf("flatfile.Record.Int: Field %s is a %s", name, value.Type())
+}
+
+//Uint returns the value of an unsigned integer field, including byte fields
+func (r *Record) Uint(name string) (uint64, error) {
+	value, err := r.value("Uint", name)
+	if err != nil {
+		return 0, err
+	}
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), nil
+	}
+	return 0, errors.Errorf("flatfile.Record.Uint: Field %s is a %s", name, value.Type())
+}
+
+//Float returns the value of a float field
+func (r *Record) Float(name string) (float64, error) {
+	value, err := r.value("Float", name)
+	if err != nil {
+		return 0, err
+	}
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	}
+	return 0, errors.Errorf("flatfile.Record.Float: Field %s is a %s", name, value.Type())
+}
+
+//Bool returns the value of a bool field
+func (r *Record) Bool(name string) (bool, error) {
+	value, err := r.value("Bool", name)
+	if err != nil {
+		return false, err
+	}
+	if value.Kind() != reflect.Bool {
+		return false, errors.Errorf("flatfile.Record.Bool: Field %s is a %s", name, value.Type())
+	}
+	return value.Bool(), nil
+}
+
+//Time returns the value of a time field
+func (r *Record) Time(name string) (time.Time, error) {
+	value, err := r.value("Time", name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if value.Type() != timeType {
+		return time.Time{}, errors.Errorf("flatfile.Record.Time: Field %s is a %s", name, value.Type())
+	}
+	return value.Interface().(time.Time), nil
+}
+
+//Group returns the record of a group field which occurs once
+func (r *Record) Group(name string) (*Record, error) {
+	value, err := r.value("Group", name)
+	if err != nil {
+		return nil, err
+	}
+	group, ok := value.Interface().(*Record)
+	if !ok {
+		return nil, errors.Errorf("flatfile.Record.Group: Field %s is a %s", name, value.Type())
+	}
+	return group, nil
+}
+
+//Groups returns the records of a group field which occurs more than once
+func (r *Record) Groups(name string) ([]*Record, error) {
+	value, err := r.value("Groups", name)
+	if err != nil {
+		return nil, err
+	}
+	groups, ok := value.Interface().([]*Record)
+	if !ok {
+		return nil, errors.Errorf("flatfile.Record.Groups: Field %s is a %s", name, value.Type())
+	}
+	return groups, nil
+}
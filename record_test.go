@@ -0,0 +1,98 @@
+package flatfile
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordAccessors(t *testing.T) {
+	schema, err := ReadSchemaYAML(strings.NewReader(`
+name: account
+fields:
+  - {name: Name, column: 1, length: 3}
+  - {name: Active, column: 4, length: 1, type: bool}
+  - {name: Count, column: 5, length: 2, type: int8}
+  - {name: Grade, column: 7, length: 1, type: byte}
+  - {name: Rate, column: 8, length: 4, type: float32}
+  - {name: Opened, column: 12, length: 4, type: time, format: "2006"}
+  - name: Items
+    column: 16
+    length: 2
+    occurs: 2
+    fields:
+      - {name: Code, column: 1, length: 2}
+  - name: Owner
+    column: 1
+    length: 3
+    fields:
+      - {name: Initial, column: 1, length: 1}
+  - {name: Missing, column: 30, length: 1}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := schema.Decode([]byte("AMYT-5A1.2519990102"))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if record.Schema() != schema {
+		t.Errorf("Schema() = %v", record.Schema())
+	}
+
+	if v, err := record.String("Name"); v != "AMY" || err != nil {
+		t.Errorf("String() = %q, %v", v, err)
+	}
+	if v, err := record.Bool("Active"); !v || err != nil {
+		t.Errorf("Bool() = %v, %v", v, err)
+	}
+	if v, err := record.Int("Count"); v != -5 || err != nil {
+		t.Errorf("Int() = %v, %v", v, err)
+	}
+	if v, err := record.Uint("Grade"); v != 'A' || err != nil {
+		t.Errorf("Uint() = %v, %v", v, err)
+	}
+	if v, err := record.Float("Rate"); v != 1.25 || err != nil {
+		t.Errorf("Float() = %v, %v", v, err)
+	}
+	if v, err := record.Time("Opened"); !v.Equal(time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)) || err != nil {
+		t.Errorf("Time() = %v, %v", v, err)
+	}
+	if items, err := record.Groups("Items"); err != nil || len(items) != 2 {
+		t.Errorf("Groups() = %v, %v", items, err)
+	} else if code, err := items[1].String("Code"); code != "02" || err != nil {
+		t.Errorf("Groups()[1].String() = %q, %v", code, err)
+	}
+	if owner, err := record.Group("Owner"); err != nil {
+		t.Errorf("Group() error = %v", err)
+	} else if initial, err := owner.String("Initial"); initial != "A" || err != nil {
+		t.Errorf("Group().String() = %q, %v", initial, err)
+	}
+	if v, ok := record.Get("Count"); !ok || v != int8(-5) {
+		t.Errorf("Get() = %v, %v", v, ok)
+	}
+
+	errorTests := []struct {
+		name    string
+		access  func() error
+		wantErr string
+	}{
+		{"Not decoded", func() error { _, err := record.String("Missing"); return err }, "was not decoded"},
+		{"Not in schema", func() error { _, err := record.String("Unknown"); return err }, "is not in schema account"},
+		{"String type", func() error { _, err := record.String("Count"); return err }, "Field Count is a int8"},
+		{"Int type", func() error { _, err := record.Int("Name"); return err }, "Field Name is a string"},
+		{"Uint type", func() error { _, err := record.Uint("Count"); return err }, "is a int8"},
+		{"Float type", func() error { _, err := record.Float("Count"); return err }, "is a int8"},
+		{"Bool type", func() error { _, err := record.Bool("Count"); return err }, "is a int8"},
+		{"Time type", func() error { _, err := record.Time("Count"); return err }, "is a int8"},
+		{"Group type", func() error { _, err := record.Group("Items"); return err }, "is a []*flatfile.Record"},
+		{"Groups type", func() error { _, err := record.Groups("Owner"); return err }, "is a *flatfile.Record"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.access(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package flatfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+//RejectFormat is the format of the records written by WithRejectWriter
+type RejectFormat int
+
+const (
+	//RejectRaw writes rejected records exactly as they were read, so that they can be fixed and read again
+	//Records are followed by a line break unless WithRecordLength is provided.
+	RejectRaw RejectFormat = iota
+	//RejectJSON writes a JSON object per line with the record number, the error, the failing fields and the data of the record
+	RejectJSON
+)
+
+//rejectWriter writes records which fail to decode to a reject file
+type rejectWriter struct {
+	w          io.Writer
+	format     RejectFormat
+	lineBreaks bool
+}
+
+//rejectRecord is a rejected record written by RejectJSON
+type rejectRecord struct {
+	Record int64         `json:"record"`
+	Error  string        `json:"error"`
+	Fields []rejectField `json:"fields,omitempty"`
+	Data   string        `json:"data"`
+}
+
+//rejectField is a field of a rejected record which failed to decode or does not satisfy a validation rule
+type rejectField struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule,omitempty"`
+	Value string `json:"value,omitempty"`
+	Error string `json:"error"`
+}
+
+//write writes a rejected record in the format of the writer
+func (r *rejectWriter) write(recordErr *RecordError) error {
+	if r.format == RejectJSON {
+		reject := rejectRecord{Record: recordErr.Number, Error: recordErr.Err.Error(), Data: string(recordErr.Data)}
+		var fieldErrs FieldErrors
+		if errors.As(recordErr.Err, &fieldErrs) {
+			for _, fieldErr := range fieldErrs {
+				field := rejectField{Field: fieldErr.Field, Rule: fieldErr.Rule, Error: fieldErr.Error()}
+				if fieldErr.Err != nil {
+					field.Error = fieldErr.Err.Error()
+				}
+				if fieldErr.Value != nil {
+					field.Value = fmt.Sprint(fieldErr.Value)
+				}
+				reject.Fields = append(reject.Fields, field)
+			}
+		}
+		return errors.Wrap(json.NewEncoder(r.w).Encode(reject), "flatfile.rejectWriter: Failed to write reject")
+	}
+
+	data := recordErr.Data
+	if r.lineBreaks {
+		data = append(data, '\n')
+	}
+	_, err := r.w.Write(data)
+	return errors.Wrap(err, "flatfile.rejectWriter: Failed to write reject")
+}
@@ -0,0 +1,78 @@
+package flatfile
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRejectWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		format RejectFormat
+		data   string
+		opts   []Option
+		want   string
+	}{
+		{"Raw lines", RejectRaw, "DATA!DATA!1\nDATA!DATA!X\nDATA!DATA!3\n", []Option{}, "DATA!DATA!X\n"},
+		{"Raw fixed length", RejectRaw, "DATA!DATA!1DATA!DATA!XDATA!DATA!Y", []Option{WithRecordLength(11)}, "DATA!DATA!XDATA!DATA!Y"},
+		{"JSON", RejectJSON, "DATA!DATA!1\nDATA!DATA!X\n", []Option{},
+			`{"record":2,"error":"flatfile.Unmarshal: Failed to unmarshal: flatfile: Field Number value \"X\" failed to decode: strconv.ParseInt: parsing \"X\": invalid syntax","data":"DATA!DATA!X"}` + "\n"},
+		{"JSON lenient", RejectJSON, "DATA!DATA!1\nDATA!DATA!X\n", []Option{WithLenient()},
+			`{"record":2,"error":"flatfile: Field Number value \"X\" failed to decode: strconv.ParseInt: parsing \"X\": invalid syntax","fields":[{"field":"Number","value":"X","error":"strconv.ParseInt: parsing \"X\": invalid syntax"}],"data":"DATA!DATA!X"}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rejects bytes.Buffer
+			opts := append(tt.opts, WithRejectWriter(&rejects, tt.format))
+			_, err := NewReader[testType](strings.NewReader(tt.data), opts...).ReadAll()
+			if err != nil {
+				t.Errorf("Reader.ReadAll() unexpected error %s", err)
+			}
+			if rejects.String() != tt.want {
+				t.Errorf("Rejects got:\n%s\nwant:\n%s", rejects.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFlatFileReject(t *testing.T) {
+	var rejects bytes.Buffer
+	record := &testType{}
+	file, err := New(bufio.NewReader(strings.NewReader("DATA!DATA!X\nDATA!DATA!2\nDATA!DATA!Y\n")), record, WithRejectWriter(&rejects, RejectRaw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var numbers []int
+	for err = file.Read(); err == nil; err = file.Read() {
+		numbers = append(numbers, record.Number)
+	}
+	if err != io.EOF {
+		t.Errorf("FlatFile.Read() unexpected error %s", err)
+	}
+	if len(numbers) != 1 || numbers[0] != 2 {
+		t.Errorf("FlatFile.Read() got records %v want [2]", numbers)
+	}
+	if rejects.String() != "DATA!DATA!X\nDATA!DATA!Y\n" {
+		t.Errorf("Rejects got: %q", rejects.String())
+	}
+}
+
+type failingRejectWriter struct{}
+
+func (failingRejectWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRejectWriterErr(t *testing.T) {
+	records, err := NewReader[testType](strings.NewReader("DATA!DATA!1\nDATA!DATA!X\nDATA!DATA!3\n"), WithRejectWriter(failingRejectWriter{}, RejectRaw)).ReadAll()
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("Reader.ReadAll() error = %v, want the error of the reject writer", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("Reader.ReadAll() got %d records want 1", len(records))
+	}
+}
@@ -0,0 +1,524 @@
+package flatfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+/*Schema is a record layout defined at runtime instead of with struct tags
+
+A schema is usually loaded from a JSON or YAML file:
+
+	name: customer
+	fields:
+	  - {name: Name, column: 1, length: 3}
+	  - {name: OpenDate, column: 4, length: 10, type: time, format: "2006-01-02"}
+	  - {name: Age, column: 14, length: 3, type: uint}
+	  - {name: Phones, column: 34, length: 10, occurs: 2}
+
+Fields are decoded with the same conversions and errors as Unmarshal.
+When a file holds several record types, the condition of each schema selects the records it describes.
+*/
+type Schema struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	//Condition has the same form as the cond tag option. Match reports whether a record satisfies it
+	Condition string        `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Fields    []SchemaField `json:"fields" yaml:"fields"`
+
+	cond flatfileTag
+}
+
+//SchemaField describes one field of a Schema
+//Column, Length, Occurs, Condition, Encoding and Decimals have the same meaning as the flatfile tag options.
+type SchemaField struct {
+	Name   string `json:"name" yaml:"name"`
+	Column int    `json:"column" yaml:"column"`
+	Length int    `json:"length" yaml:"length"`
+	//Type is one of the types in schemaTypes. It defaults to string, or group when Fields is set
+	Type   string `json:"type,omitempty" yaml:"type,omitempty"`
+	Occurs int    `json:"occurs,omitempty" yaml:"occurs,omitempty"`
+	//Format is the time.Parse layout of time fields
+	Format    string `json:"format,omitempty" yaml:"format,omitempty"`
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Encoding  string `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	Decimals  int    `json:"decimals,omitempty" yaml:"decimals,omitempty"`
+	//Fields are the fields of a group, with columns relative to the start of the group
+	Fields []SchemaField `json:"fields,omitempty" yaml:"fields,omitempty"`
+
+	tag flatfileTag
+	//goType is the type of a decoded value, or of an element when the field occurs more than once
+	goType reflect.Type
+	group  *Schema
+}
+
+//schemaTypes maps the type names of schema fields to the Go type of their values
+var schemaTypes = map[string]reflect.Type{
+	"string":  reflect.TypeOf(""),
+	"bool":    reflect.TypeOf(false),
+	"int":     reflect.TypeOf(int(0)),
+	"int8":    reflect.TypeOf(int8(0)),
+	"int16":   reflect.TypeOf(int16(0)),
+	"int32":   reflect.TypeOf(int32(0)),
+	"int64":   reflect.TypeOf(int64(0)),
+	"uint":    reflect.TypeOf(uint(0)),
+	"uint8":   reflect.TypeOf(uint8(0)),
+	"uint16":  reflect.TypeOf(uint16(0)),
+	"uint32":  reflect.TypeOf(uint32(0)),
+	"uint64":  reflect.TypeOf(uint64(0)),
+	"float32": reflect.TypeOf(float32(0)),
+	"float64": reflect.TypeOf(float64(0)),
+	"byte":    reflect.TypeOf(byte(0)),
+	"rune":    reflect.TypeOf(rune(0)),
+	"time":    timeType,
+}
+
+//ReadSchemaJSON reads and validates a schema in JSON format
+func ReadSchemaJSON(r io.Reader) (*Schema, error) {
+	s := &Schema{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(s); err != nil {
+		return nil, errors.Wrap(err, "flatfile.ReadSchemaJSON: Failed to decode schema")
+	}
+	return s, s.Compile()
+}
+
+//ReadSchemaYAML reads and validates a schema in YAML format
+func ReadSchemaYAML(r io.Reader) (*Schema, error) {
+	s := &Schema{}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(s); err != nil {
+		return nil, errors.Wrap(err, "flatfile.ReadSchemaYAML: Failed to decode schema")
+	}
+	return s, s.Compile()
+}
+
+//LoadSchema reads a schema from a .json, .yaml or .yml file
+func LoadSchema(name string) (*Schema, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "flatfile.LoadSchema: Failed to open schema")
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return ReadSchemaJSON(file)
+	case ".yaml", ".yml":
+		return ReadSchemaYAML(file)
+	}
+	return nil, errors.Errorf("flatfile.LoadSchema: Unknown schema format %s. Schema files must end in .json, .yaml or .yml", filepath.Ext(name))
+}
+
+/*Compile validates the fields of a schema built in code and prepares it for decoding
+
+Schemas returned by ReadSchemaJSON, ReadSchemaYAML and LoadSchema are already compiled.
+Compile must be called again after the fields of a schema are changed.
+*/
+func (s *Schema) Compile() error {
+	s.cond = flatfileTag{}
+	if s.Condition != "" {
+		if err := parseConditionOption(s.Condition, &s.cond); err != nil {
+			return errors.Wrapf(err, "flatfile.Schema: Invalid condition of schema %s", s.Name)
+		}
+		if s.cond.cond.references() {
+			return errors.Errorf("flatfile.Schema: Condition of schema %s compares fields, schemas only support ranges", s.Name)
+		}
+	}
+	names := map[string]bool{}
+	for i := range s.Fields {
+		field := &s.Fields[i]
+		if field.Name == "" {
+			return errors.Errorf("flatfile.Schema: Field %d has no name", i+1)
+		}
+		if names[field.Name] {
+			return errors.Errorf("flatfile.Schema: Duplicate field name %s", field.Name)
+		}
+		names[field.Name] = true
+		if err := field.compile(); err != nil {
+			return errors.Wrapf(err, "flatfile.Schema: Invalid field %s", field.Name)
+		}
+	}
+	return nil
+}
+
+//compile parses the options of a field using the flatfile tag parser
+func (f *SchemaField) compile() error {
+	options := []string{"col=" + strconv.Itoa(f.Column), "len=" + strconv.Itoa(f.Length)}
+	if f.Occurs != 0 {
+		options = append(options, "occurs="+strconv.Itoa(f.Occurs))
+	}
+	if f.Condition != "" {
+		options = append(options, "cond="+f.Condition)
+	}
+	if f.Encoding != "" {
+		options = append(options, "enc="+f.Encoding)
+	}
+	if f.Decimals != 0 {
+		options = append(options, "dec="+strconv.Itoa(f.Decimals))
+	}
+	f.tag = flatfileTag{}
+	if err := parseFlatfileTag(strings.Join(options, ","), &f.tag); err != nil {
+		return err
+	}
+	if f.tag.cond.references() {
+		return errors.New("Conditions comparing fields are not supported by schemas, use ranges such as 1:2 = H1")
+	}
+	//layouts may contain commas, so the format is not passed through the tag parser
+	f.tag.format = f.Format
+
+	typeName := f.Type
+	if typeName == "" {
+		typeName = "string"
+		if len(f.Fields) > 0 {
+			typeName = "group"
+		}
+	}
+	switch typeName {
+	case "group":
+		if len(f.Fields) == 0 {
+			return errors.New("Group fields must have fields")
+		}
+		f.group = &Schema{Name: f.Name, Fields: f.Fields}
+		f.goType = nil
+		return f.group.Compile()
+	case "byte", "rune":
+		f.tag.override = typeName
+	case "time":
+		if f.Format == "" {
+			return errors.New("Time fields must have a format")
+		}
+	}
+	if len(f.Fields) > 0 {
+		return errors.Errorf("Fields of type %s cannot have fields", typeName)
+	}
+	goType, ok := schemaTypes[typeName]
+	if !ok {
+		return errors.Errorf("Unknown type %s", typeName)
+	}
+	f.goType = goType
+	return nil
+}
+
+//Match returns true when data satisfies the condition of the schema, or the schema has no condition
+func (s *Schema) Match(data []byte) bool {
+	return conditionMet(&s.cond, data, reflect.Value{})
+}
+
+//RecordLength returns the last column covered by any field of the schema
+func (s *Schema) RecordLength() int {
+	length := 0
+	for i := range s.Fields {
+		field := &s.Fields[i]
+		if end := field.Column - 1 + field.Length*field.occurrences(); end > length {
+			length = end
+		}
+	}
+	return length
+}
+
+//occurrences returns how many times the field repeats in a record
+func (f *SchemaField) occurrences() int {
+	if f.tag.occurs > 0 {
+		return f.tag.occurs
+	}
+	return 1
+}
+
+//Unmarshal decodes a record into a map from field names to values
+//Groups are decoded into nested maps. Fields which occur more than once are decoded into slices.
+func (s *Schema) Unmarshal(data []byte) (map[string]any, error) {
+	record, err := s.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return record.Map(), nil
+}
+
+/*Decode decodes a record into a Record
+
+Like Unmarshal, fields starting after the end of data are skipped and fields whose condition is not met are left out.
+Fields cut short by the end of data fail with ErrCutShort, except strings which are decoded from the bytes which remain.
+Fields which fail to decode are returned as a FieldError.
+*/
+func (s *Schema) Decode(data []byte) (*Record, error) {
+	return s.decode(data, &decoder{})
+}
+
+func (s *Schema) decode(data []byte, d *decoder) (*Record, error) {
+	record := &Record{schema: s, values: make(map[string]any, len(s.Fields))}
+	for i := range s.Fields {
+		field := &s.Fields[i]
+		if field.goType == nil && field.group == nil {
+			return nil, errors.Errorf("flatfile.Schema.Decode: Schema %s is not compiled", s.Name)
+		}
+		if !conditionMet(&field.tag, data, reflect.Value{}) {
+			continue
+		}
+		lowerBound := field.tag.col - 1
+		if lowerBound >= len(data) {
+			continue
+		}
+		//the data of all occurrences, values cut short by the end of the record are checked as Unmarshal does
+		fieldData := data[lowerBound:min(lowerBound+field.tag.length*field.occurrences(), len(data))]
+
+		value, err := field.decode(fieldData, d)
+		if err != nil {
+			return nil, errors.Wrap(decodeError(field.Name, fieldData, err), "flatfile.Schema.Decode: Failed to decode")
+		}
+		record.values[field.Name] = value
+	}
+	return record, nil
+}
+
+//decode converts the data of all occurrences of a field
+func (f *SchemaField) decode(fieldData []byte, d *decoder) (any, error) {
+	if f.group != nil {
+		if f.tag.occurs == 0 {
+			return f.group.decode(fieldData, d)
+		}
+		records := make([]*Record, f.tag.occurs)
+		for i := range records {
+			//occurrences starting after the end of the record are empty
+			elemData := fieldData[min(i*f.tag.length, len(fieldData)):min((i+1)*f.tag.length, len(fieldData))]
+			record, err := f.group.decode(elemData, d)
+			if err != nil {
+				return nil, decodeError("["+strconv.Itoa(i)+"]", elemData, err)
+			}
+			records[i] = record
+		}
+		return records, nil
+	}
+
+	valueType := f.goType
+	if f.tag.occurs > 0 {
+		valueType = reflect.SliceOf(f.goType)
+	}
+	value := reflect.New(valueType).Elem()
+	if err := d.assignBasedOnKind(valueType.Kind(), value, fieldData, &f.tag); err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
+
+/*Marshal encodes values into a record of RecordLength bytes, the reverse of Unmarshal
+
+Groups are maps or *Record values, and fields which occur more than once are slices. Missing and nil values leave their field blank.
+Values of the type of their field are encoded as Marshal encodes struct fields. Numbers of another type are converted when no precision is lost.
+Strings and json.Number values are parsed as the text of a record would be, ignoring the encoding and decimals of the field.
+*/
+func (s *Schema) Marshal(values map[string]any) ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, s.RecordLength())
+	if err := s.encode(values, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *Schema) encode(values map[string]any, data []byte) error {
+	for i := range s.Fields {
+		field := &s.Fields[i]
+		if field.goType == nil && field.group == nil {
+			return errors.Errorf("flatfile.Schema.Marshal: Schema %s is not compiled", s.Name)
+		}
+		value, ok := values[field.Name]
+		if !ok || value == nil || !conditionMet(&field.tag, data, reflect.Value{}) {
+			continue
+		}
+		lowerBound := field.tag.col - 1
+		upperBound := lowerBound + field.tag.length*field.occurrences()
+		if err := field.encode(value, data[lowerBound:upperBound]); err != nil {
+			return errors.Wrapf(err, "flatfile.Schema.Marshal: Failed to marshal field %s", field.Name)
+		}
+	}
+	return nil
+}
+
+//encode writes all occurrences of a field
+func (f *SchemaField) encode(value any, dst []byte) error {
+	if f.tag.occurs == 0 {
+		return f.encodeOccurrence(value, dst)
+	}
+	elems := reflect.ValueOf(value)
+	if elems.Kind() != reflect.Slice && elems.Kind() != reflect.Array {
+		return errors.Errorf("flatfile.SchemaField.encode: Field occurs %d times but the value is a %T", f.tag.occurs, value)
+	}
+	if elems.Len() > f.tag.occurs {
+		return errors.Errorf("flatfile.SchemaField.encode: %d elements exceed the %d occurrences of the field", elems.Len(), f.tag.occurs)
+	}
+	for i := 0; i < elems.Len(); i++ {
+		elem := elems.Index(i).Interface()
+		if elem == nil {
+			continue
+		}
+		if err := f.encodeOccurrence(elem, dst[i*f.tag.length:(i+1)*f.tag.length]); err != nil {
+			return errors.Wrapf(err, "flatfile.SchemaField.encode: Failed to marshal element %d", i)
+		}
+	}
+	return nil
+}
+
+//encodeOccurrence writes one occurrence of a field
+func (f *SchemaField) encodeOccurrence(value any, dst []byte) error {
+	if f.group != nil {
+		switch v := value.(type) {
+		case map[string]any:
+			return f.group.encode(v, dst)
+		case *Record:
+			return f.group.encode(v.values, dst)
+		}
+		return errors.Errorf("flatfile.SchemaField.encode: Group values must be maps or records but the value is a %T", value)
+	}
+	field, err := f.reflectValue(value)
+	if err != nil {
+		return err
+	}
+	return encodeBasedOnKind(field, dst, &f.tag)
+}
+
+//reflectValue converts a value to the type of the field
+func (f *SchemaField) reflectValue(value any) (reflect.Value, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return f.parseText(v.String())
+	case string:
+		if f.goType.Kind() != reflect.String {
+			return f.parseText(v)
+		}
+	}
+
+	field := reflect.ValueOf(value)
+	if field.Type() == f.goType {
+		return field, nil
+	}
+	if numericKind(field.Kind()) && numericKind(f.goType.Kind()) {
+		converted := field.Convert(f.goType)
+		if converted.Convert(field.Type()).Interface() == value && !(negative(field) && converted.CanUint()) {
+			return converted, nil
+		}
+		return reflect.Value{}, errors.Errorf("flatfile.SchemaField.encode: %v cannot be converted to %s without losing precision", value, f.goType)
+	}
+	return reflect.Value{}, errors.Errorf("flatfile.SchemaField.encode: Field is a %s but the value is a %T", f.goType, value)
+}
+
+//parseText decodes the text of a value using the conversions of Unmarshal, as if the field was displayed without implied decimals
+func (f *SchemaField) parseText(text string) (reflect.Value, error) {
+	if text == "" {
+		return reflect.Value{}, errors.Errorf("flatfile.SchemaField.encode: Empty text cannot be converted to %s", f.goType)
+	}
+	tag := flatfileTag{col: 1, length: len(text), override: f.tag.override, format: f.tag.format}
+	field := reflect.New(f.goType).Elem()
+	if err := (&decoder{}).assignBasedOnKind(f.goType.Kind(), field, []byte(text), &tag); err != nil {
+		return reflect.Value{}, err
+	}
+	return field, nil
+}
+
+//negative returns true for integer and float values below zero
+func negative(field reflect.Value) bool {
+	return (field.CanInt() && field.Int() < 0) || (field.CanFloat() && field.Float() < 0)
+}
+
+//numericKind returns true for integer and float kinds
+func numericKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+/*SchemaOf returns the schema of the flatfile tags of the struct or pointer to struct v
+
+Nested structs become groups and arrays and slices become fields which occur more than once,
+so records of v can be converted with the methods of Schema.
+*/
+func SchemaOf(v interface{}) (*Schema, error) {
+	vType := reflect.TypeOf(v)
+	if vType != nil && vType.Kind() == reflect.Ptr {
+		vType = vType.Elem()
+	}
+	if vType == nil || vType.Kind() != reflect.Struct {
+		return nil, errors.Errorf("flatfile.SchemaOf: %s is not a struct or pointer to struct", reflect.TypeOf(v))
+	}
+	fields, err := schemaFieldsOf(vType)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{Name: vType.Name(), Fields: fields}
+	return s, s.Compile()
+}
+
+func schemaFieldsOf(vType reflect.Type) ([]SchemaField, error) {
+	var fields []SchemaField
+	for i, layout := range layoutOf(vType).fields {
+		if layout.embedded {
+			embedded, err := schemaFieldsOf(embeddedStruct(vType.Field(i).Type))
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+		}
+		if !layout.tagged {
+			continue
+		}
+		structField := vType.Field(i)
+		if layout.tagErr != nil {
+			return nil, errors.Wrapf(layout.tagErr, "flatfile.SchemaOf: Failed to parse tag of field %s", structField.Name)
+		}
+		ffpTag := &layout.tag
+		if ffpTag.union || ffpTag.variants != nil {
+			return nil, errors.Errorf("flatfile.SchemaOf: Field %s is a union or variant, which schemas do not support", structField.Name)
+		}
+		field := SchemaField{Name: structField.Name, Column: ffpTag.col, Length: ffpTag.length, Occurs: ffpTag.occurs,
+			Format: ffpTag.format, Encoding: ffpTag.encoding, Decimals: ffpTag.decimals}
+		if ffpTag.condChk {
+			field.Condition = fmt.Sprintf("%d-%d-%s", ffpTag.condCol, ffpTag.condLen, ffpTag.condVal)
+		}
+		if ffpTag.cond != nil {
+			field.Condition = ffpTag.condText
+		}
+
+		fieldType := structField.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType.Kind() {
+		case reflect.Array:
+			field.Occurs = fieldType.Len()
+			fieldType = fieldType.Elem()
+		case reflect.Slice:
+			fieldType = fieldType.Elem()
+		}
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		switch {
+		case ffpTag.override != "":
+			field.Type = ffpTag.override
+		case fieldType == timeType && ffpTag.format != "":
+			field.Type = "time"
+		case fieldType.Kind() == reflect.Struct:
+			field.Type = "group"
+			nested, err := schemaFieldsOf(fieldType)
+			if err != nil {
+				return nil, err
+			}
+			field.Fields = nested
+		case schemaTypes[fieldType.Kind().String()] != nil:
+			field.Type = fieldType.Kind().String()
+		default:
+			return nil, errors.Errorf("flatfile.SchemaOf: Field %s of type %s is not supported", structField.Name, structField.Type)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
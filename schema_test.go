@@ -0,0 +1,218 @@
+package flatfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const customerSchemaYAML = `
+name: customer
+fields:
+  - {name: Name, column: 1, length: 3}
+  - {name: OpenDate, column: 4, length: 10, type: time, format: "2006-01-02"}
+  - {name: Age, column: 14, length: 3, type: uint}
+  - name: Address
+    column: 17
+    length: 17
+    fields:
+      - {name: Street, column: 1, length: 15}
+      - {name: Country, column: 16, length: 2}
+  - {name: Phones, column: 34, length: 10, occurs: 2, type: int64}
+  - {name: Initial, column: 1, length: 1, type: rune}
+  - {name: Balance, column: 54, length: 6, type: float64, decimals: 2, condition: 32-2-CA}
+`
+
+const customerSchemaJSON = `{
+	"name": "customer",
+	"fields": [
+		{"name": "Name", "column": 1, "length": 3},
+		{"name": "OpenDate", "column": 4, "length": 10, "type": "time", "format": "2006-01-02"},
+		{"name": "Age", "column": 14, "length": 3, "type": "uint"},
+		{"name": "Address", "column": 17, "length": 17, "fields": [
+			{"name": "Street", "column": 1, "length": 15},
+			{"name": "Country", "column": 16, "length": 2}
+		]},
+		{"name": "Phones", "column": 34, "length": 10, "occurs": 2, "type": "int64"},
+		{"name": "Initial", "column": 1, "length": 1, "type": "rune"},
+		{"name": "Balance", "column": 54, "length": 6, "type": "float64", "decimals": 2, "condition": "32-2-CA"}
+	]
+}`
+
+var customerRecordData = []byte("AMY1900-01-01019123 FAKE STREETCA41611122229053334444012345")
+
+func TestSchemaUnmarshal(t *testing.T) {
+	yamlSchema, err := ReadSchemaYAML(strings.NewReader(customerSchemaYAML))
+	if err != nil {
+		t.Fatalf("ReadSchemaYAML() error = %v", err)
+	}
+	jsonSchema, err := ReadSchemaJSON(strings.NewReader(customerSchemaJSON))
+	if err != nil {
+		t.Fatalf("ReadSchemaJSON() error = %v", err)
+	}
+
+	want := map[string]any{
+		"Name":     "AMY",
+		"OpenDate": time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		"Age":      uint(19),
+		"Address":  map[string]any{"Street": "123 FAKE STREET", "Country": "CA"},
+		"Phones":   []int64{4161112222, 9053334444},
+		"Initial":  'A',
+		"Balance":  123.45,
+	}
+	for name, schema := range map[string]*Schema{"yaml": yamlSchema, "json": jsonSchema} {
+		t.Run(name, func(t *testing.T) {
+			if length := schema.RecordLength(); length != len(customerRecordData) {
+				t.Errorf("RecordLength() = %d, want %d", length, len(customerRecordData))
+			}
+			got, err := schema.Unmarshal(customerRecordData)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Unmarshal() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestSchemaSkippedFields(t *testing.T) {
+	schema, err := ReadSchemaYAML(strings.NewReader(customerSchemaYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := append([]byte(nil), customerRecordData...)
+	copy(data[31:], "US")
+	got, err := schema.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if _, ok := got["Balance"]; ok {
+		t.Errorf("Unmarshal() decoded Balance when its condition was not met")
+	}
+
+	got, err = schema.Unmarshal(data[:16])
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(got) != 4 {
+		t.Errorf("Unmarshal() of a short record = %v", got)
+	}
+
+	//strings cut short by the end of the record are decoded from the bytes which remain, other fields fail
+	got, err = schema.Unmarshal(data[:20])
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if address, _ := got["Address"].(map[string]any); address["Street"] != "123 " {
+		t.Errorf("Unmarshal() of a record ending within a string = %v", got)
+	}
+	_, err = schema.Unmarshal(data[:15])
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Age" || !errors.Is(err, ErrCutShort) {
+		t.Errorf("Unmarshal() got error %v, want ErrCutShort for Age", err)
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		data    string
+		wantErr string
+	}{
+		{"Missing name", `{"fields": [{"column": 1, "length": 1}]}`, "", "has no name"},
+		{"Duplicate name", `{"fields": [{"name": "A", "column": 1, "length": 1}, {"name": "A", "column": 2, "length": 1}]}`, "", "Duplicate"},
+		{"Invalid column", `{"fields": [{"name": "A", "column": 0, "length": 1}]}`, "", "Invalid field A"},
+		{"Unknown type", `{"fields": [{"name": "A", "column": 1, "length": 1, "type": "decimal"}]}`, "", "Unknown type decimal"},
+		{"Time without format", `{"fields": [{"name": "A", "column": 1, "length": 1, "type": "time"}]}`, "", "format"},
+		{"Empty group", `{"fields": [{"name": "A", "column": 1, "length": 1, "type": "group"}]}`, "", "must have fields"},
+		{"Fields on scalar", `{"fields": [{"name": "A", "column": 1, "length": 1, "type": "int", "fields": [{"name": "B", "column": 1, "length": 1}]}]}`, "", "cannot have fields"},
+		{"Invalid group field", `{"fields": [{"name": "A", "column": 1, "length": 1, "fields": [{"name": "B", "column": 1, "length": 0}]}]}`, "", "Invalid field B"},
+		{"Unknown key", `{"fields": [{"name": "A", "column": 1, "length": 1, "colour": "red"}]}`, "", "unknown field"},
+		{"Invalid number", `{"fields": [{"name": "A", "column": 1, "length": 2, "type": "int"}]}`, "1X", "Field A value \"1X\" failed to decode"},
+		{"Invalid time", `{"fields": [{"name": "A", "column": 1, "length": 2, "type": "time", "format": "06"}]}`, "XX", "Field A value \"XX\" failed to decode"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := ReadSchemaJSON(strings.NewReader(tt.schema))
+			if err == nil {
+				_, err = schema.Unmarshal([]byte(tt.data))
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSchema(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"customer.yml": customerSchemaYAML, "customer.json": customerSchemaJSON, "customer.txt": ""} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"customer.yml", "customer.json"} {
+		schema, err := LoadSchema(filepath.Join(dir, name))
+		if err != nil || len(schema.Fields) != 7 {
+			t.Errorf("LoadSchema(%s) = %v, %v", name, schema, err)
+		}
+	}
+	if _, err := LoadSchema(filepath.Join(dir, "customer.txt")); err == nil {
+		t.Errorf("LoadSchema() expected error for unknown extension")
+	}
+	if _, err := LoadSchema(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("LoadSchema() expected error for missing file")
+	}
+}
+
+func TestSchemaCompile(t *testing.T) {
+	schema := &Schema{Fields: []SchemaField{{Name: "Amount", Column: 1, Length: 3, Type: "int", Encoding: "zoned"}}}
+	if _, err := schema.Decode([]byte("12J")); err == nil {
+		t.Errorf("Decode() expected error for a schema which is not compiled")
+	}
+	if err := schema.Compile(); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	record, err := schema.Decode([]byte("12J"))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if amount, err := record.Int("Amount"); amount != -121 || err != nil {
+		t.Errorf("Int() = %d, %v", amount, err)
+	}
+}
+
+func TestSchemaMatch(t *testing.T) {
+	schema, err := ReadSchemaJSON(strings.NewReader(`{"name": "order", "condition": "1-1-O", "fields": [{"name": "A", "column": 2, "length": 1}]}`))
+	if err != nil {
+		t.Fatalf("ReadSchemaJSON() error = %v", err)
+	}
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{"OX", true},
+		{"CX", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := schema.Match([]byte(tt.data)); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+	if !(&Schema{}).Match([]byte("X")) {
+		t.Errorf("Match() = false for a schema without a condition")
+	}
+
+	if _, err := ReadSchemaJSON(strings.NewReader(`{"condition": "1-O", "fields": []}`)); err == nil || !strings.Contains(err.Error(), "Invalid condition") {
+		t.Errorf("ReadSchemaJSON() error = %v, want invalid condition", err)
+	}
+}
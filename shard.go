@@ -0,0 +1,105 @@
+package flatfile
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+//shardScanSize is the size of the chunks read when looking for line breaks
+const shardScanSize = 64 * 1024
+
+//Shard is a byte range of a flat file which starts and ends on record boundaries
+//Shards can be read independently, for example by separate processes, using NewShardReader
+type Shard struct {
+	//Offset is the byte offset of the first record in the shard
+	Offset int64
+	//Length is the number of bytes in the shard
+	Length int64
+	//FirstRecord is the record number of the first record in the shard, counted from the start of the file starting at 1
+	FirstRecord int64
+}
+
+//Shards splits the first size bytes of r into at most k shards of roughly equal size
+//
+//When WithRecordLength is provided, records are fixed length and shard boundaries are computed directly.
+//Otherwise records are lines and r is scanned once to align boundaries to the start of a line and to count the records before each shard.
+//Fewer than k shards are returned when there are fewer records than shards.
+func Shards(r io.ReaderAt, size int64, k int, opts ...Option) ([]Shard, error) {
+	if k < 1 {
+		return nil, errors.Errorf("flatfile.Shards: Number of shards must be at least 1 but got %d", k)
+	}
+	if size < 0 {
+		return nil, errors.Errorf("flatfile.Shards: Size cannot be negative but got %d", size)
+	}
+
+	o := newOptions(opts)
+	if o.recordLength > 0 {
+		return fixedLengthShards(size, k, int64(o.recordLength)), nil
+	}
+	return lineShards(r, size, k)
+}
+
+//fixedLengthShards splits size bytes of fixed length records into k shards
+//A truncated record at the end of the file is included in the last shard so that reading it reports an error
+func fixedLengthShards(size int64, k int, recordLength int64) []Shard {
+	records := (size + recordLength - 1) / recordLength
+	perShard := (records + int64(k) - 1) / int64(k)
+
+	var shards []Shard
+	for first := int64(0); first < records; first += perShard {
+		shard := Shard{Offset: first * recordLength, Length: perShard * recordLength, FirstRecord: first + 1}
+		if shard.Offset+shard.Length > size {
+			shard.Length = size - shard.Offset
+		}
+		shards = append(shards, shard)
+	}
+	return shards
+}
+
+//lineShards splits size bytes of line delimited records into k shards
+//Every shard except the first begins immediately after a line break
+func lineShards(r io.ReaderAt, size int64, k int) ([]Shard, error) {
+	var shards []Shard
+	current := Shard{FirstRecord: 1}
+	//target is the offset at which the next shard should begin
+	target := size / int64(k)
+	lines := int64(0)
+
+	buf := make([]byte, shardScanSize)
+	for offset := int64(0); offset < size && len(shards) < k-1; {
+		chunk := buf
+		if remaining := size - offset; remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		n, err := r.ReadAt(chunk, offset)
+		if n == 0 && err != nil {
+			return nil, errors.Wrapf(err, "flatfile.Shards: Failed to read at offset %d", offset)
+		}
+
+		chunk = chunk[:n]
+		for pos := 0; len(shards) < k-1; pos++ {
+			idx := bytes.IndexByte(chunk[pos:], '\n')
+			if idx < 0 {
+				break
+			}
+			pos += idx
+			lines++
+
+			if boundary := offset + int64(pos) + 1; boundary >= target && boundary < size {
+				current.Length = boundary - current.Offset
+				shards = append(shards, current)
+				current = Shard{Offset: boundary, FirstRecord: lines + 1}
+				target = boundary + (size-boundary)/int64(k-len(shards))
+			}
+		}
+		offset += int64(n)
+	}
+
+	if current.Offset < size || len(shards) == 0 {
+		current.Length = size - current.Offset
+		shards = append(shards, current)
+	}
+	return shards, nil
+}
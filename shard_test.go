@@ -0,0 +1,111 @@
+package flatfile
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestShardsLines(t *testing.T) {
+	var data strings.Builder
+	for i := 0; i < 97; i++ {
+		fmt.Fprintf(&data, "RECORD%04d%d%s\n", i, i%10, strings.Repeat(" ", i%13))
+	}
+	file := strings.NewReader(data.String())
+
+	for k := 1; k <= 8; k++ {
+		t.Run(fmt.Sprintf("TestShardsLines-%d", k), func(t *testing.T) {
+			shards, err := Shards(file, file.Size(), k)
+			if err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+			if len(shards) != k {
+				t.Errorf("Shards() got %d shards want %d", len(shards), k)
+			}
+
+			next := int64(0)
+			record := 0
+			for _, shard := range shards {
+				if shard.Offset != next {
+					t.Errorf("Shards() shard %v does not start at offset %d", shard, next)
+				}
+				next = shard.Offset + shard.Length
+
+				reader := NewShardReader[testType](file, shard)
+				for reader.Next() {
+					if want := fmt.Sprintf("RECORD%04d", record); reader.Record().Data != want {
+						t.Errorf("NewShardReader() got: %v want: %s", reader.Record(), want)
+					}
+					record++
+					if reader.RecordNumber() != int64(record) {
+						t.Errorf("Reader.RecordNumber() got: %d want: %d", reader.RecordNumber(), record)
+					}
+				}
+				if err := reader.Err(); err != nil {
+					t.Errorf("Unexpected error %s", err)
+				}
+			}
+			if next != file.Size() || record != 97 {
+				t.Errorf("Shards() covered %d bytes and %d records want %d bytes and 97 records", next, record, file.Size())
+			}
+		})
+	}
+}
+
+func TestShardsFixedLength(t *testing.T) {
+	length, err := RecordLength(&testType{})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	var data strings.Builder
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&data, "RECORD%04d%d", i, i%10)
+	}
+	file := strings.NewReader(data.String())
+
+	shards, err := Shards(file, file.Size(), 3, WithRecordLength(length))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	want := []Shard{
+		{Offset: 0, Length: 17 * 11, FirstRecord: 1},
+		{Offset: 17 * 11, Length: 17 * 11, FirstRecord: 18},
+		{Offset: 34 * 11, Length: 16 * 11, FirstRecord: 35},
+	}
+	if len(shards) != len(want) {
+		t.Fatalf("Shards() got: %v want: %v", shards, want)
+	}
+	for i := range want {
+		if shards[i] != want[i] {
+			t.Errorf("Shards() got: %v want: %v", shards, want)
+		}
+	}
+
+	records, err := NewShardReader[testType](file, shards[2], WithRecordLength(length)).ReadAll()
+	if err != nil {
+		t.Errorf("Unexpected error %s", err)
+	}
+	if len(records) != 16 || records[0].Data != "RECORD0034" {
+		t.Errorf("NewShardReader() got: %v", records)
+	}
+}
+
+func TestShardReaderErr(t *testing.T) {
+	file := strings.NewReader("DATA!DATA!1\nDATA!DATA!2\nDATA!DATA!X\n")
+	shards, err := Shards(file, file.Size(), 2)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	_, err = NewShardReader[testType](file, shards[len(shards)-1]).ReadAll()
+	if err == nil || !strings.Contains(err.Error(), "record 3") {
+		t.Errorf("NewShardReader() error %v should report record 3", err)
+	}
+}
+
+func TestShardsErr(t *testing.T) {
+	if _, err := Shards(strings.NewReader(""), 0, 0); err == nil {
+		t.Error("Shards() should return error when number of shards is less than 1")
+	}
+}
@@ -0,0 +1,173 @@
+package flatfile
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//parseDiscriminatorOption parses the disc option of a union, a field name or a range in the form col:len
+func parseDiscriminatorOption(param string, ffpTag *flatfileTag) error {
+	tokens, err := tokenizeCondition(param)
+	if err != nil {
+		return errors.Wrapf(err, "flatfile.parseDiscriminatorOption: Invalid discriminator %s", param)
+	}
+	p := &conditionParser{tokens: tokens}
+	disc := &condition{}
+	if err := p.operand(disc); err != nil {
+		return errors.Wrapf(err, "flatfile.parseDiscriminatorOption: Invalid discriminator %s", param)
+	}
+	if p.pos < len(p.tokens) {
+		return errors.Errorf("flatfile.parseDiscriminatorOption: Discriminator %s must be a field name or a range in the form col:len", param)
+	}
+	ffpTag.disc = disc
+	ffpTag.discText = param
+	return nil
+}
+
+//parseVariantOption parses the values of the discriminator selecting a variant of a union, separated by |
+func parseVariantOption(param string, ffpTag *flatfileTag) error {
+	if param == "" {
+		return errors.New("flatfile.parseVariantOption: Variant parameter cannot be empty")
+	}
+	ffpTag.variants = strings.Split(param, "|")
+	return nil
+}
+
+/*checkUnion returns an error when the union and disc options are not used together, or when a union field is not a struct
+of pointers to structs with distinct variants which fit in the union
+
+Unions are areas of a record holding one of several layouts, like a COBOL REDEFINES, chosen by a discriminator
+
+A union field is a struct of pointers to structs, its tag has the union option and a disc option naming a field
+declared before it or a range in the form col:len. Each pointer is a variant whose variant option lists the values
+of the discriminator selecting it, its column is relative to the union.
+
+	type Payment struct {
+		Kind   string       `flatfile:"1,1"`
+		Method PaymentUnion `flatfile:"2,20,union,disc=Kind"`
+	}
+
+	type PaymentUnion struct {
+		Card *Card `flatfile:"1,20,variant=C"`
+		Bank *Bank `flatfile:"1,18,variant=B|S"`
+	}
+
+Only the variant selected by the discriminator is decoded, the others are set to nil. When no variant is selected all
+variants are nil. The discriminator is compared as text without padding, numbers without leading zeros.
+Marshal writes the variant which is not nil.
+*/
+func checkUnion(ffpTag *flatfileTag, fieldType reflect.Type) error {
+	if ffpTag.union != (ffpTag.disc != nil) {
+		return errors.New("flatfile.checkUnion: The union and disc options must be used together")
+	}
+	if !ffpTag.union {
+		return nil
+	}
+	if fieldType.Kind() != reflect.Struct || fieldType == timeType {
+		return errors.Errorf("flatfile.checkUnion: Union fields must be structs of pointers to structs, not %s", fieldType)
+	}
+	variants := map[string]string{}
+	for i, member := range layoutOf(fieldType).fields {
+		if !member.tagged {
+			continue
+		}
+		name := fieldType.Field(i).Name
+		if member.tagErr != nil {
+			return errors.Wrapf(member.tagErr, "flatfile.checkUnion: Failed to parse tag of variant %s", name)
+		}
+		memberType := fieldType.Field(i).Type
+		if memberType.Kind() != reflect.Ptr || memberType.Elem().Kind() != reflect.Struct || memberType.Elem() == timeType {
+			return errors.Errorf("flatfile.checkUnion: Variant %s must be a pointer to struct", name)
+		}
+		if len(member.tag.variants) == 0 {
+			return errors.Errorf("flatfile.checkUnion: Variant %s has no variant option", name)
+		}
+		if member.tag.col-1+member.tag.length > ffpTag.length {
+			return errors.Errorf("flatfile.checkUnion: Variant %s does not fit in the %d bytes of the union", name, ffpTag.length)
+		}
+		for _, value := range member.tag.variants {
+			if other, ok := variants[value]; ok {
+				return errors.Errorf("flatfile.checkUnion: Variants %s and %s are both selected by %s", other, name, value)
+			}
+			variants[value] = name
+		}
+	}
+	return nil
+}
+
+//assignUnion decodes the variant of union field selected by disc from the data of the union and sets the other variants to nil
+func (d *decoder) assignUnion(field reflect.Value, fieldData []byte, disc string) error {
+	unionType := field.Type()
+	selected := -1
+	for i, member := range layoutOf(unionType).fields {
+		if !member.tagged {
+			continue
+		}
+		field.Field(i).Set(reflect.Zero(field.Field(i).Type()))
+		if contains(member.tag.variants, disc) {
+			selected = i
+		}
+	}
+	if selected < 0 {
+		return nil
+	}
+
+	member := &layoutOf(unionType).fields[selected]
+	lowerBound := member.tag.col - 1
+	//like fields, a variant starting after the end of the record is left nil, the fields of one cut short are checked as Unmarshal does
+	if lowerBound >= len(fieldData) {
+		return nil
+	}
+	upperBound := min(lowerBound+member.tag.length, len(fieldData))
+
+	variant := field.Field(selected)
+	variant.Set(reflect.New(variant.Type().Elem()))
+	err := d.unmarshal(fieldData[lowerBound:upperBound], variant.Interface(), 0, 0, false)
+	//the validation errors of the variant are collected with those of the record
+	var fieldErrs FieldErrors
+	if errors.As(err, &fieldErrs) {
+		return fieldErrs.prefix(unionType.Field(selected).Name)
+	}
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return decodeError(unionType.Field(selected).Name, fieldData[lowerBound:upperBound], err)
+	}
+	return errors.Wrapf(err, "flatfile.assignUnion: Failed to unmarshal variant %s", unionType.Field(selected).Name)
+}
+
+//discriminator returns the text of the discriminator of a union in data or in the fields of the struct value v
+func (c *condition) discriminator(data []byte, v reflect.Value) string {
+	if c.field == "" {
+		return strings.TrimSpace(string(dataRange(data, c.col, c.length)))
+	}
+	field, isNil := c.fieldValue(v)
+	if isNil {
+		return ""
+	}
+	return valueText(field, c.fieldTag)
+}
+
+//checkVariants returns an error when more than one variant of the union field is set
+func checkVariants(field reflect.Value) error {
+	var set []string
+	for i, member := range layoutOf(field.Type()).fields {
+		if member.tagged && !field.Field(i).IsNil() {
+			set = append(set, field.Type().Field(i).Name)
+		}
+	}
+	if len(set) > 1 {
+		return errors.Errorf("flatfile.checkVariants: Only one variant of a union can be set, got %s", strings.Join(set, " and "))
+	}
+	return nil
+}
+
+//variantCondition returns the condition selecting a variant, used to describe it
+func variantCondition(disc *condition, variants []string) *condition {
+	cond := &condition{op: "in", col: disc.col, length: disc.length, field: disc.field, values: variants}
+	if len(variants) == 1 {
+		cond.op = "="
+	}
+	return cond
+}
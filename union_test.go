@@ -0,0 +1,200 @@
+package flatfile
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type unionCard struct {
+	Number string `flatfile:"1,16,required"`
+	Expiry int    `flatfile:"17,4"`
+}
+
+type unionBank struct {
+	Transit string `flatfile:"1,5"`
+	Account string `flatfile:"6,12"`
+}
+
+type paymentUnion struct {
+	Card *unionCard `flatfile:"1,20,variant=C"`
+	Bank *unionBank `flatfile:"1,17,variant=B|S"`
+}
+
+type payment struct {
+	Kind   string       `flatfile:"1,1"`
+	Method paymentUnion `flatfile:"2,20,union,disc=Kind"`
+	Amount int          `flatfile:"22,5"`
+}
+
+type rangePayment struct {
+	Method paymentUnion `flatfile:"2,20,union,disc=1:1"`
+}
+
+func TestUnionUnmarshal(t *testing.T) {
+	tests := []struct {
+		data string
+		want payment
+	}{
+		{"C4111111111111111229000100", payment{Kind: "C", Method: paymentUnion{Card: &unionCard{Number: "4111111111111111", Expiry: 2290}}, Amount: 100}},
+		{"B12345000000012345   00200", payment{Kind: "B", Method: paymentUnion{Bank: &unionBank{Transit: "12345", Account: "000000012345"}}, Amount: 200}},
+		{"S12345000000012345   00300", payment{Kind: "S", Method: paymentUnion{Bank: &unionBank{Transit: "12345", Account: "000000012345"}}, Amount: 300}},
+		{"X????????????????????00400", payment{Kind: "X", Amount: 400}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			//variants which are not selected are set to nil
+			record := payment{Method: paymentUnion{Card: &unionCard{}, Bank: &unionBank{}}}
+			if err := Unmarshal([]byte(tt.data), &record, 0, 0, false); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(record, tt.want) {
+				t.Errorf("Unmarshal() got: %+v want: %+v", record, tt.want)
+			}
+
+			var byRange rangePayment
+			if err := Unmarshal([]byte(tt.data), &byRange, 0, 0, false); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(byRange.Method, tt.want.Method) {
+				t.Errorf("Unmarshal() with a range got: %+v want: %+v", byRange.Method, tt.want.Method)
+			}
+
+			if tt.want.Kind == "X" {
+				return
+			}
+			data, err := Marshal(&tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.data {
+				t.Errorf("Marshal() got: %q want: %q", data, tt.data)
+			}
+		})
+	}
+}
+
+func TestUnionShortRecord(t *testing.T) {
+	//the strings of a variant cut short by the end of the record are decoded from the bytes which remain
+	var record payment
+	if err := Unmarshal([]byte("CXY"), &record, 0, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	want := payment{Kind: "C", Method: paymentUnion{Card: &unionCard{Number: "XY"}}}
+	if !reflect.DeepEqual(record, want) {
+		t.Errorf("Unmarshal() got: %+v want: %+v", record, want)
+	}
+
+	//other fields of a variant cut short fail to decode
+	err := Unmarshal([]byte("C4111111111111111229"), &record, 0, 0, false)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Method.Card.Expiry" || !errors.Is(err, ErrCutShort) {
+		t.Errorf("Unmarshal() got error %v, want ErrCutShort for Method.Card.Expiry", err)
+	}
+
+	//a variant starting after the end of the record is left nil
+	var offset struct {
+		Method struct {
+			Bank *unionBank `flatfile:"5,17,variant=B"`
+		} `flatfile:"2,21,union,disc=1:1"`
+	}
+	offset.Method.Bank = &unionBank{}
+	if err := Unmarshal([]byte("B123"), &offset, 0, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	if offset.Method.Bank != nil {
+		t.Errorf("Unmarshal() got: %+v want a nil variant", offset.Method.Bank)
+	}
+}
+
+func TestUnionErrors(t *testing.T) {
+	var record payment
+	err := Unmarshal([]byte("C                229000001"), &record, 0, 0, false)
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 1 || fieldErrs[0].Field != "Method.Card.Number" {
+		t.Errorf("Unmarshal() got error %v, want a required error for Method.Card.Number", err)
+	}
+	if err := Unmarshal([]byte("CXXXXXXXXXXXXXXXXYYYY00001"), &record, 0, 0, false); err == nil {
+		t.Error("Unmarshal() expected error for an invalid variant")
+	}
+
+	both := payment{Kind: "C", Method: paymentUnion{Card: &unionCard{}, Bank: &unionBank{}}}
+	if _, err := Marshal(both); err == nil {
+		t.Error("Marshal() expected error for a union with two variants set")
+	}
+}
+
+func TestUnionTagErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"union without disc", &struct {
+			Method paymentUnion `flatfile:"1,20,union"`
+		}{}},
+		{"disc without union", &struct {
+			Method paymentUnion `flatfile:"1,20,disc=1:1"`
+		}{}},
+		{"invalid disc", &struct {
+			Method paymentUnion `flatfile:"1,20,union,disc=1:1 = C"`
+		}{}},
+		{"disc declared after", &struct {
+			Method paymentUnion `flatfile:"2,20,union,disc=Kind"`
+			Kind   string       `flatfile:"1,1"`
+		}{}},
+		{"not a struct", &struct {
+			Method string `flatfile:"1,20,union,disc=1:1"`
+		}{}},
+		{"variant not a pointer", &struct {
+			Method struct {
+				Card unionCard `flatfile:"1,20,variant=C"`
+			} `flatfile:"1,20,union,disc=1:1"`
+		}{}},
+		{"variant without values", &struct {
+			Method struct {
+				Card *unionCard `flatfile:"1,20"`
+			} `flatfile:"1,20,union,disc=1:1"`
+		}{}},
+		{"variant too long", &struct {
+			Method paymentUnion `flatfile:"1,10,union,disc=1:1"`
+		}{}},
+		{"duplicate variant", &struct {
+			Method struct {
+				Card *unionCard `flatfile:"1,20,variant=C"`
+				Bank *unionBank `flatfile:"1,17,variant=B|C"`
+			} `flatfile:"1,20,union,disc=1:1"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal([]byte("C"), tt.v, 0, 0, false); err == nil {
+				t.Error("Unmarshal() expected error")
+			}
+		})
+	}
+}
+
+func TestUnionDescribe(t *testing.T) {
+	layout, err := DescribeLayout(rangePayment{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conditions := map[string]string{}
+	for _, field := range layout.Fields {
+		conditions[field.Name] = field.Condition
+	}
+	if conditions["Method.Card"] != "1:1 = C" || conditions["Method.Bank"] != "1:1 in B|S" {
+		t.Errorf("DescribeLayout() got conditions %v", conditions)
+	}
+
+	tag, err := ParseTag("2,20,union,disc=Kind")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tag.Union || tag.Discriminator != "Kind" {
+		t.Errorf("ParseTag() got %+v", tag)
+	}
+	if _, err := SchemaOf(payment{}); err == nil {
+		t.Error("SchemaOf() expected error for a union")
+	}
+}
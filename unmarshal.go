@@ -13,6 +13,9 @@ func min(a, b int) int {
 	return b
 }
 
+//ErrCutShort is the error of a field or element which is cut short by the end of a record
+var ErrCutShort = errors.New("flatfile: value is cut short by the end of the record")
+
 /*Unmarshal will read data and convert it into a struct based on a schema/map defined by struct tags
 
 Struct tags are in the form `flatfile:"col,len"`. col and len should be integers > 0
@@ -25,11 +28,43 @@ isPartialUnmarshal: flag should be set to true if the data[0] is intended to be
 
 If startFieldIdx == 0 and umFieldsToMarshal == 0 then Unmarshal will attempt to unmarshal all fields with an ffp tag
 
+Nil pointers are allocated, except that pointers with the optional option are set to nil when their data is blank.
+
+Unmarshal stops at the first field which fails to decode and returns a FieldError naming it by its path from v,
+for example Lines[2].Amount for an element of an array or slice. Fields and elements starting after the end of data are skipped.
+
+Fields and elements cut short by the end of data fail to decode with ErrCutShort, except strings: lines often have their
+trailing spaces trimmed, so a string is decoded from the bytes which remain. The fields of nested structs are checked one by one.
+
+Fields with validation rules are checked once decoded, see Validate. When rules are not satisfied, all fields are still
+unmarshalled and FieldErrors listing every violation is returned.
+
 */
 func Unmarshal(data []byte, v interface{}, startFieldIdx int, numFieldsToUnmarshal int, isPartialUnmarshal bool) error {
+	return (&decoder{}).unmarshal(data, v, startFieldIdx, numFieldsToUnmarshal, isPartialUnmarshal)
+}
+
+/*UnmarshalWith unmarshals a whole record into v like Unmarshal, using the decoding options among opts
+
+With WithLenient every field and element is attempted: those which fail to decode are left at their zero value and
+FieldErrors listing each of them, along with any validation rule which is not satisfied, is returned.
+*/
+func UnmarshalWith(data []byte, v interface{}, opts ...Option) error {
+	return newOptions(opts).decoder.unmarshal(data, v, 0, 0, false)
+}
+
+//unmarshal implements Unmarshal using the options of the decoder
+func (d *decoder) unmarshal(data []byte, v interface{}, startFieldIdx int, numFieldsToUnmarshal int, isPartialUnmarshal bool) error {
+	//types with generated methods decode themselves when the whole record is unmarshalled without decoding options,
+	//which only reflection applies
+	if *d == (decoder{}) && startFieldIdx == 0 && numFieldsToUnmarshal == 0 && !isPartialUnmarshal {
+		if u, ok := v.(Unmarshaler); ok {
+			return u.UnmarshalFlatfile(data)
+		}
+	}
 	colOffset := 0
-	//init ffpTag for later use
-	ffpTag := &flatfileTag{}
+	//fieldErrs collects the validation rules which are not satisfied, returned once all fields are unmarshalled
+	var fieldErrs FieldErrors
 	if reflect.TypeOf(v).Kind() == reflect.Ptr {
 		//Get underlying type
 		vType := reflect.TypeOf(v).Elem()
@@ -38,6 +73,8 @@ func Unmarshal(data []byte, v interface{}, startFieldIdx int, numFieldsToUnmarsh
 		if vType.Kind() == reflect.Struct {
 			//Dereference pointer to struct
 			vStruct := reflect.ValueOf(v).Elem()
+			//Tags are parsed once per type and cached
+			layout := layoutOf(vType)
 			maxField := 0
 			if numFieldsToUnmarshal > 0 {
 				maxField = min(startFieldIdx+numFieldsToUnmarshal, vStruct.NumField())
@@ -49,14 +86,25 @@ func Unmarshal(data []byte, v interface{}, startFieldIdx int, numFieldsToUnmarsh
 
 				//Get underlying type of field
 				fieldType := vStruct.Field(i).Type()
-				fieldTag, tagFlag := vType.Field(i).Tag.Lookup("flatfile")
-				if tagFlag {
-
-					tagParseErr := parseFlatfileTag(fieldTag, ffpTag)
-					if tagParseErr != nil {
-						return errors.Wrapf(tagParseErr, "flatfile.Unmarshal: Failed to parse field tag %s", fieldTag)
+				//the fields of untagged embedded structs have columns relative to the same data
+				if layout.fields[i].embedded && colOffset == 0 {
+					embedded, _ := embeddedValue(vStruct.Field(i), true)
+					err := d.unmarshal(data, embedded.Addr().Interface(), 0, 0, false)
+					var nestedErrs FieldErrors
+					switch {
+					case errors.As(err, &nestedErrs):
+						fieldErrs = append(fieldErrs, nestedErrs...)
+					case err != nil:
+						return errors.Wrapf(err, "flatfile.Unmarshal: Failed to unmarshal embedded %s", vType.Field(i).Name)
 					}
-					if ShouldUnmarshal(ffpTag, data) {
+					continue
+				}
+				if field := &layout.fields[i]; field.tagged {
+					ffpTag := &field.tag
+					if field.tagErr != nil {
+						return errors.Wrapf(field.tagErr, "flatfile.Unmarshal: Failed to parse field tag %s", field.rawTag)
+					}
+					if shouldUnmarshal(ffpTag, data, vStruct) {
 						//determine pos offset based on start index in case start index not 0 (1)
 						if i == startFieldIdx && startFieldIdx > 0 && isPartialUnmarshal {
 							colOffset = ffpTag.col - 1
@@ -66,13 +114,40 @@ func Unmarshal(data []byte, v interface{}, startFieldIdx int, numFieldsToUnmarsh
 						if ffpTag.col > colOffset {
 							//extract byte slice from byte data
 							lowerBound := ffpTag.col - 1 - colOffset
-							upperBound := lowerBound + ffpTag.length
+							//the data of all occurrences of arrays and slices, each element is bounds checked when it is assigned
+							//data cut short by the end of the record is checked by assignBasedOnKind, which only accepts it for strings
+							upperBound := min(lowerBound+ffpTag.length*occurrences(ffpTag, fieldType), len(data))
 							//and check that pos does not exceed length of bytes to prevent attempting to parse nulls
 							if lowerBound < len(data) {
 								fieldData := data[lowerBound:upperBound]
-								err := assignBasedOnKind(fieldType.Kind(), vStruct.Field(i), fieldData, ffpTag)
+								var err error
+								if ffpTag.union {
+									err = d.assignUnion(vStruct.Field(i), fieldData, ffpTag.disc.discriminator(data, vStruct))
+								} else {
+									err = d.assignBasedOnKind(fieldType.Kind(), vStruct.Field(i), fieldData, ffpTag)
+								}
 								if err != nil {
-									return errors.Wrap(err, "flatfile.Unmarshal: Failed to unmarshal")
+									//the validation errors of nested structs are collected with those of this struct
+									var nestedErrs FieldErrors
+									switch {
+									case errors.As(err, &nestedErrs):
+										fieldErrs = append(fieldErrs, nestedErrs.prefix(vType.Field(i).Name)...)
+									case d.lenient:
+										//fields which fail to decode are left at their zero value and decoding continues
+										vStruct.Field(i).Set(reflect.Zero(fieldType))
+										fieldErrs = append(fieldErrs, decodeError(vType.Field(i).Name, fieldData, err))
+										continue
+									default:
+										//the error names the field by its path from v, for example Lines[2].Amount
+										return errors.Wrap(decodeError(vType.Field(i).Name, fieldData, err), "flatfile.Unmarshal: Failed to unmarshal")
+									}
+								}
+								if ffpTag.validated() {
+									errs, err := validateField(vType.Field(i).Name, vStruct.Field(i), ffpTag)
+									if err != nil {
+										return errors.Wrap(err, "flatfile.Unmarshal: Failed to validate")
+									}
+									fieldErrs = append(fieldErrs, errs...)
 								}
 							}
 						}
@@ -80,6 +155,9 @@ func Unmarshal(data []byte, v interface{}, startFieldIdx int, numFieldsToUnmarsh
 				}
 			}
 		}
+		if len(fieldErrs) > 0 {
+			return fieldErrs
+		}
 		return nil
 	}
 	return errors.Errorf("flatfile.Unmarshal: Unmarshal not complete. %s is not a pointer", reflect.TypeOf(v))
@@ -155,12 +233,69 @@ func CalcNumFieldsToUnmarshal(data []byte, v interface{}, fieldOffset int) (int,
 	return 0, []byte(""), errors.Errorf("flatfile.CalcNumFieldsToUnmarshal: CalcNumFieldsToUnmarshal not complete. %s is not a pointer", reflect.TypeOf(v))
 }
 
+//RecordLength returns the length of a record described by the flatfile tags of v
+//The length is the last column covered by any field, including all occurrences of array and slice fields
+func RecordLength(v interface{}) (int, error) {
+	vType := reflect.TypeOf(v)
+	if vType != nil && vType.Kind() == reflect.Ptr {
+		vType = vType.Elem()
+	}
+	if vType == nil || vType.Kind() != reflect.Struct {
+		return 0, errors.Errorf("flatfile.RecordLength: %s is not a struct or pointer to struct", reflect.TypeOf(v))
+	}
+	return recordLength(vType)
+}
+
+//recordLength implements RecordLength for a struct type
+func recordLength(vType reflect.Type) (int, error) {
+	length := 0
+	for i, field := range layoutOf(vType).fields {
+		if field.embedded {
+			end, err := recordLength(embeddedStruct(vType.Field(i).Type))
+			if err != nil {
+				return 0, err
+			}
+			length = max(length, end)
+		}
+		if !field.tagged {
+			continue
+		}
+		if field.tagErr != nil {
+			return 0, errors.Wrapf(field.tagErr, "flatfile.RecordLength: Failed to parse field tag %s", field.rawTag)
+		}
+		ffpTag := &field.tag
+		if end := ffpTag.col - 1 + ffpTag.length*occurrences(ffpTag, vType.Field(i).Type); end > length {
+			length = end
+		}
+	}
+	return length, nil
+}
+
+//occurrences returns how many times a field of type fieldType repeats in a record
+func occurrences(ffpTag *flatfileTag, fieldType reflect.Type) int {
+	if ffpTag.occurs > 0 {
+		return ffpTag.occurs
+	}
+	if fieldType.Kind() == reflect.Array {
+		return fieldType.Len()
+	}
+	return 1
+}
+
 //ShouldUnmarshal returns true if the condition
+//Conditions which compare fields are not met, the fields are only known while a struct is unmarshalled.
 func ShouldUnmarshal(ffpTag *flatfileTag, data []byte) bool {
+	return shouldUnmarshal(ffpTag, data, reflect.Value{})
+}
+
+//shouldUnmarshal returns true if the condition holds for data and the fields of the struct value v decoded before the field
+func shouldUnmarshal(ffpTag *flatfileTag, data []byte, v reflect.Value) bool {
+	if ffpTag.cond != nil {
+		return ffpTag.cond.met(data, v)
+	}
 	if ffpTag.condChk {
-		lowerBound := ffpTag.condCol - 1
-		upperBound := lowerBound + ffpTag.condLen
-		return string(data[lowerBound:upperBound]) == ffpTag.condVal
+		//like condition expressions, a range which extends past the end of data compares the part within data
+		return string(dataRange(data, ffpTag.condCol, ffpTag.condLen)) == ffpTag.condVal
 	}
 
 	return true
@@ -0,0 +1,2467 @@
+// Code generated by flatfilegen; DO NOT EDIT.
+
+package flatfile
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *boolFalseStruct) UnmarshalFlatfile(data []byte) error {
+	// BoolFalse1 `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData1 := data[0:min(1, len(data))]
+		if b, err := ParseBool(fieldData1); err != nil {
+			return DecodeError("BoolFalse1", fieldData1, err)
+		} else {
+			v.BoolFalse1 = bool(b)
+		}
+	}
+	// BoolFalse2 `flatfile:"2,1"`
+	if len(data) > 1 {
+		fieldData2 := data[1:min(2, len(data))]
+		if b, err := ParseBool(fieldData2); err != nil {
+			return DecodeError("BoolFalse2", fieldData2, err)
+		} else {
+			v.BoolFalse2 = bool(b)
+		}
+	}
+	// BoolFalse3 `flatfile:"3,1"`
+	if len(data) > 2 {
+		fieldData3 := data[2:min(3, len(data))]
+		if b, err := ParseBool(fieldData3); err != nil {
+			return DecodeError("BoolFalse3", fieldData3, err)
+		} else {
+			v.BoolFalse3 = bool(b)
+		}
+	}
+	// BoolFalse4 `flatfile:"4,5"`
+	if len(data) > 3 {
+		fieldData4 := data[3:min(8, len(data))]
+		if len(fieldData4) < 5 {
+			err := ErrCutShort
+			return DecodeError("BoolFalse4", fieldData4, err)
+		}
+		if b, err := ParseBool(fieldData4); err != nil {
+			return DecodeError("BoolFalse4", fieldData4, err)
+		} else {
+			v.BoolFalse4 = bool(b)
+		}
+	}
+	// BoolFalse5 `flatfile:"9,5"`
+	if len(data) > 8 {
+		fieldData5 := data[8:min(13, len(data))]
+		if len(fieldData5) < 5 {
+			err := ErrCutShort
+			return DecodeError("BoolFalse5", fieldData5, err)
+		}
+		if b, err := ParseBool(fieldData5); err != nil {
+			return DecodeError("BoolFalse5", fieldData5, err)
+		} else {
+			v.BoolFalse5 = bool(b)
+		}
+	}
+	// BoolFalse6 `flatfile:"14,5"`
+	if len(data) > 13 {
+		fieldData6 := data[13:min(18, len(data))]
+		if len(fieldData6) < 5 {
+			err := ErrCutShort
+			return DecodeError("BoolFalse6", fieldData6, err)
+		}
+		if b, err := ParseBool(fieldData6); err != nil {
+			return DecodeError("BoolFalse6", fieldData6, err)
+		} else {
+			v.BoolFalse6 = bool(b)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v boolFalseStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 18)
+	// BoolFalse1 `flatfile:"1,1"`
+	if err := EncodeBool(data[0:1], bool(v.BoolFalse1)); err != nil {
+		return nil, fmt.Errorf("boolFalseStruct.BoolFalse1: %w", err)
+	}
+	// BoolFalse2 `flatfile:"2,1"`
+	if err := EncodeBool(data[1:2], bool(v.BoolFalse2)); err != nil {
+		return nil, fmt.Errorf("boolFalseStruct.BoolFalse2: %w", err)
+	}
+	// BoolFalse3 `flatfile:"3,1"`
+	if err := EncodeBool(data[2:3], bool(v.BoolFalse3)); err != nil {
+		return nil, fmt.Errorf("boolFalseStruct.BoolFalse3: %w", err)
+	}
+	// BoolFalse4 `flatfile:"4,5"`
+	if err := EncodeBool(data[3:8], bool(v.BoolFalse4)); err != nil {
+		return nil, fmt.Errorf("boolFalseStruct.BoolFalse4: %w", err)
+	}
+	// BoolFalse5 `flatfile:"9,5"`
+	if err := EncodeBool(data[8:13], bool(v.BoolFalse5)); err != nil {
+		return nil, fmt.Errorf("boolFalseStruct.BoolFalse5: %w", err)
+	}
+	// BoolFalse6 `flatfile:"14,5"`
+	if err := EncodeBool(data[13:18], bool(v.BoolFalse6)); err != nil {
+		return nil, fmt.Errorf("boolFalseStruct.BoolFalse6: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *boolTrueStruct) UnmarshalFlatfile(data []byte) error {
+	// BoolTrue1 `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData7 := data[0:min(1, len(data))]
+		if b, err := ParseBool(fieldData7); err != nil {
+			return DecodeError("BoolTrue1", fieldData7, err)
+		} else {
+			v.BoolTrue1 = bool(b)
+		}
+	}
+	// BoolTrue2 `flatfile:"2,1"`
+	if len(data) > 1 {
+		fieldData8 := data[1:min(2, len(data))]
+		if b, err := ParseBool(fieldData8); err != nil {
+			return DecodeError("BoolTrue2", fieldData8, err)
+		} else {
+			v.BoolTrue2 = bool(b)
+		}
+	}
+	// BoolTrue3 `flatfile:"3,1"`
+	if len(data) > 2 {
+		fieldData9 := data[2:min(3, len(data))]
+		if b, err := ParseBool(fieldData9); err != nil {
+			return DecodeError("BoolTrue3", fieldData9, err)
+		} else {
+			v.BoolTrue3 = bool(b)
+		}
+	}
+	// BoolTrue4 `flatfile:"4,4"`
+	if len(data) > 3 {
+		fieldData10 := data[3:min(7, len(data))]
+		if len(fieldData10) < 4 {
+			err := ErrCutShort
+			return DecodeError("BoolTrue4", fieldData10, err)
+		}
+		if b, err := ParseBool(fieldData10); err != nil {
+			return DecodeError("BoolTrue4", fieldData10, err)
+		} else {
+			v.BoolTrue4 = bool(b)
+		}
+	}
+	// BoolTrue5 `flatfile:"8,4"`
+	if len(data) > 7 {
+		fieldData11 := data[7:min(11, len(data))]
+		if len(fieldData11) < 4 {
+			err := ErrCutShort
+			return DecodeError("BoolTrue5", fieldData11, err)
+		}
+		if b, err := ParseBool(fieldData11); err != nil {
+			return DecodeError("BoolTrue5", fieldData11, err)
+		} else {
+			v.BoolTrue5 = bool(b)
+		}
+	}
+	// BoolTrue6 `flatfile:"12,4"`
+	if len(data) > 11 {
+		fieldData12 := data[11:min(15, len(data))]
+		if len(fieldData12) < 4 {
+			err := ErrCutShort
+			return DecodeError("BoolTrue6", fieldData12, err)
+		}
+		if b, err := ParseBool(fieldData12); err != nil {
+			return DecodeError("BoolTrue6", fieldData12, err)
+		} else {
+			v.BoolTrue6 = bool(b)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v boolTrueStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 15)
+	// BoolTrue1 `flatfile:"1,1"`
+	if err := EncodeBool(data[0:1], bool(v.BoolTrue1)); err != nil {
+		return nil, fmt.Errorf("boolTrueStruct.BoolTrue1: %w", err)
+	}
+	// BoolTrue2 `flatfile:"2,1"`
+	if err := EncodeBool(data[1:2], bool(v.BoolTrue2)); err != nil {
+		return nil, fmt.Errorf("boolTrueStruct.BoolTrue2: %w", err)
+	}
+	// BoolTrue3 `flatfile:"3,1"`
+	if err := EncodeBool(data[2:3], bool(v.BoolTrue3)); err != nil {
+		return nil, fmt.Errorf("boolTrueStruct.BoolTrue3: %w", err)
+	}
+	// BoolTrue4 `flatfile:"4,4"`
+	if err := EncodeBool(data[3:7], bool(v.BoolTrue4)); err != nil {
+		return nil, fmt.Errorf("boolTrueStruct.BoolTrue4: %w", err)
+	}
+	// BoolTrue5 `flatfile:"8,4"`
+	if err := EncodeBool(data[7:11], bool(v.BoolTrue5)); err != nil {
+		return nil, fmt.Errorf("boolTrueStruct.BoolTrue5: %w", err)
+	}
+	// BoolTrue6 `flatfile:"12,4"`
+	if err := EncodeBool(data[11:15], bool(v.BoolTrue6)); err != nil {
+		return nil, fmt.Errorf("boolTrueStruct.BoolTrue6: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *boolErrStruct) UnmarshalFlatfile(data []byte) error {
+	// BoolTrue1 `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData13 := data[0:min(1, len(data))]
+		if b, err := ParseBool(fieldData13); err != nil {
+			return DecodeError("BoolTrue1", fieldData13, err)
+		} else {
+			v.BoolTrue1 = bool(b)
+		}
+	}
+	// BoolTrue2 `flatfile:"2,1"`
+	if len(data) > 1 {
+		fieldData14 := data[1:min(2, len(data))]
+		if b, err := ParseBool(fieldData14); err != nil {
+			return DecodeError("BoolTrue2", fieldData14, err)
+		} else {
+			v.BoolTrue2 = bool(b)
+		}
+	}
+	// BoolTrue3 `flatfile:"3,1"`
+	if len(data) > 2 {
+		fieldData15 := data[2:min(3, len(data))]
+		if b, err := ParseBool(fieldData15); err != nil {
+			return DecodeError("BoolTrue3", fieldData15, err)
+		} else {
+			v.BoolTrue3 = bool(b)
+		}
+	}
+	// BoolTrue4 `flatfile:"4,4"`
+	if len(data) > 3 {
+		fieldData16 := data[3:min(7, len(data))]
+		if len(fieldData16) < 4 {
+			err := ErrCutShort
+			return DecodeError("BoolTrue4", fieldData16, err)
+		}
+		if b, err := ParseBool(fieldData16); err != nil {
+			return DecodeError("BoolTrue4", fieldData16, err)
+		} else {
+			v.BoolTrue4 = bool(b)
+		}
+	}
+	// BoolTrue5 `flatfile:"8,4"`
+	if len(data) > 7 {
+		fieldData17 := data[7:min(11, len(data))]
+		if len(fieldData17) < 4 {
+			err := ErrCutShort
+			return DecodeError("BoolTrue5", fieldData17, err)
+		}
+		if b, err := ParseBool(fieldData17); err != nil {
+			return DecodeError("BoolTrue5", fieldData17, err)
+		} else {
+			v.BoolTrue5 = bool(b)
+		}
+	}
+	// BoolTrue6 `flatfile:"12,4"`
+	if len(data) > 11 {
+		fieldData18 := data[11:min(15, len(data))]
+		if len(fieldData18) < 4 {
+			err := ErrCutShort
+			return DecodeError("BoolTrue6", fieldData18, err)
+		}
+		if b, err := ParseBool(fieldData18); err != nil {
+			return DecodeError("BoolTrue6", fieldData18, err)
+		} else {
+			v.BoolTrue6 = bool(b)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v boolErrStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 15)
+	// BoolTrue1 `flatfile:"1,1"`
+	if err := EncodeBool(data[0:1], bool(v.BoolTrue1)); err != nil {
+		return nil, fmt.Errorf("boolErrStruct.BoolTrue1: %w", err)
+	}
+	// BoolTrue2 `flatfile:"2,1"`
+	if err := EncodeBool(data[1:2], bool(v.BoolTrue2)); err != nil {
+		return nil, fmt.Errorf("boolErrStruct.BoolTrue2: %w", err)
+	}
+	// BoolTrue3 `flatfile:"3,1"`
+	if err := EncodeBool(data[2:3], bool(v.BoolTrue3)); err != nil {
+		return nil, fmt.Errorf("boolErrStruct.BoolTrue3: %w", err)
+	}
+	// BoolTrue4 `flatfile:"4,4"`
+	if err := EncodeBool(data[3:7], bool(v.BoolTrue4)); err != nil {
+		return nil, fmt.Errorf("boolErrStruct.BoolTrue4: %w", err)
+	}
+	// BoolTrue5 `flatfile:"8,4"`
+	if err := EncodeBool(data[7:11], bool(v.BoolTrue5)); err != nil {
+		return nil, fmt.Errorf("boolErrStruct.BoolTrue5: %w", err)
+	}
+	// BoolTrue6 `flatfile:"12,4"`
+	if err := EncodeBool(data[11:15], bool(v.BoolTrue6)); err != nil {
+		return nil, fmt.Errorf("boolErrStruct.BoolTrue6: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *arrayStruct) UnmarshalFlatfile(data []byte) error {
+	// TestVal `flatfile:"1,2"`
+	if len(data) > 0 {
+		fieldData19 := data[0:min(8, len(data))]
+		for i20 := 0; i20 < 4; i20++ {
+			if i20*2 >= len(fieldData19) {
+				break
+			}
+			elemData21 := fieldData19[i20*2 : min((i20+1)*2, len(fieldData19))]
+			if len(elemData21) < 2 {
+				err := ErrCutShort
+				err = DecodeError(fmt.Sprintf("[%d]", i20), elemData21, err)
+				return DecodeError("TestVal", fieldData19, err)
+			}
+			if n, err := ParseInt(elemData21, 0); err != nil {
+				err = DecodeError(fmt.Sprintf("[%d]", i20), elemData21, err)
+				return DecodeError("TestVal", fieldData19, err)
+			} else {
+				v.TestVal[i20] = int(n)
+			}
+		}
+	}
+	// Names `flatfile:"9,3"`
+	if len(data) > 8 {
+		fieldData22 := data[8:min(38, len(data))]
+		for i23 := 0; i23 < 10; i23++ {
+			if i23*3 >= len(fieldData22) {
+				break
+			}
+			elemData24 := fieldData22[i23*3 : min((i23+1)*3, len(fieldData22))]
+			v.Names[i23] = string(elemData24)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v arrayStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 38)
+	// TestVal `flatfile:"1,2"`
+	for i25 := range v.TestVal {
+		if err := EncodeInt(data[0:8][i25*2:(i25+1)*2], int64(v.TestVal[i25])); err != nil {
+			return nil, fmt.Errorf("arrayStruct.TestVal: %w", err)
+		}
+	}
+	// Names `flatfile:"9,3"`
+	for i26 := range v.Names {
+		if err := EncodeString(data[8:38][i26*3:(i26+1)*3], string(v.Names[i26])); err != nil {
+			return nil, fmt.Errorf("arrayStruct.Names: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *arrayName) UnmarshalFlatfile(data []byte) error {
+	// NameData `flatfile:"2,2"`
+	if len(data) > 1 {
+		fieldData27 := data[1:min(3, len(data))]
+		v.NameData = string(fieldData27)
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v arrayName) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 3)
+	// NameData `flatfile:"2,2"`
+	if err := EncodeString(data[1:3], string(v.NameData)); err != nil {
+		return nil, fmt.Errorf("arrayName.NameData: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *arrayNestedStruct) UnmarshalFlatfile(data []byte) error {
+	// Names `flatfile:"1,3"`
+	if len(data) > 0 {
+		fieldData28 := data[0:min(9, len(data))]
+		for i29 := 0; i29 < 3; i29++ {
+			if i29*3 >= len(fieldData28) {
+				break
+			}
+			elemData30 := fieldData28[i29*3 : min((i29+1)*3, len(fieldData28))]
+			if err := v.Names[i29].UnmarshalFlatfile(elemData30); err != nil {
+				err = DecodeError(fmt.Sprintf("[%d]", i29), elemData30, err)
+				return DecodeError("Names", fieldData28, err)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v arrayNestedStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 9)
+	// Names `flatfile:"1,3"`
+	for i31 := range v.Names {
+		if record32, err := v.Names[i31].MarshalFlatfile(); err != nil {
+			return nil, fmt.Errorf("arrayNestedStruct.Names: %w", err)
+		} else if len(record32) > len(data[0:9][i31*3:(i31+1)*3]) {
+			err := ErrValueTooLong
+			return nil, fmt.Errorf("arrayNestedStruct.Names: %w", err)
+		} else {
+			copy(data[0:9][i31*3:(i31+1)*3], record32)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *sliceStruct) UnmarshalFlatfile(data []byte) error {
+	// TestVal `flatfile:"1,2,4"`
+	if len(data) > 0 {
+		fieldData33 := data[0:min(8, len(data))]
+		v.TestVal = make([]int, 4)
+		for i34 := 0; i34 < 4; i34++ {
+			if i34*2 >= len(fieldData33) {
+				break
+			}
+			elemData35 := fieldData33[i34*2 : min((i34+1)*2, len(fieldData33))]
+			if len(elemData35) < 2 {
+				err := ErrCutShort
+				err = DecodeError(fmt.Sprintf("[%d]", i34), elemData35, err)
+				return DecodeError("TestVal", fieldData33, err)
+			}
+			if n, err := ParseInt(elemData35, 0); err != nil {
+				err = DecodeError(fmt.Sprintf("[%d]", i34), elemData35, err)
+				return DecodeError("TestVal", fieldData33, err)
+			} else {
+				v.TestVal[i34] = int(n)
+			}
+		}
+	}
+	// Names `flatfile:"9,3,10"`
+	if len(data) > 8 {
+		fieldData36 := data[8:min(38, len(data))]
+		v.Names = make([]string, 10)
+		for i37 := 0; i37 < 10; i37++ {
+			if i37*3 >= len(fieldData36) {
+				break
+			}
+			elemData38 := fieldData36[i37*3 : min((i37+1)*3, len(fieldData36))]
+			v.Names[i37] = string(elemData38)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v sliceStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 38)
+	// TestVal `flatfile:"1,2,4"`
+	if len(v.TestVal) > 4 {
+		err := fmt.Errorf("%d elements exceed the 4 occurrences of the field", len(v.TestVal))
+		return nil, fmt.Errorf("sliceStruct.TestVal: %w", err)
+	}
+	for i39 := range v.TestVal {
+		if err := EncodeInt(data[0:8][i39*2:(i39+1)*2], int64(v.TestVal[i39])); err != nil {
+			return nil, fmt.Errorf("sliceStruct.TestVal: %w", err)
+		}
+	}
+	// Names `flatfile:"9,3,10"`
+	if len(v.Names) > 10 {
+		err := fmt.Errorf("%d elements exceed the 10 occurrences of the field", len(v.Names))
+		return nil, fmt.Errorf("sliceStruct.Names: %w", err)
+	}
+	for i40 := range v.Names {
+		if err := EncodeString(data[8:38][i40*3:(i40+1)*3], string(v.Names[i40])); err != nil {
+			return nil, fmt.Errorf("sliceStruct.Names: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *sliceName) UnmarshalFlatfile(data []byte) error {
+	// NameData `flatfile:"2,2"`
+	if len(data) > 1 {
+		fieldData41 := data[1:min(3, len(data))]
+		v.NameData = string(fieldData41)
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v sliceName) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 3)
+	// NameData `flatfile:"2,2"`
+	if err := EncodeString(data[1:3], string(v.NameData)); err != nil {
+		return nil, fmt.Errorf("sliceName.NameData: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *sliceNestedStruct) UnmarshalFlatfile(data []byte) error {
+	// Names `flatfile:"1,3,3"`
+	if len(data) > 0 {
+		fieldData42 := data[0:min(9, len(data))]
+		v.Names = make([]sliceName, 3)
+		for i43 := 0; i43 < 3; i43++ {
+			if i43*3 >= len(fieldData42) {
+				break
+			}
+			elemData44 := fieldData42[i43*3 : min((i43+1)*3, len(fieldData42))]
+			if err := v.Names[i43].UnmarshalFlatfile(elemData44); err != nil {
+				err = DecodeError(fmt.Sprintf("[%d]", i43), elemData44, err)
+				return DecodeError("Names", fieldData42, err)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v sliceNestedStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 9)
+	// Names `flatfile:"1,3,3"`
+	if len(v.Names) > 3 {
+		err := fmt.Errorf("%d elements exceed the 3 occurrences of the field", len(v.Names))
+		return nil, fmt.Errorf("sliceNestedStruct.Names: %w", err)
+	}
+	for i45 := range v.Names {
+		if record46, err := v.Names[i45].MarshalFlatfile(); err != nil {
+			return nil, fmt.Errorf("sliceNestedStruct.Names: %w", err)
+		} else if len(record46) > len(data[0:9][i45*3:(i45+1)*3]) {
+			err := ErrValueTooLong
+			return nil, fmt.Errorf("sliceNestedStruct.Names: %w", err)
+		} else {
+			copy(data[0:9][i45*3:(i45+1)*3], record46)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *byteStruct) UnmarshalFlatfile(data []byte) error {
+	// ByteOne `flatfile:"1,1,override=byte"`
+	if len(data) > 0 {
+		fieldData47 := data[0:min(1, len(data))]
+		v.ByteOne = byte(fieldData47[0])
+	}
+	// ByteTwo `flatfile:"2,1,override=byte"`
+	if len(data) > 1 {
+		fieldData48 := data[1:min(2, len(data))]
+		v.ByteTwo = byte(fieldData48[0])
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v byteStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 2)
+	// ByteOne `flatfile:"1,1,override=byte"`
+	data[0] = byte(v.ByteOne)
+	// ByteTwo `flatfile:"2,1,override=byte"`
+	data[1] = byte(v.ByteTwo)
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *runeStruct) UnmarshalFlatfile(data []byte) error {
+	// RuneOne `flatfile:"1,1,override=rune"`
+	if len(data) > 0 {
+		fieldData49 := data[0:min(1, len(data))]
+		if r, err := ParseRune(fieldData49); err != nil {
+			return DecodeError("RuneOne", fieldData49, err)
+		} else {
+			v.RuneOne = rune(r)
+		}
+	}
+	// RuneTwo `flatfile:"2,1,override=rune"`
+	if len(data) > 1 {
+		fieldData50 := data[1:min(2, len(data))]
+		if r, err := ParseRune(fieldData50); err != nil {
+			return DecodeError("RuneTwo", fieldData50, err)
+		} else {
+			v.RuneTwo = rune(r)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v runeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 2)
+	// RuneOne `flatfile:"1,1,override=rune"`
+	if err := EncodeRune(data[0:1], rune(v.RuneOne)); err != nil {
+		return nil, fmt.Errorf("runeStruct.RuneOne: %w", err)
+	}
+	// RuneTwo `flatfile:"2,1,override=rune"`
+	if err := EncodeRune(data[1:2], rune(v.RuneTwo)); err != nil {
+		return nil, fmt.Errorf("runeStruct.RuneTwo: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *nestedPointerData) UnmarshalFlatfile(data []byte) error {
+	// ByteOne `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData51 := data[0:min(1, len(data))]
+		if v.ByteOne == nil {
+			v.ByteOne = new(int)
+		}
+		if n, err := ParseInt(fieldData51, 0); err != nil {
+			return DecodeError("ByteOne", fieldData51, err)
+		} else {
+			(*v.ByteOne) = int(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v nestedPointerData) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// ByteOne `flatfile:"1,1"`
+	if v.ByteOne != nil {
+		if err := EncodeInt(data[0:1], int64((*v.ByteOne))); err != nil {
+			return nil, fmt.Errorf("nestedPointerData.ByteOne: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *nestedPointerStruct) UnmarshalFlatfile(data []byte) error {
+	// NestedData `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData52 := data[0:min(1, len(data))]
+		if v.NestedData == nil {
+			v.NestedData = new(nestedPointerData)
+		}
+		if err := (*v.NestedData).UnmarshalFlatfile(fieldData52); err != nil {
+			return DecodeError("NestedData", fieldData52, err)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v nestedPointerStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// NestedData `flatfile:"1,1"`
+	if v.NestedData != nil {
+		if record53, err := (*v.NestedData).MarshalFlatfile(); err != nil {
+			return nil, fmt.Errorf("nestedPointerStruct.NestedData: %w", err)
+		} else if len(record53) > len(data[0:1]) {
+			err := ErrValueTooLong
+			return nil, fmt.Errorf("nestedPointerStruct.NestedData: %w", err)
+		} else {
+			copy(data[0:1], record53)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint8Struct) UnmarshalFlatfile(data []byte) error {
+	// Uint8One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData54 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData54, 8); err != nil {
+			return DecodeError("Uint8One", fieldData54, err)
+		} else {
+			v.Uint8One = uint8(n)
+		}
+	}
+	// Uint8Two `flatfile:"2,3"`
+	if len(data) > 1 {
+		fieldData55 := data[1:min(4, len(data))]
+		if len(fieldData55) < 3 {
+			err := ErrCutShort
+			return DecodeError("Uint8Two", fieldData55, err)
+		}
+		if n, err := ParseUint(fieldData55, 8); err != nil {
+			return DecodeError("Uint8Two", fieldData55, err)
+		} else {
+			v.Uint8Two = uint8(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint8Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 4)
+	// Uint8One `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint8One)); err != nil {
+		return nil, fmt.Errorf("uint8Struct.Uint8One: %w", err)
+	}
+	// Uint8Two `flatfile:"2,3"`
+	if err := EncodeUint(data[1:4], uint64(v.Uint8Two)); err != nil {
+		return nil, fmt.Errorf("uint8Struct.Uint8Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint8SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint8One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData56 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData56, 8); err != nil {
+			return DecodeError("Uint8One", fieldData56, err)
+		} else {
+			v.Uint8One = uint8(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint8SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Uint8One `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint8One)); err != nil {
+		return nil, fmt.Errorf("uint8SyntaxStruct.Uint8One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint8RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint8One `flatfile:"1,4"`
+	if len(data) > 0 {
+		fieldData57 := data[0:min(4, len(data))]
+		if len(fieldData57) < 4 {
+			err := ErrCutShort
+			return DecodeError("Uint8One", fieldData57, err)
+		}
+		if n, err := ParseUint(fieldData57, 8); err != nil {
+			return DecodeError("Uint8One", fieldData57, err)
+		} else {
+			v.Uint8One = uint8(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint8RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 4)
+	// Uint8One `flatfile:"1,4"`
+	if err := EncodeUint(data[0:4], uint64(v.Uint8One)); err != nil {
+		return nil, fmt.Errorf("uint8RangeStruct.Uint8One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint16Struct) UnmarshalFlatfile(data []byte) error {
+	// Uint16One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData58 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData58, 16); err != nil {
+			return DecodeError("Uint16One", fieldData58, err)
+		} else {
+			v.Uint16One = uint16(n)
+		}
+	}
+	// Uint16Two `flatfile:"2,5"`
+	if len(data) > 1 {
+		fieldData59 := data[1:min(6, len(data))]
+		if len(fieldData59) < 5 {
+			err := ErrCutShort
+			return DecodeError("Uint16Two", fieldData59, err)
+		}
+		if n, err := ParseUint(fieldData59, 16); err != nil {
+			return DecodeError("Uint16Two", fieldData59, err)
+		} else {
+			v.Uint16Two = uint16(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint16Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 6)
+	// Uint16One `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint16One)); err != nil {
+		return nil, fmt.Errorf("uint16Struct.Uint16One: %w", err)
+	}
+	// Uint16Two `flatfile:"2,5"`
+	if err := EncodeUint(data[1:6], uint64(v.Uint16Two)); err != nil {
+		return nil, fmt.Errorf("uint16Struct.Uint16Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint16SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint16One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData60 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData60, 16); err != nil {
+			return DecodeError("Uint16One", fieldData60, err)
+		} else {
+			v.Uint16One = uint16(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint16SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Uint16One `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint16One)); err != nil {
+		return nil, fmt.Errorf("uint16SyntaxStruct.Uint16One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint16RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint16One `flatfile:"1,5"`
+	if len(data) > 0 {
+		fieldData61 := data[0:min(5, len(data))]
+		if len(fieldData61) < 5 {
+			err := ErrCutShort
+			return DecodeError("Uint16One", fieldData61, err)
+		}
+		if n, err := ParseUint(fieldData61, 16); err != nil {
+			return DecodeError("Uint16One", fieldData61, err)
+		} else {
+			v.Uint16One = uint16(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint16RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 5)
+	// Uint16One `flatfile:"1,5"`
+	if err := EncodeUint(data[0:5], uint64(v.Uint16One)); err != nil {
+		return nil, fmt.Errorf("uint16RangeStruct.Uint16One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint32Struct) UnmarshalFlatfile(data []byte) error {
+	// Uint32One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData62 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData62, 32); err != nil {
+			return DecodeError("Uint32One", fieldData62, err)
+		} else {
+			v.Uint32One = uint32(n)
+		}
+	}
+	// Uint32Two `flatfile:"2,10"`
+	if len(data) > 1 {
+		fieldData63 := data[1:min(11, len(data))]
+		if len(fieldData63) < 10 {
+			err := ErrCutShort
+			return DecodeError("Uint32Two", fieldData63, err)
+		}
+		if n, err := ParseUint(fieldData63, 32); err != nil {
+			return DecodeError("Uint32Two", fieldData63, err)
+		} else {
+			v.Uint32Two = uint32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint32Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 11)
+	// Uint32One `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint32One)); err != nil {
+		return nil, fmt.Errorf("uint32Struct.Uint32One: %w", err)
+	}
+	// Uint32Two `flatfile:"2,10"`
+	if err := EncodeUint(data[1:11], uint64(v.Uint32Two)); err != nil {
+		return nil, fmt.Errorf("uint32Struct.Uint32Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint32SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint32One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData64 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData64, 32); err != nil {
+			return DecodeError("Uint32One", fieldData64, err)
+		} else {
+			v.Uint32One = uint32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint32SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Uint32One `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint32One)); err != nil {
+		return nil, fmt.Errorf("uint32SyntaxStruct.Uint32One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint32RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint32One `flatfile:"1,10"`
+	if len(data) > 0 {
+		fieldData65 := data[0:min(10, len(data))]
+		if len(fieldData65) < 10 {
+			err := ErrCutShort
+			return DecodeError("Uint32One", fieldData65, err)
+		}
+		if n, err := ParseUint(fieldData65, 32); err != nil {
+			return DecodeError("Uint32One", fieldData65, err)
+		} else {
+			v.Uint32One = uint32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint32RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 10)
+	// Uint32One `flatfile:"1,10"`
+	if err := EncodeUint(data[0:10], uint64(v.Uint32One)); err != nil {
+		return nil, fmt.Errorf("uint32RangeStruct.Uint32One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint64Struct) UnmarshalFlatfile(data []byte) error {
+	// Uint64One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData66 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData66, 64); err != nil {
+			return DecodeError("Uint64One", fieldData66, err)
+		} else {
+			v.Uint64One = uint64(n)
+		}
+	}
+	// Uint64Two `flatfile:"2,20"`
+	if len(data) > 1 {
+		fieldData67 := data[1:min(21, len(data))]
+		if len(fieldData67) < 20 {
+			err := ErrCutShort
+			return DecodeError("Uint64Two", fieldData67, err)
+		}
+		if n, err := ParseUint(fieldData67, 64); err != nil {
+			return DecodeError("Uint64Two", fieldData67, err)
+		} else {
+			v.Uint64Two = uint64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint64Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 21)
+	// Uint64One `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint64One)); err != nil {
+		return nil, fmt.Errorf("uint64Struct.Uint64One: %w", err)
+	}
+	// Uint64Two `flatfile:"2,20"`
+	if err := EncodeUint(data[1:21], uint64(v.Uint64Two)); err != nil {
+		return nil, fmt.Errorf("uint64Struct.Uint64Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint64SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint64One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData68 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData68, 64); err != nil {
+			return DecodeError("Uint64One", fieldData68, err)
+		} else {
+			v.Uint64One = uint64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint64SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Uint64One `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint64One)); err != nil {
+		return nil, fmt.Errorf("uint64SyntaxStruct.Uint64One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uint64RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint64One `flatfile:"1,20"`
+	if len(data) > 0 {
+		fieldData69 := data[0:min(20, len(data))]
+		if len(fieldData69) < 20 {
+			err := ErrCutShort
+			return DecodeError("Uint64One", fieldData69, err)
+		}
+		if n, err := ParseUint(fieldData69, 64); err != nil {
+			return DecodeError("Uint64One", fieldData69, err)
+		} else {
+			v.Uint64One = uint64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uint64RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 20)
+	// Uint64One `flatfile:"1,20"`
+	if err := EncodeUint(data[0:20], uint64(v.Uint64One)); err != nil {
+		return nil, fmt.Errorf("uint64RangeStruct.Uint64One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int8Struct) UnmarshalFlatfile(data []byte) error {
+	// Int8One `flatfile:"1,4"`
+	if len(data) > 0 {
+		fieldData70 := data[0:min(4, len(data))]
+		if len(fieldData70) < 4 {
+			err := ErrCutShort
+			return DecodeError("Int8One", fieldData70, err)
+		}
+		if n, err := ParseInt(fieldData70, 8); err != nil {
+			return DecodeError("Int8One", fieldData70, err)
+		} else {
+			v.Int8One = int8(n)
+		}
+	}
+	// Int8Two `flatfile:"5,3"`
+	if len(data) > 4 {
+		fieldData71 := data[4:min(7, len(data))]
+		if len(fieldData71) < 3 {
+			err := ErrCutShort
+			return DecodeError("Int8Two", fieldData71, err)
+		}
+		if n, err := ParseInt(fieldData71, 8); err != nil {
+			return DecodeError("Int8Two", fieldData71, err)
+		} else {
+			v.Int8Two = int8(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int8Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 7)
+	// Int8One `flatfile:"1,4"`
+	if err := EncodeInt(data[0:4], int64(v.Int8One)); err != nil {
+		return nil, fmt.Errorf("int8Struct.Int8One: %w", err)
+	}
+	// Int8Two `flatfile:"5,3"`
+	if err := EncodeInt(data[4:7], int64(v.Int8Two)); err != nil {
+		return nil, fmt.Errorf("int8Struct.Int8Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int8SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Int8One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData72 := data[0:min(1, len(data))]
+		if n, err := ParseInt(fieldData72, 8); err != nil {
+			return DecodeError("Int8One", fieldData72, err)
+		} else {
+			v.Int8One = int8(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int8SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Int8One `flatfile:"1,1"`
+	if err := EncodeInt(data[0:1], int64(v.Int8One)); err != nil {
+		return nil, fmt.Errorf("int8SyntaxStruct.Int8One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int8RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Int8One `flatfile:"1,4"`
+	if len(data) > 0 {
+		fieldData73 := data[0:min(4, len(data))]
+		if len(fieldData73) < 4 {
+			err := ErrCutShort
+			return DecodeError("Int8One", fieldData73, err)
+		}
+		if n, err := ParseInt(fieldData73, 8); err != nil {
+			return DecodeError("Int8One", fieldData73, err)
+		} else {
+			v.Int8One = int8(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int8RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 4)
+	// Int8One `flatfile:"1,4"`
+	if err := EncodeInt(data[0:4], int64(v.Int8One)); err != nil {
+		return nil, fmt.Errorf("int8RangeStruct.Int8One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int16Struct) UnmarshalFlatfile(data []byte) error {
+	// Int16One `flatfile:"1,6"`
+	if len(data) > 0 {
+		fieldData74 := data[0:min(6, len(data))]
+		if len(fieldData74) < 6 {
+			err := ErrCutShort
+			return DecodeError("Int16One", fieldData74, err)
+		}
+		if n, err := ParseInt(fieldData74, 16); err != nil {
+			return DecodeError("Int16One", fieldData74, err)
+		} else {
+			v.Int16One = int16(n)
+		}
+	}
+	// Int16Two `flatfile:"7,5"`
+	if len(data) > 6 {
+		fieldData75 := data[6:min(11, len(data))]
+		if len(fieldData75) < 5 {
+			err := ErrCutShort
+			return DecodeError("Int16Two", fieldData75, err)
+		}
+		if n, err := ParseInt(fieldData75, 16); err != nil {
+			return DecodeError("Int16Two", fieldData75, err)
+		} else {
+			v.Int16Two = int16(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int16Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 11)
+	// Int16One `flatfile:"1,6"`
+	if err := EncodeInt(data[0:6], int64(v.Int16One)); err != nil {
+		return nil, fmt.Errorf("int16Struct.Int16One: %w", err)
+	}
+	// Int16Two `flatfile:"7,5"`
+	if err := EncodeInt(data[6:11], int64(v.Int16Two)); err != nil {
+		return nil, fmt.Errorf("int16Struct.Int16Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int16SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Int16One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData76 := data[0:min(1, len(data))]
+		if n, err := ParseInt(fieldData76, 16); err != nil {
+			return DecodeError("Int16One", fieldData76, err)
+		} else {
+			v.Int16One = int16(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int16SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Int16One `flatfile:"1,1"`
+	if err := EncodeInt(data[0:1], int64(v.Int16One)); err != nil {
+		return nil, fmt.Errorf("int16SyntaxStruct.Int16One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int16RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Int16One `flatfile:"1,5"`
+	if len(data) > 0 {
+		fieldData77 := data[0:min(5, len(data))]
+		if len(fieldData77) < 5 {
+			err := ErrCutShort
+			return DecodeError("Int16One", fieldData77, err)
+		}
+		if n, err := ParseInt(fieldData77, 16); err != nil {
+			return DecodeError("Int16One", fieldData77, err)
+		} else {
+			v.Int16One = int16(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int16RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 5)
+	// Int16One `flatfile:"1,5"`
+	if err := EncodeInt(data[0:5], int64(v.Int16One)); err != nil {
+		return nil, fmt.Errorf("int16RangeStruct.Int16One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int32Struct) UnmarshalFlatfile(data []byte) error {
+	// Int32One `flatfile:"1,11"`
+	if len(data) > 0 {
+		fieldData78 := data[0:min(11, len(data))]
+		if len(fieldData78) < 11 {
+			err := ErrCutShort
+			return DecodeError("Int32One", fieldData78, err)
+		}
+		if n, err := ParseInt(fieldData78, 32); err != nil {
+			return DecodeError("Int32One", fieldData78, err)
+		} else {
+			v.Int32One = int32(n)
+		}
+	}
+	// Int32Two `flatfile:"12,10"`
+	if len(data) > 11 {
+		fieldData79 := data[11:min(21, len(data))]
+		if len(fieldData79) < 10 {
+			err := ErrCutShort
+			return DecodeError("Int32Two", fieldData79, err)
+		}
+		if n, err := ParseInt(fieldData79, 32); err != nil {
+			return DecodeError("Int32Two", fieldData79, err)
+		} else {
+			v.Int32Two = int32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int32Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 21)
+	// Int32One `flatfile:"1,11"`
+	if err := EncodeInt(data[0:11], int64(v.Int32One)); err != nil {
+		return nil, fmt.Errorf("int32Struct.Int32One: %w", err)
+	}
+	// Int32Two `flatfile:"12,10"`
+	if err := EncodeInt(data[11:21], int64(v.Int32Two)); err != nil {
+		return nil, fmt.Errorf("int32Struct.Int32Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int32SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Int32One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData80 := data[0:min(1, len(data))]
+		if n, err := ParseInt(fieldData80, 32); err != nil {
+			return DecodeError("Int32One", fieldData80, err)
+		} else {
+			v.Int32One = int32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int32SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Int32One `flatfile:"1,1"`
+	if err := EncodeInt(data[0:1], int64(v.Int32One)); err != nil {
+		return nil, fmt.Errorf("int32SyntaxStruct.Int32One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int32RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Int32One `flatfile:"1,10"`
+	if len(data) > 0 {
+		fieldData81 := data[0:min(10, len(data))]
+		if len(fieldData81) < 10 {
+			err := ErrCutShort
+			return DecodeError("Int32One", fieldData81, err)
+		}
+		if n, err := ParseInt(fieldData81, 32); err != nil {
+			return DecodeError("Int32One", fieldData81, err)
+		} else {
+			v.Int32One = int32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int32RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 10)
+	// Int32One `flatfile:"1,10"`
+	if err := EncodeInt(data[0:10], int64(v.Int32One)); err != nil {
+		return nil, fmt.Errorf("int32RangeStruct.Int32One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int64Struct) UnmarshalFlatfile(data []byte) error {
+	// Int64One `flatfile:"1,20"`
+	if len(data) > 0 {
+		fieldData82 := data[0:min(20, len(data))]
+		if len(fieldData82) < 20 {
+			err := ErrCutShort
+			return DecodeError("Int64One", fieldData82, err)
+		}
+		if n, err := ParseInt(fieldData82, 64); err != nil {
+			return DecodeError("Int64One", fieldData82, err)
+		} else {
+			v.Int64One = int64(n)
+		}
+	}
+	// Int64Two `flatfile:"21,19"`
+	if len(data) > 20 {
+		fieldData83 := data[20:min(39, len(data))]
+		if len(fieldData83) < 19 {
+			err := ErrCutShort
+			return DecodeError("Int64Two", fieldData83, err)
+		}
+		if n, err := ParseInt(fieldData83, 64); err != nil {
+			return DecodeError("Int64Two", fieldData83, err)
+		} else {
+			v.Int64Two = int64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int64Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 39)
+	// Int64One `flatfile:"1,20"`
+	if err := EncodeInt(data[0:20], int64(v.Int64One)); err != nil {
+		return nil, fmt.Errorf("int64Struct.Int64One: %w", err)
+	}
+	// Int64Two `flatfile:"21,19"`
+	if err := EncodeInt(data[20:39], int64(v.Int64Two)); err != nil {
+		return nil, fmt.Errorf("int64Struct.Int64Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int64SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Int64One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData84 := data[0:min(1, len(data))]
+		if n, err := ParseInt(fieldData84, 64); err != nil {
+			return DecodeError("Int64One", fieldData84, err)
+		} else {
+			v.Int64One = int64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int64SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Int64One `flatfile:"1,1"`
+	if err := EncodeInt(data[0:1], int64(v.Int64One)); err != nil {
+		return nil, fmt.Errorf("int64SyntaxStruct.Int64One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *int64RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Int64One `flatfile:"1,19"`
+	if len(data) > 0 {
+		fieldData85 := data[0:min(19, len(data))]
+		if len(fieldData85) < 19 {
+			err := ErrCutShort
+			return DecodeError("Int64One", fieldData85, err)
+		}
+		if n, err := ParseInt(fieldData85, 64); err != nil {
+			return DecodeError("Int64One", fieldData85, err)
+		} else {
+			v.Int64One = int64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v int64RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 19)
+	// Int64One `flatfile:"1,19"`
+	if err := EncodeInt(data[0:19], int64(v.Int64One)); err != nil {
+		return nil, fmt.Errorf("int64RangeStruct.Int64One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *float32Struct) UnmarshalFlatfile(data []byte) error {
+	// Float32One `flatfile:"1,22"`
+	if len(data) > 0 {
+		fieldData86 := data[0:min(22, len(data))]
+		if len(fieldData86) < 22 {
+			err := ErrCutShort
+			return DecodeError("Float32One", fieldData86, err)
+		}
+		if n, err := ParseFloat(fieldData86, 32); err != nil {
+			return DecodeError("Float32One", fieldData86, err)
+		} else {
+			v.Float32One = float32(n)
+		}
+	}
+	// Float32Two `flatfile:"23,21"`
+	if len(data) > 22 {
+		fieldData87 := data[22:min(43, len(data))]
+		if len(fieldData87) < 21 {
+			err := ErrCutShort
+			return DecodeError("Float32Two", fieldData87, err)
+		}
+		if n, err := ParseFloat(fieldData87, 32); err != nil {
+			return DecodeError("Float32Two", fieldData87, err)
+		} else {
+			v.Float32Two = float32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v float32Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 43)
+	// Float32One `flatfile:"1,22"`
+	if err := EncodeFloat(data[0:22], float64(v.Float32One), 32); err != nil {
+		return nil, fmt.Errorf("float32Struct.Float32One: %w", err)
+	}
+	// Float32Two `flatfile:"23,21"`
+	if err := EncodeFloat(data[22:43], float64(v.Float32Two), 32); err != nil {
+		return nil, fmt.Errorf("float32Struct.Float32Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *float32SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Float32One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData88 := data[0:min(1, len(data))]
+		if n, err := ParseFloat(fieldData88, 32); err != nil {
+			return DecodeError("Float32One", fieldData88, err)
+		} else {
+			v.Float32One = float32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v float32SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Float32One `flatfile:"1,1"`
+	if err := EncodeFloat(data[0:1], float64(v.Float32One), 32); err != nil {
+		return nil, fmt.Errorf("float32SyntaxStruct.Float32One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *float32RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Float32One `flatfile:"1,40"`
+	if len(data) > 0 {
+		fieldData89 := data[0:min(40, len(data))]
+		if len(fieldData89) < 40 {
+			err := ErrCutShort
+			return DecodeError("Float32One", fieldData89, err)
+		}
+		if n, err := ParseFloat(fieldData89, 32); err != nil {
+			return DecodeError("Float32One", fieldData89, err)
+		} else {
+			v.Float32One = float32(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v float32RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 40)
+	// Float32One `flatfile:"1,40"`
+	if err := EncodeFloat(data[0:40], float64(v.Float32One), 32); err != nil {
+		return nil, fmt.Errorf("float32RangeStruct.Float32One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *float64Struct) UnmarshalFlatfile(data []byte) error {
+	// Float64One `flatfile:"1,23"`
+	if len(data) > 0 {
+		fieldData90 := data[0:min(23, len(data))]
+		if len(fieldData90) < 23 {
+			err := ErrCutShort
+			return DecodeError("Float64One", fieldData90, err)
+		}
+		if n, err := ParseFloat(fieldData90, 64); err != nil {
+			return DecodeError("Float64One", fieldData90, err)
+		} else {
+			v.Float64One = float64(n)
+		}
+	}
+	// Float64Two `flatfile:"24,6"`
+	if len(data) > 23 {
+		fieldData91 := data[23:min(29, len(data))]
+		if len(fieldData91) < 6 {
+			err := ErrCutShort
+			return DecodeError("Float64Two", fieldData91, err)
+		}
+		if n, err := ParseFloat(fieldData91, 64); err != nil {
+			return DecodeError("Float64Two", fieldData91, err)
+		} else {
+			v.Float64Two = float64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v float64Struct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 29)
+	// Float64One `flatfile:"1,23"`
+	if err := EncodeFloat(data[0:23], float64(v.Float64One), 64); err != nil {
+		return nil, fmt.Errorf("float64Struct.Float64One: %w", err)
+	}
+	// Float64Two `flatfile:"24,6"`
+	if err := EncodeFloat(data[23:29], float64(v.Float64Two), 64); err != nil {
+		return nil, fmt.Errorf("float64Struct.Float64Two: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *float64SyntaxStruct) UnmarshalFlatfile(data []byte) error {
+	// Float64One `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData92 := data[0:min(1, len(data))]
+		if n, err := ParseFloat(fieldData92, 64); err != nil {
+			return DecodeError("Float64One", fieldData92, err)
+		} else {
+			v.Float64One = float64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v float64SyntaxStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 1)
+	// Float64One `flatfile:"1,1"`
+	if err := EncodeFloat(data[0:1], float64(v.Float64One), 64); err != nil {
+		return nil, fmt.Errorf("float64SyntaxStruct.Float64One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *float64RangeStruct) UnmarshalFlatfile(data []byte) error {
+	// Float64One `flatfile:"1,23"`
+	if len(data) > 0 {
+		fieldData93 := data[0:min(23, len(data))]
+		if len(fieldData93) < 23 {
+			err := ErrCutShort
+			return DecodeError("Float64One", fieldData93, err)
+		}
+		if n, err := ParseFloat(fieldData93, 64); err != nil {
+			return DecodeError("Float64One", fieldData93, err)
+		} else {
+			v.Float64One = float64(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v float64RangeStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 23)
+	// Float64One `flatfile:"1,23"`
+	if err := EncodeFloat(data[0:23], float64(v.Float64One), 64); err != nil {
+		return nil, fmt.Errorf("float64RangeStruct.Float64One: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *uintStruct) UnmarshalFlatfile(data []byte) error {
+	// Uint8val `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData94 := data[0:min(1, len(data))]
+		if n, err := ParseUint(fieldData94, 0); err != nil {
+			return DecodeError("Uint8val", fieldData94, err)
+		} else {
+			v.Uint8val = uint(n)
+		}
+	}
+	// Uint16val `flatfile:"2,5"`
+	if len(data) > 1 {
+		fieldData95 := data[1:min(6, len(data))]
+		if len(fieldData95) < 5 {
+			err := ErrCutShort
+			return DecodeError("Uint16val", fieldData95, err)
+		}
+		if n, err := ParseUint(fieldData95, 0); err != nil {
+			return DecodeError("Uint16val", fieldData95, err)
+		} else {
+			v.Uint16val = uint(n)
+		}
+	}
+	// Uint32val `flatfile:"2,10"`
+	if len(data) > 1 {
+		fieldData96 := data[1:min(11, len(data))]
+		if len(fieldData96) < 10 {
+			err := ErrCutShort
+			return DecodeError("Uint32val", fieldData96, err)
+		}
+		if n, err := ParseUint(fieldData96, 0); err != nil {
+			return DecodeError("Uint32val", fieldData96, err)
+		} else {
+			v.Uint32val = uint(n)
+		}
+	}
+	// Uint64val `flatfile:"2,20"`
+	if len(data) > 1 {
+		fieldData97 := data[1:min(21, len(data))]
+		if len(fieldData97) < 20 {
+			err := ErrCutShort
+			return DecodeError("Uint64val", fieldData97, err)
+		}
+		if n, err := ParseUint(fieldData97, 0); err != nil {
+			return DecodeError("Uint64val", fieldData97, err)
+		} else {
+			v.Uint64val = uint(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v uintStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 21)
+	// Uint8val `flatfile:"1,1"`
+	if err := EncodeUint(data[0:1], uint64(v.Uint8val)); err != nil {
+		return nil, fmt.Errorf("uintStruct.Uint8val: %w", err)
+	}
+	// Uint16val `flatfile:"2,5"`
+	if err := EncodeUint(data[1:6], uint64(v.Uint16val)); err != nil {
+		return nil, fmt.Errorf("uintStruct.Uint16val: %w", err)
+	}
+	// Uint32val `flatfile:"2,10"`
+	if err := EncodeUint(data[1:11], uint64(v.Uint32val)); err != nil {
+		return nil, fmt.Errorf("uintStruct.Uint32val: %w", err)
+	}
+	// Uint64val `flatfile:"2,20"`
+	if err := EncodeUint(data[1:21], uint64(v.Uint64val)); err != nil {
+		return nil, fmt.Errorf("uintStruct.Uint64val: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *intStruct) UnmarshalFlatfile(data []byte) error {
+	// Int8val `flatfile:"1,1"`
+	if len(data) > 0 {
+		fieldData98 := data[0:min(1, len(data))]
+		if n, err := ParseInt(fieldData98, 0); err != nil {
+			return DecodeError("Int8val", fieldData98, err)
+		} else {
+			v.Int8val = int(n)
+		}
+	}
+	// Int16val `flatfile:"2,5"`
+	if len(data) > 1 {
+		fieldData99 := data[1:min(6, len(data))]
+		if len(fieldData99) < 5 {
+			err := ErrCutShort
+			return DecodeError("Int16val", fieldData99, err)
+		}
+		if n, err := ParseInt(fieldData99, 0); err != nil {
+			return DecodeError("Int16val", fieldData99, err)
+		} else {
+			v.Int16val = int(n)
+		}
+	}
+	// Int32val `flatfile:"2,9"`
+	if len(data) > 1 {
+		fieldData100 := data[1:min(10, len(data))]
+		if len(fieldData100) < 9 {
+			err := ErrCutShort
+			return DecodeError("Int32val", fieldData100, err)
+		}
+		if n, err := ParseInt(fieldData100, 0); err != nil {
+			return DecodeError("Int32val", fieldData100, err)
+		} else {
+			v.Int32val = int(n)
+		}
+	}
+	// Int64val `flatfile:"1,19"`
+	if len(data) > 0 {
+		fieldData101 := data[0:min(19, len(data))]
+		if len(fieldData101) < 19 {
+			err := ErrCutShort
+			return DecodeError("Int64val", fieldData101, err)
+		}
+		if n, err := ParseInt(fieldData101, 0); err != nil {
+			return DecodeError("Int64val", fieldData101, err)
+		} else {
+			v.Int64val = int(n)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v intStruct) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 19)
+	// Int8val `flatfile:"1,1"`
+	if err := EncodeInt(data[0:1], int64(v.Int8val)); err != nil {
+		return nil, fmt.Errorf("intStruct.Int8val: %w", err)
+	}
+	// Int16val `flatfile:"2,5"`
+	if err := EncodeInt(data[1:6], int64(v.Int16val)); err != nil {
+		return nil, fmt.Errorf("intStruct.Int16val: %w", err)
+	}
+	// Int32val `flatfile:"2,9"`
+	if err := EncodeInt(data[1:10], int64(v.Int32val)); err != nil {
+		return nil, fmt.Errorf("intStruct.Int32val: %w", err)
+	}
+	// Int64val `flatfile:"1,19"`
+	if err := EncodeInt(data[0:19], int64(v.Int64val)); err != nil {
+		return nil, fmt.Errorf("intStruct.Int64val: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *numericRecord) UnmarshalFlatfile(data []byte) error {
+	// Int `flatfile:"1,5"`
+	if len(data) > 0 {
+		fieldData102 := data[0:min(5, len(data))]
+		if len(fieldData102) < 5 {
+			err := ErrCutShort
+			return DecodeError("Int", fieldData102, err)
+		}
+		if n, err := ParseInt(fieldData102, 0); err != nil {
+			return DecodeError("Int", fieldData102, err)
+		} else {
+			v.Int = int(n)
+		}
+	}
+	// Int8 `flatfile:"6,4"`
+	if len(data) > 5 {
+		fieldData103 := data[5:min(9, len(data))]
+		if len(fieldData103) < 4 {
+			err := ErrCutShort
+			return DecodeError("Int8", fieldData103, err)
+		}
+		if n, err := ParseInt(fieldData103, 8); err != nil {
+			return DecodeError("Int8", fieldData103, err)
+		} else {
+			v.Int8 = int8(n)
+		}
+	}
+	// Int16 `flatfile:"10,6"`
+	if len(data) > 9 {
+		fieldData104 := data[9:min(15, len(data))]
+		if len(fieldData104) < 6 {
+			err := ErrCutShort
+			return DecodeError("Int16", fieldData104, err)
+		}
+		if n, err := ParseInt(fieldData104, 16); err != nil {
+			return DecodeError("Int16", fieldData104, err)
+		} else {
+			v.Int16 = int16(n)
+		}
+	}
+	// Int32 `flatfile:"16,11"`
+	if len(data) > 15 {
+		fieldData105 := data[15:min(26, len(data))]
+		if len(fieldData105) < 11 {
+			err := ErrCutShort
+			return DecodeError("Int32", fieldData105, err)
+		}
+		if n, err := ParseInt(fieldData105, 32); err != nil {
+			return DecodeError("Int32", fieldData105, err)
+		} else {
+			v.Int32 = int32(n)
+		}
+	}
+	// Int64 `flatfile:"27,20"`
+	if len(data) > 26 {
+		fieldData106 := data[26:min(46, len(data))]
+		if len(fieldData106) < 20 {
+			err := ErrCutShort
+			return DecodeError("Int64", fieldData106, err)
+		}
+		if n, err := ParseInt(fieldData106, 64); err != nil {
+			return DecodeError("Int64", fieldData106, err)
+		} else {
+			v.Int64 = int64(n)
+		}
+	}
+	// Uint `flatfile:"47,5"`
+	if len(data) > 46 {
+		fieldData107 := data[46:min(51, len(data))]
+		if len(fieldData107) < 5 {
+			err := ErrCutShort
+			return DecodeError("Uint", fieldData107, err)
+		}
+		if n, err := ParseUint(fieldData107, 0); err != nil {
+			return DecodeError("Uint", fieldData107, err)
+		} else {
+			v.Uint = uint(n)
+		}
+	}
+	// Uint8 `flatfile:"52,3"`
+	if len(data) > 51 {
+		fieldData108 := data[51:min(54, len(data))]
+		if len(fieldData108) < 3 {
+			err := ErrCutShort
+			return DecodeError("Uint8", fieldData108, err)
+		}
+		if n, err := ParseUint(fieldData108, 8); err != nil {
+			return DecodeError("Uint8", fieldData108, err)
+		} else {
+			v.Uint8 = uint8(n)
+		}
+	}
+	// Uint64 `flatfile:"55,20"`
+	if len(data) > 54 {
+		fieldData109 := data[54:min(74, len(data))]
+		if len(fieldData109) < 20 {
+			err := ErrCutShort
+			return DecodeError("Uint64", fieldData109, err)
+		}
+		if n, err := ParseUint(fieldData109, 64); err != nil {
+			return DecodeError("Uint64", fieldData109, err)
+		} else {
+			v.Uint64 = uint64(n)
+		}
+	}
+	// Float32 `flatfile:"75,8"`
+	if len(data) > 74 {
+		fieldData110 := data[74:min(82, len(data))]
+		if len(fieldData110) < 8 {
+			err := ErrCutShort
+			return DecodeError("Float32", fieldData110, err)
+		}
+		if n, err := ParseFloat(fieldData110, 32); err != nil {
+			return DecodeError("Float32", fieldData110, err)
+		} else {
+			v.Float32 = float32(n)
+		}
+	}
+	// Float64 `flatfile:"83,12"`
+	if len(data) > 82 {
+		fieldData111 := data[82:min(94, len(data))]
+		if len(fieldData111) < 12 {
+			err := ErrCutShort
+			return DecodeError("Float64", fieldData111, err)
+		}
+		if n, err := ParseFloat(fieldData111, 64); err != nil {
+			return DecodeError("Float64", fieldData111, err)
+		} else {
+			v.Float64 = float64(n)
+		}
+	}
+	// Bool `flatfile:"95,5"`
+	if len(data) > 94 {
+		fieldData112 := data[94:min(99, len(data))]
+		if len(fieldData112) < 5 {
+			err := ErrCutShort
+			return DecodeError("Bool", fieldData112, err)
+		}
+		if b, err := ParseBool(fieldData112); err != nil {
+			return DecodeError("Bool", fieldData112, err)
+		} else {
+			v.Bool = bool(b)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v numericRecord) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 99)
+	// Int `flatfile:"1,5"`
+	if err := EncodeInt(data[0:5], int64(v.Int)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Int: %w", err)
+	}
+	// Int8 `flatfile:"6,4"`
+	if err := EncodeInt(data[5:9], int64(v.Int8)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Int8: %w", err)
+	}
+	// Int16 `flatfile:"10,6"`
+	if err := EncodeInt(data[9:15], int64(v.Int16)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Int16: %w", err)
+	}
+	// Int32 `flatfile:"16,11"`
+	if err := EncodeInt(data[15:26], int64(v.Int32)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Int32: %w", err)
+	}
+	// Int64 `flatfile:"27,20"`
+	if err := EncodeInt(data[26:46], int64(v.Int64)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Int64: %w", err)
+	}
+	// Uint `flatfile:"47,5"`
+	if err := EncodeUint(data[46:51], uint64(v.Uint)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Uint: %w", err)
+	}
+	// Uint8 `flatfile:"52,3"`
+	if err := EncodeUint(data[51:54], uint64(v.Uint8)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Uint8: %w", err)
+	}
+	// Uint64 `flatfile:"55,20"`
+	if err := EncodeUint(data[54:74], uint64(v.Uint64)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Uint64: %w", err)
+	}
+	// Float32 `flatfile:"75,8"`
+	if err := EncodeFloat(data[74:82], float64(v.Float32), 32); err != nil {
+		return nil, fmt.Errorf("numericRecord.Float32: %w", err)
+	}
+	// Float64 `flatfile:"83,12"`
+	if err := EncodeFloat(data[82:94], float64(v.Float64), 64); err != nil {
+		return nil, fmt.Errorf("numericRecord.Float64: %w", err)
+	}
+	// Bool `flatfile:"95,5"`
+	if err := EncodeBool(data[94:99], bool(v.Bool)); err != nil {
+		return nil, fmt.Errorf("numericRecord.Bool: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *pointerNested) UnmarshalFlatfile(data []byte) error {
+	// Code `flatfile:"1,2"`
+	if len(data) > 0 {
+		fieldData113 := data[0:min(2, len(data))]
+		v.Code = string(fieldData113)
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v pointerNested) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 2)
+	// Code `flatfile:"1,2"`
+	if err := EncodeString(data[0:2], string(v.Code)); err != nil {
+		return nil, fmt.Errorf("pointerNested.Code: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *pointerRecord) UnmarshalFlatfile(data []byte) error {
+	// Count `flatfile:"1,2"`
+	if len(data) > 0 {
+		fieldData114 := data[0:min(2, len(data))]
+		if v.Count == nil {
+			v.Count = new(int)
+		}
+		if len(fieldData114) < 2 {
+			err := ErrCutShort
+			return DecodeError("Count", fieldData114, err)
+		}
+		if n, err := ParseInt(fieldData114, 0); err != nil {
+			return DecodeError("Count", fieldData114, err)
+		} else {
+			(*v.Count) = int(n)
+		}
+	}
+	// Name `flatfile:"3,3"`
+	if len(data) > 2 {
+		fieldData115 := data[2:min(5, len(data))]
+		if v.Name == nil {
+			v.Name = new(*string)
+		}
+		if (*v.Name) == nil {
+			(*v.Name) = new(string)
+		}
+		(*(*v.Name)) = string(fieldData115)
+	}
+	// Nested `flatfile:"6,2"`
+	if len(data) > 5 {
+		fieldData116 := data[5:min(7, len(data))]
+		if v.Nested == nil {
+			v.Nested = new(pointerNested)
+		}
+		if err := (*v.Nested).UnmarshalFlatfile(fieldData116); err != nil {
+			return DecodeError("Nested", fieldData116, err)
+		}
+	}
+	// Scores `flatfile:"8,1"`
+	if len(data) > 7 {
+		fieldData117 := data[7:min(9, len(data))]
+		for i118 := 0; i118 < 2; i118++ {
+			if i118*1 >= len(fieldData117) {
+				break
+			}
+			elemData119 := fieldData117[i118*1 : min((i118+1)*1, len(fieldData117))]
+			if v.Scores[i118] == nil {
+				v.Scores[i118] = new(int)
+			}
+			if n, err := ParseInt(elemData119, 0); err != nil {
+				err = DecodeError(fmt.Sprintf("[%d]", i118), elemData119, err)
+				return DecodeError("Scores", fieldData117, err)
+			} else {
+				(*v.Scores[i118]) = int(n)
+			}
+		}
+	}
+	// Codes `flatfile:"10,1,occurs=2"`
+	if len(data) > 9 {
+		fieldData120 := data[9:min(11, len(data))]
+		v.Codes = make([]*string, 2)
+		for i121 := 0; i121 < 2; i121++ {
+			if i121*1 >= len(fieldData120) {
+				break
+			}
+			elemData122 := fieldData120[i121*1 : min((i121+1)*1, len(fieldData120))]
+			if v.Codes[i121] == nil {
+				v.Codes[i121] = new(string)
+			}
+			(*v.Codes[i121]) = string(elemData122)
+		}
+	}
+	// Optional `flatfile:"12,2,optional"`
+	if len(data) > 11 {
+		fieldData123 := data[11:min(13, len(data))]
+		if len(bytes.TrimLeft(fieldData123, " ")) == 0 {
+			v.Optional = nil
+		} else {
+			if v.Optional == nil {
+				v.Optional = new(pointerNested)
+			}
+			if err := (*v.Optional).UnmarshalFlatfile(fieldData123); err != nil {
+				return DecodeError("Optional", fieldData123, err)
+			}
+		}
+	}
+	// Elements `flatfile:"14,1,occurs=2,optional"`
+	if len(data) > 13 {
+		fieldData124 := data[13:min(15, len(data))]
+		v.Elements = make([]*int, 2)
+		for i125 := 0; i125 < 2; i125++ {
+			if i125*1 >= len(fieldData124) {
+				break
+			}
+			elemData126 := fieldData124[i125*1 : min((i125+1)*1, len(fieldData124))]
+			if len(bytes.TrimLeft(elemData126, " ")) == 0 {
+				v.Elements[i125] = nil
+			} else {
+				if v.Elements[i125] == nil {
+					v.Elements[i125] = new(int)
+				}
+				if n, err := ParseInt(elemData126, 0); err != nil {
+					err = DecodeError(fmt.Sprintf("[%d]", i125), elemData126, err)
+					return DecodeError("Elements", fieldData124, err)
+				} else {
+					(*v.Elements[i125]) = int(n)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v pointerRecord) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 15)
+	// Count `flatfile:"1,2"`
+	if v.Count != nil {
+		if err := EncodeInt(data[0:2], int64((*v.Count))); err != nil {
+			return nil, fmt.Errorf("pointerRecord.Count: %w", err)
+		}
+	}
+	// Name `flatfile:"3,3"`
+	if v.Name != nil {
+		if (*v.Name) != nil {
+			if err := EncodeString(data[2:5], string((*(*v.Name)))); err != nil {
+				return nil, fmt.Errorf("pointerRecord.Name: %w", err)
+			}
+		}
+	}
+	// Nested `flatfile:"6,2"`
+	if v.Nested != nil {
+		if record127, err := (*v.Nested).MarshalFlatfile(); err != nil {
+			return nil, fmt.Errorf("pointerRecord.Nested: %w", err)
+		} else if len(record127) > len(data[5:7]) {
+			err := ErrValueTooLong
+			return nil, fmt.Errorf("pointerRecord.Nested: %w", err)
+		} else {
+			copy(data[5:7], record127)
+		}
+	}
+	// Scores `flatfile:"8,1"`
+	for i128 := range v.Scores {
+		if v.Scores[i128] != nil {
+			if err := EncodeInt(data[7:9][i128*1:(i128+1)*1], int64((*v.Scores[i128]))); err != nil {
+				return nil, fmt.Errorf("pointerRecord.Scores: %w", err)
+			}
+		}
+	}
+	// Codes `flatfile:"10,1,occurs=2"`
+	if len(v.Codes) > 2 {
+		err := fmt.Errorf("%d elements exceed the 2 occurrences of the field", len(v.Codes))
+		return nil, fmt.Errorf("pointerRecord.Codes: %w", err)
+	}
+	for i129 := range v.Codes {
+		if v.Codes[i129] != nil {
+			if err := EncodeString(data[9:11][i129*1:(i129+1)*1], string((*v.Codes[i129]))); err != nil {
+				return nil, fmt.Errorf("pointerRecord.Codes: %w", err)
+			}
+		}
+	}
+	// Optional `flatfile:"12,2,optional"`
+	if v.Optional != nil {
+		if record130, err := (*v.Optional).MarshalFlatfile(); err != nil {
+			return nil, fmt.Errorf("pointerRecord.Optional: %w", err)
+		} else if len(record130) > len(data[11:13]) {
+			err := ErrValueTooLong
+			return nil, fmt.Errorf("pointerRecord.Optional: %w", err)
+		} else {
+			copy(data[11:13], record130)
+		}
+	}
+	// Elements `flatfile:"14,1,occurs=2,optional"`
+	if len(v.Elements) > 2 {
+		err := fmt.Errorf("%d elements exceed the 2 occurrences of the field", len(v.Elements))
+		return nil, fmt.Errorf("pointerRecord.Elements: %w", err)
+	}
+	for i131 := range v.Elements {
+		if v.Elements[i131] != nil {
+			if err := EncodeInt(data[13:15][i131*1:(i131+1)*1], int64((*v.Elements[i131]))); err != nil {
+				return nil, fmt.Errorf("pointerRecord.Elements: %w", err)
+			}
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *elementLine) UnmarshalFlatfile(data []byte) error {
+	// Amount `flatfile:"1,3"`
+	if len(data) > 0 {
+		fieldData132 := data[0:min(3, len(data))]
+		if len(fieldData132) < 3 {
+			err := ErrCutShort
+			return DecodeError("Amount", fieldData132, err)
+		}
+		if n, err := ParseInt(fieldData132, 0); err != nil {
+			return DecodeError("Amount", fieldData132, err)
+		} else {
+			v.Amount = int(n)
+		}
+	}
+	// Code `flatfile:"4,1"`
+	if len(data) > 3 {
+		fieldData133 := data[3:min(4, len(data))]
+		v.Code = string(fieldData133)
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v elementLine) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 4)
+	// Amount `flatfile:"1,3"`
+	if err := EncodeInt(data[0:3], int64(v.Amount)); err != nil {
+		return nil, fmt.Errorf("elementLine.Amount: %w", err)
+	}
+	// Code `flatfile:"4,1"`
+	if err := EncodeString(data[3:4], string(v.Code)); err != nil {
+		return nil, fmt.Errorf("elementLine.Code: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *elementRecord) UnmarshalFlatfile(data []byte) error {
+	// Name `flatfile:"1,3"`
+	if len(data) > 0 {
+		fieldData134 := data[0:min(3, len(data))]
+		v.Name = string(fieldData134)
+	}
+	// Lines `flatfile:"4,4,occurs=3"`
+	if len(data) > 3 {
+		fieldData135 := data[3:min(15, len(data))]
+		v.Lines = make([]elementLine, 3)
+		for i136 := 0; i136 < 3; i136++ {
+			if i136*4 >= len(fieldData135) {
+				break
+			}
+			elemData137 := fieldData135[i136*4 : min((i136+1)*4, len(fieldData135))]
+			if err := v.Lines[i136].UnmarshalFlatfile(elemData137); err != nil {
+				err = DecodeError(fmt.Sprintf("[%d]", i136), elemData137, err)
+				return DecodeError("Lines", fieldData135, err)
+			}
+		}
+	}
+	// Counts `flatfile:"16,2"`
+	if len(data) > 15 {
+		fieldData138 := data[15:min(19, len(data))]
+		for i139 := 0; i139 < 2; i139++ {
+			if i139*2 >= len(fieldData138) {
+				break
+			}
+			elemData140 := fieldData138[i139*2 : min((i139+1)*2, len(fieldData138))]
+			if len(elemData140) < 2 {
+				err := ErrCutShort
+				err = DecodeError(fmt.Sprintf("[%d]", i139), elemData140, err)
+				return DecodeError("Counts", fieldData138, err)
+			}
+			if n, err := ParseInt(elemData140, 0); err != nil {
+				err = DecodeError(fmt.Sprintf("[%d]", i139), elemData140, err)
+				return DecodeError("Counts", fieldData138, err)
+			} else {
+				v.Counts[i139] = int(n)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v elementRecord) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 19)
+	// Name `flatfile:"1,3"`
+	if err := EncodeString(data[0:3], string(v.Name)); err != nil {
+		return nil, fmt.Errorf("elementRecord.Name: %w", err)
+	}
+	// Lines `flatfile:"4,4,occurs=3"`
+	if len(v.Lines) > 3 {
+		err := fmt.Errorf("%d elements exceed the 3 occurrences of the field", len(v.Lines))
+		return nil, fmt.Errorf("elementRecord.Lines: %w", err)
+	}
+	for i141 := range v.Lines {
+		if record142, err := v.Lines[i141].MarshalFlatfile(); err != nil {
+			return nil, fmt.Errorf("elementRecord.Lines: %w", err)
+		} else if len(record142) > len(data[3:15][i141*4:(i141+1)*4]) {
+			err := ErrValueTooLong
+			return nil, fmt.Errorf("elementRecord.Lines: %w", err)
+		} else {
+			copy(data[3:15][i141*4:(i141+1)*4], record142)
+		}
+	}
+	// Counts `flatfile:"16,2"`
+	for i143 := range v.Counts {
+		if err := EncodeInt(data[15:19][i143*2:(i143+1)*2], int64(v.Counts[i143])); err != nil {
+			return nil, fmt.Errorf("elementRecord.Counts: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalFlatfile decodes data into v without reflection.
+// It is equivalent to Unmarshal(data, v, 0, 0, false).
+func (v *cutShortRecord) UnmarshalFlatfile(data []byte) error {
+	// Code `flatfile:"1,2"`
+	if len(data) > 0 {
+		fieldData144 := data[0:min(2, len(data))]
+		v.Code = string(fieldData144)
+	}
+	// Amount `flatfile:"3,4"`
+	if len(data) > 2 {
+		fieldData145 := data[2:min(6, len(data))]
+		if len(fieldData145) < 4 {
+			err := ErrCutShort
+			return DecodeError("Amount", fieldData145, err)
+		}
+		if n, err := ParseInt(fieldData145, 0); err != nil {
+			return DecodeError("Amount", fieldData145, err)
+		} else {
+			v.Amount = int(n)
+		}
+	}
+	// Line `flatfile:"7,4"`
+	if len(data) > 6 {
+		fieldData146 := data[6:min(10, len(data))]
+		if err := v.Line.UnmarshalFlatfile(fieldData146); err != nil {
+			return DecodeError("Line", fieldData146, err)
+		}
+	}
+	// Names `flatfile:"11,3"`
+	if len(data) > 10 {
+		fieldData147 := data[10:min(16, len(data))]
+		for i148 := 0; i148 < 2; i148++ {
+			if i148*3 >= len(fieldData147) {
+				break
+			}
+			elemData149 := fieldData147[i148*3 : min((i148+1)*3, len(fieldData147))]
+			v.Names[i148] = string(elemData149)
+		}
+	}
+	return nil
+}
+
+// MarshalFlatfile encodes v into a record without reflection.
+// It is equivalent to Marshal(v).
+func (v cutShortRecord) MarshalFlatfile() ([]byte, error) {
+	data := bytes.Repeat([]byte{' '}, 16)
+	// Code `flatfile:"1,2"`
+	if err := EncodeString(data[0:2], string(v.Code)); err != nil {
+		return nil, fmt.Errorf("cutShortRecord.Code: %w", err)
+	}
+	// Amount `flatfile:"3,4"`
+	if err := EncodeInt(data[2:6], int64(v.Amount)); err != nil {
+		return nil, fmt.Errorf("cutShortRecord.Amount: %w", err)
+	}
+	// Line `flatfile:"7,4"`
+	if record150, err := v.Line.MarshalFlatfile(); err != nil {
+		return nil, fmt.Errorf("cutShortRecord.Line: %w", err)
+	} else if len(record150) > len(data[6:10]) {
+		err := ErrValueTooLong
+		return nil, fmt.Errorf("cutShortRecord.Line: %w", err)
+	} else {
+		copy(data[6:10], record150)
+	}
+	// Names `flatfile:"11,3"`
+	for i151 := range v.Names {
+		if err := EncodeString(data[10:16][i151*3:(i151+1)*3], string(v.Names[i151])); err != nil {
+			return nil, fmt.Errorf("cutShortRecord.Names: %w", err)
+		}
+	}
+	return data, nil
+}
@@ -2,12 +2,17 @@ package flatfile
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
 	"testing"
+
+	"github.com/pkg/errors"
 )
 
+//go:generate go run ./cmd/flatfilegen -type boolFalseStruct,boolTrueStruct,boolErrStruct,arrayStruct,arrayName,arrayNestedStruct,sliceStruct,sliceName,sliceNestedStruct,byteStruct,runeStruct,nestedPointerData,nestedPointerStruct,uint8Struct,uint8SyntaxStruct,uint8RangeStruct,uint16Struct,uint16SyntaxStruct,uint16RangeStruct,uint32Struct,uint32SyntaxStruct,uint32RangeStruct,uint64Struct,uint64SyntaxStruct,uint64RangeStruct,int8Struct,int8SyntaxStruct,int8RangeStruct,int16Struct,int16SyntaxStruct,int16RangeStruct,int32Struct,int32SyntaxStruct,int32RangeStruct,int64Struct,int64SyntaxStruct,int64RangeStruct,float32Struct,float32SyntaxStruct,float32RangeStruct,float64Struct,float64SyntaxStruct,float64RangeStruct,uintStruct,intStruct,numericRecord,pointerNested,pointerRecord,elementLine,elementRecord,cutShortRecord -output unmarshal_flatfile_test.go
+
 /*
 uint8  : 0 to 255
 uint16 : 0 to 65535
@@ -19,20 +24,20 @@ int32  : -2147483648 to 2147483647
 int64  : -9223372036854775808 to 9223372036854775807
 */
 
-func TestBoolFalse_Unmarshal(t *testing.T) {
-	type BoolStruct struct {
-		BoolFalse1 bool `flatfile:"1,1"`
-		BoolFalse2 bool `flatfile:"2,1"`
-		BoolFalse3 bool `flatfile:"3,1"`
-		BoolFalse4 bool `flatfile:"4,5"`
-		BoolFalse5 bool `flatfile:"9,5"`
-		BoolFalse6 bool `flatfile:"14,5"`
-	}
+type boolFalseStruct struct {
+	BoolFalse1 bool `flatfile:"1,1"`
+	BoolFalse2 bool `flatfile:"2,1"`
+	BoolFalse3 bool `flatfile:"3,1"`
+	BoolFalse4 bool `flatfile:"4,5"`
+	BoolFalse5 bool `flatfile:"9,5"`
+	BoolFalse6 bool `flatfile:"14,5"`
+}
 
-	testVal := &BoolStruct{}
+func TestBoolFalse_Unmarshal(t *testing.T) {
+	testVal := &boolFalseStruct{}
 	data := []byte("0fFfalseFalseFALSE")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -47,20 +52,21 @@ func TestBoolFalse_Unmarshal(t *testing.T) {
 		}
 	}
 }
-func TestBoolTrue_Unmarshal(t *testing.T) {
-	type BoolStruct struct {
-		BoolTrue1 bool `flatfile:"1,1"`
-		BoolTrue2 bool `flatfile:"2,1"`
-		BoolTrue3 bool `flatfile:"3,1"`
-		BoolTrue4 bool `flatfile:"4,4"`
-		BoolTrue5 bool `flatfile:"8,4"`
-		BoolTrue6 bool `flatfile:"12,4"`
-	}
 
-	testVal := &BoolStruct{}
+type boolTrueStruct struct {
+	BoolTrue1 bool `flatfile:"1,1"`
+	BoolTrue2 bool `flatfile:"2,1"`
+	BoolTrue3 bool `flatfile:"3,1"`
+	BoolTrue4 bool `flatfile:"4,4"`
+	BoolTrue5 bool `flatfile:"8,4"`
+	BoolTrue6 bool `flatfile:"12,4"`
+}
+
+func TestBoolTrue_Unmarshal(t *testing.T) {
+	testVal := &boolTrueStruct{}
 	data := []byte("1tTtrueTrueTRUE")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -75,20 +81,20 @@ func TestBoolTrue_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestBoolErr_Unmarshal(t *testing.T) {
-	type BoolStruct struct {
-		BoolTrue1 bool `flatfile:"1,1"`
-		BoolTrue2 bool `flatfile:"2,1"`
-		BoolTrue3 bool `flatfile:"3,1"`
-		BoolTrue4 bool `flatfile:"4,4"`
-		BoolTrue5 bool `flatfile:"8,4"`
-		BoolTrue6 bool `flatfile:"12,4"`
-	}
+type boolErrStruct struct {
+	BoolTrue1 bool `flatfile:"1,1"`
+	BoolTrue2 bool `flatfile:"2,1"`
+	BoolTrue3 bool `flatfile:"3,1"`
+	BoolTrue4 bool `flatfile:"4,4"`
+	BoolTrue5 bool `flatfile:"8,4"`
+	BoolTrue6 bool `flatfile:"12,4"`
+}
 
-	testVal := &BoolStruct{}
+func TestBoolErr_Unmarshal(t *testing.T) {
+	testVal := &boolErrStruct{}
 	data := []byte("3aBerrrErrrERRR")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse bool")
@@ -96,16 +102,16 @@ func TestBoolErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint8_Unmarshal(t *testing.T) {
-	type Uint8Struct struct {
-		Uint8One uint8 `flatfile:"1,1"`
-		Uint8Two uint8 `flatfile:"2,3"`
-	}
+type uint8Struct struct {
+	Uint8One uint8 `flatfile:"1,1"`
+	Uint8Two uint8 `flatfile:"2,3"`
+}
 
-	testVal := &Uint8Struct{}
+func TestUint8_Unmarshal(t *testing.T) {
+	testVal := &uint8Struct{}
 	data := []byte("1255")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -117,15 +123,15 @@ func TestUint8_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestUint8InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Uint8Struct struct {
-		Uint8One uint8 `flatfile:"1,1"`
-	}
+type uint8SyntaxStruct struct {
+	Uint8One uint8 `flatfile:"1,1"`
+}
 
-	testVal := &Uint8Struct{}
+func TestUint8InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &uint8SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse uint8")
@@ -134,15 +140,15 @@ func TestUint8InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint8OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Uint8Struct struct {
-		Uint8One uint8 `flatfile:"1,4"`
-	}
+type uint8RangeStruct struct {
+	Uint8One uint8 `flatfile:"1,4"`
+}
 
-	testVal := &Uint8Struct{}
+func TestUint8OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &uint8RangeStruct{}
 	data := []byte("2555")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse uint8")
@@ -151,16 +157,16 @@ func TestUint8OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint16_Unmarshal(t *testing.T) {
-	type Uint16Struct struct {
-		Uint16One uint16 `flatfile:"1,1"`
-		Uint16Two uint16 `flatfile:"2,5"`
-	}
+type uint16Struct struct {
+	Uint16One uint16 `flatfile:"1,1"`
+	Uint16Two uint16 `flatfile:"2,5"`
+}
 
-	testVal := &Uint16Struct{}
+func TestUint16_Unmarshal(t *testing.T) {
+	testVal := &uint16Struct{}
 	data := []byte("165535")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -172,15 +178,15 @@ func TestUint16_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestUint16InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Uint16Struct struct {
-		Uint16One uint16 `flatfile:"1,1"`
-	}
+type uint16SyntaxStruct struct {
+	Uint16One uint16 `flatfile:"1,1"`
+}
 
-	testVal := &Uint16Struct{}
+func TestUint16InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &uint16SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse uint16")
@@ -189,15 +195,15 @@ func TestUint16InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint16OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Uint16Struct struct {
-		Uint16One uint16 `flatfile:"1,5"`
-	}
+type uint16RangeStruct struct {
+	Uint16One uint16 `flatfile:"1,5"`
+}
 
-	testVal := &Uint16Struct{}
+func TestUint16OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &uint16RangeStruct{}
 	data := []byte("99999")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse uint16")
@@ -206,16 +212,16 @@ func TestUint16OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint32_Unmarshal(t *testing.T) {
-	type Uint32Struct struct {
-		Uint32One uint32 `flatfile:"1,1"`
-		Uint32Two uint32 `flatfile:"2,10"`
-	}
+type uint32Struct struct {
+	Uint32One uint32 `flatfile:"1,1"`
+	Uint32Two uint32 `flatfile:"2,10"`
+}
 
-	testVal := &Uint32Struct{}
+func TestUint32_Unmarshal(t *testing.T) {
+	testVal := &uint32Struct{}
 	data := []byte("14294967295")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -227,15 +233,15 @@ func TestUint32_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestUint32InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Uint32Struct struct {
-		Uint32One uint32 `flatfile:"1,1"`
-	}
+type uint32SyntaxStruct struct {
+	Uint32One uint32 `flatfile:"1,1"`
+}
 
-	testVal := &Uint32Struct{}
+func TestUint32InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &uint32SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse uint32")
@@ -244,15 +250,15 @@ func TestUint32InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint32OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Uint32Struct struct {
-		Uint32One uint32 `flatfile:"1,10"`
-	}
+type uint32RangeStruct struct {
+	Uint32One uint32 `flatfile:"1,10"`
+}
 
-	testVal := &Uint32Struct{}
+func TestUint32OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &uint32RangeStruct{}
 	data := []byte("9999999999")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse uint32")
@@ -261,16 +267,16 @@ func TestUint32OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint64_Unmarshal(t *testing.T) {
-	type Uint64Struct struct {
-		Uint64One uint64 `flatfile:"1,1"`
-		Uint64Two uint64 `flatfile:"2,20"`
-	}
+type uint64Struct struct {
+	Uint64One uint64 `flatfile:"1,1"`
+	Uint64Two uint64 `flatfile:"2,20"`
+}
 
-	testVal := &Uint64Struct{}
+func TestUint64_Unmarshal(t *testing.T) {
+	testVal := &uint64Struct{}
 	data := []byte("118446744073709551615")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -282,15 +288,15 @@ func TestUint64_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestUint64InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Uint64Struct struct {
-		Uint64One uint64 `flatfile:"1,1"`
-	}
+type uint64SyntaxStruct struct {
+	Uint64One uint64 `flatfile:"1,1"`
+}
 
-	testVal := &Uint64Struct{}
+func TestUint64InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &uint64SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse uint64")
@@ -299,15 +305,15 @@ func TestUint64InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint64OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Uint64Struct struct {
-		Uint64One uint64 `flatfile:"1,20"`
-	}
+type uint64RangeStruct struct {
+	Uint64One uint64 `flatfile:"1,20"`
+}
 
-	testVal := &Uint64Struct{}
+func TestUint64OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &uint64RangeStruct{}
 	data := []byte("99999999999999999999")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse uint64")
@@ -316,18 +322,18 @@ func TestUint64OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestUint_Unmarshal(t *testing.T) {
-	type UintStruct struct {
-		Uint8val  uint `flatfile:"1,1"`
-		Uint16val uint `flatfile:"2,5"`
-		Uint32val uint `flatfile:"2,10"`
-		Uint64val uint `flatfile:"2,20"`
-	}
+type uintStruct struct {
+	Uint8val  uint `flatfile:"1,1"`
+	Uint16val uint `flatfile:"2,5"`
+	Uint32val uint `flatfile:"2,10"`
+	Uint64val uint `flatfile:"2,20"`
+}
 
-	testVal := &UintStruct{}
+func TestUint_Unmarshal(t *testing.T) {
+	testVal := &uintStruct{}
 	data := []byte("118446744073709551615")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -339,16 +345,16 @@ func TestUint_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestInt8_Unmarshal(t *testing.T) {
-	type Int8Struct struct {
-		Int8One int8 `flatfile:"1,4"`
-		Int8Two int8 `flatfile:"5,3"`
-	}
+type int8Struct struct {
+	Int8One int8 `flatfile:"1,4"`
+	Int8Two int8 `flatfile:"5,3"`
+}
 
-	testVal := &Int8Struct{}
+func TestInt8_Unmarshal(t *testing.T) {
+	testVal := &int8Struct{}
 	data := []byte("-128127")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -360,15 +366,15 @@ func TestInt8_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestInt8InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Int8Struct struct {
-		Int8One int8 `flatfile:"1,1"`
-	}
+type int8SyntaxStruct struct {
+	Int8One int8 `flatfile:"1,1"`
+}
 
-	testVal := &Int8Struct{}
+func TestInt8InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &int8SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse int8")
@@ -377,15 +383,15 @@ func TestInt8InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestInt8OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Int8Struct struct {
-		Int8One int8 `flatfile:"1,4"`
-	}
+type int8RangeStruct struct {
+	Int8One int8 `flatfile:"1,4"`
+}
 
-	testVal := &Int8Struct{}
+func TestInt8OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &int8RangeStruct{}
 	data := []byte("2555")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse int8")
@@ -394,16 +400,16 @@ func TestInt8OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestInt16_Unmarshal(t *testing.T) {
-	type Int16Struct struct {
-		Int16One int16 `flatfile:"1,6"`
-		Int16Two int16 `flatfile:"7,5"`
-	}
+type int16Struct struct {
+	Int16One int16 `flatfile:"1,6"`
+	Int16Two int16 `flatfile:"7,5"`
+}
 
-	testVal := &Int16Struct{}
+func TestInt16_Unmarshal(t *testing.T) {
+	testVal := &int16Struct{}
 	data := []byte("-3276832767")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -415,15 +421,15 @@ func TestInt16_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestInt16InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Int16Struct struct {
-		Int16One int16 `flatfile:"1,1"`
-	}
+type int16SyntaxStruct struct {
+	Int16One int16 `flatfile:"1,1"`
+}
 
-	testVal := &Int16Struct{}
+func TestInt16InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &int16SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse int16")
@@ -432,15 +438,15 @@ func TestInt16InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestInt16OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Int16Struct struct {
-		Int16One int16 `flatfile:"1,5"`
-	}
+type int16RangeStruct struct {
+	Int16One int16 `flatfile:"1,5"`
+}
 
-	testVal := &Int16Struct{}
+func TestInt16OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &int16RangeStruct{}
 	data := []byte("99999")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse int16")
@@ -449,16 +455,16 @@ func TestInt16OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestInt32_Unmarshal(t *testing.T) {
-	type Int32Struct struct {
-		Int32One int32 `flatfile:"1,11"`
-		Int32Two int32 `flatfile:"12,10"`
-	}
+type int32Struct struct {
+	Int32One int32 `flatfile:"1,11"`
+	Int32Two int32 `flatfile:"12,10"`
+}
 
-	testVal := &Int32Struct{}
+func TestInt32_Unmarshal(t *testing.T) {
+	testVal := &int32Struct{}
 	data := []byte("-21474836482147483647")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -470,15 +476,15 @@ func TestInt32_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestInt32InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Int32Struct struct {
-		Int32One int32 `flatfile:"1,1"`
-	}
+type int32SyntaxStruct struct {
+	Int32One int32 `flatfile:"1,1"`
+}
 
-	testVal := &Int32Struct{}
+func TestInt32InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &int32SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse int32")
@@ -487,15 +493,15 @@ func TestInt32InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestInt32OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Int32Struct struct {
-		Int32One int32 `flatfile:"1,10"`
-	}
+type int32RangeStruct struct {
+	Int32One int32 `flatfile:"1,10"`
+}
 
-	testVal := &Int32Struct{}
+func TestInt32OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &int32RangeStruct{}
 	data := []byte("9999999999")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse int32")
@@ -504,16 +510,16 @@ func TestInt32OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestInt64_Unmarshal(t *testing.T) {
-	type Int64Struct struct {
-		Int64One int64 `flatfile:"1,20"`
-		Int64Two int64 `flatfile:"21,19"`
-	}
+type int64Struct struct {
+	Int64One int64 `flatfile:"1,20"`
+	Int64Two int64 `flatfile:"21,19"`
+}
 
-	testVal := &Int64Struct{}
+func TestInt64_Unmarshal(t *testing.T) {
+	testVal := &int64Struct{}
 	data := []byte("-92233720368547758089223372036854775807")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -525,15 +531,15 @@ func TestInt64_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestInt64InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Int64Struct struct {
-		Int64One int64 `flatfile:"1,1"`
-	}
+type int64SyntaxStruct struct {
+	Int64One int64 `flatfile:"1,1"`
+}
 
-	testVal := &Int64Struct{}
+func TestInt64InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &int64SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse int64")
@@ -542,15 +548,15 @@ func TestInt64InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestInt64OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Int64Struct struct {
-		Int64One int64 `flatfile:"1,19"`
-	}
+type int64RangeStruct struct {
+	Int64One int64 `flatfile:"1,19"`
+}
 
-	testVal := &Int64Struct{}
+func TestInt64OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &int64RangeStruct{}
 	data := []byte("9999999999999999999")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse int64")
@@ -559,18 +565,18 @@ func TestInt64OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestInt_Unmarshal(t *testing.T) {
-	type IntStruct struct {
-		Int8val  int `flatfile:"1,1"`
-		Int16val int `flatfile:"2,5"`
-		Int32val int `flatfile:"2,9"`
-		Int64val int `flatfile:"1,19"`
-	}
+type intStruct struct {
+	Int8val  int `flatfile:"1,1"`
+	Int16val int `flatfile:"2,5"`
+	Int32val int `flatfile:"2,9"`
+	Int64val int `flatfile:"1,19"`
+}
 
-	testVal := &IntStruct{}
+func TestInt_Unmarshal(t *testing.T) {
+	testVal := &intStruct{}
 	data := []byte("9223372036854775807")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -582,16 +588,16 @@ func TestInt_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestFloat32_Unmarshal(t *testing.T) {
-	type Float32Struct struct {
-		Float32One float32 `flatfile:"1,22"`
-		Float32Two float32 `flatfile:"23,21"`
-	}
+type float32Struct struct {
+	Float32One float32 `flatfile:"1,22"`
+	Float32Two float32 `flatfile:"23,21"`
+}
 
-	testVal := &Float32Struct{}
+func TestFloat32_Unmarshal(t *testing.T) {
+	testVal := &float32Struct{}
 	data := []byte("3.4028234663852886e+381.401298464324817e-45")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -603,15 +609,15 @@ func TestFloat32_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestFloat32InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Float32Struct struct {
-		Float32One float32 `flatfile:"1,1"`
-	}
+type float32SyntaxStruct struct {
+	Float32One float32 `flatfile:"1,1"`
+}
 
-	testVal := &Float32Struct{}
+func TestFloat32InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &float32SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse Float32")
@@ -620,15 +626,15 @@ func TestFloat32InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestFloat32OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Float32Struct struct {
-		Float32One float32 `flatfile:"1,40"`
-	}
+type float32RangeStruct struct {
+	Float32One float32 `flatfile:"1,40"`
+}
 
-	testVal := &Float32Struct{}
+func TestFloat32OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &float32RangeStruct{}
 	data := []byte("9999999999999999999999999999999999999999")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse Float32")
@@ -637,16 +643,16 @@ func TestFloat32OutOfRangeErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestFloat64_Unmarshal(t *testing.T) {
-	type Float64Struct struct {
-		Float64One float64 `flatfile:"1,23"`
-		Float64Two float64 `flatfile:"24,6"`
-	}
+type float64Struct struct {
+	Float64One float64 `flatfile:"1,23"`
+	Float64Two float64 `flatfile:"24,6"`
+}
 
-	testVal := &Float64Struct{}
+func TestFloat64_Unmarshal(t *testing.T) {
+	testVal := &float64Struct{}
 	data := []byte("1.7976931348623157e+3085e-324")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Error(err)
@@ -658,15 +664,15 @@ func TestFloat64_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestFloat64InvalidSyntaxErr_Unmarshal(t *testing.T) {
-	type Float64Struct struct {
-		Float64One float64 `flatfile:"1,1"`
-	}
+type float64SyntaxStruct struct {
+	Float64One float64 `flatfile:"1,1"`
+}
 
-	testVal := &Float64Struct{}
+func TestFloat64InvalidSyntaxErr_Unmarshal(t *testing.T) {
+	testVal := &float64SyntaxStruct{}
 	data := []byte("$")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse Float64")
@@ -675,15 +681,15 @@ func TestFloat64InvalidSyntaxErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestFloat64OutOfRangeErr_Unmarshal(t *testing.T) {
-	type Float64Struct struct {
-		Float64One float64 `flatfile:"1,23"`
-	}
+type float64RangeStruct struct {
+	Float64One float64 `flatfile:"1,23"`
+}
 
-	testVal := &Float64Struct{}
+func TestFloat64OutOfRangeErr_Unmarshal(t *testing.T) {
+	testVal := &float64RangeStruct{}
 	data := []byte("2.7976931348623157e+308")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err == nil {
 		t.Error("Unmarshal should return error when failing to parse Float64")
@@ -777,20 +783,20 @@ func TestFfpTagParseMissingParamErr_Unmarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestArrayParse(t *testing.T) {
-	type FfpTest struct {
-		TestVal [4]int     `flatfile:"1,2"`
-		Names   [10]string `flatfile:"9,3"`
-	}
+type arrayStruct struct {
+	TestVal [4]int     `flatfile:"1,2"`
+	Names   [10]string `flatfile:"9,3"`
+}
 
-	testVal := &FfpTest{}
+func TestArrayParse(t *testing.T) {
+	testVal := &arrayStruct{}
 	expectedVal := [4]int{11, 22, 33, 44}
 
 	expectedNames := [10]string{"AMY", "BOB", "CAM", "DAN", "EDD", "FAE", "GUY", "HIM", "IGG", "JAY"}
 
 	data := []byte("11223344AMYBOBCAMDANEDDFAEGUYHIMIGGJAY")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Log(err)
@@ -811,21 +817,22 @@ func TestArrayParse(t *testing.T) {
 	}
 }
 
-func TestArrayNestedStructParse(t *testing.T) {
-	type Name struct {
-		NameData string `flatfile:"2,2"`
-	}
-	type FfpTest struct {
-		Names [3]Name `flatfile:"1,3"`
-	}
+type arrayName struct {
+	NameData string `flatfile:"2,2"`
+}
 
-	testVal := &FfpTest{}
+type arrayNestedStruct struct {
+	Names [3]arrayName `flatfile:"1,3"`
+}
 
-	expectedNames := [3]Name{Name{NameData: "MY"}, Name{NameData: "OB"}, Name{NameData: "AM"}}
+func TestArrayNestedStructParse(t *testing.T) {
+	testVal := &arrayNestedStruct{}
+
+	expectedNames := [3]arrayName{arrayName{NameData: "MY"}, arrayName{NameData: "OB"}, arrayName{NameData: "AM"}}
 
 	data := []byte("AMYBOBCAM")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Log(err)
@@ -840,20 +847,20 @@ func TestArrayNestedStructParse(t *testing.T) {
 	}
 }
 
-func TestSliceParse(t *testing.T) {
-	type FfpTest struct {
-		TestVal []int    `flatfile:"1,2,4"`
-		Names   []string `flatfile:"9,3,10"`
-	}
+type sliceStruct struct {
+	TestVal []int    `flatfile:"1,2,4"`
+	Names   []string `flatfile:"9,3,10"`
+}
 
-	testVal := &FfpTest{}
+func TestSliceParse(t *testing.T) {
+	testVal := &sliceStruct{}
 	expectedVal := []int{11, 22, 33, 44}
 
 	expectedNames := []string{"AMY", "BOB", "CAM", "DAN", "EDD", "FAE", "GUY", "HIM", "IGG", "JAY"}
 
 	data := []byte("11223344AMYBOBCAMDANEDDFAEGUYHIMIGGJAY")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Log(err)
@@ -877,21 +884,22 @@ func TestSliceParse(t *testing.T) {
 	}
 }
 
-func TestSliceNestedStructParse(t *testing.T) {
-	type Name struct {
-		NameData string `flatfile:"2,2"`
-	}
-	type FfpTest struct {
-		Names []Name `flatfile:"1,3,3"`
-	}
+type sliceName struct {
+	NameData string `flatfile:"2,2"`
+}
 
-	testVal := &FfpTest{}
+type sliceNestedStruct struct {
+	Names []sliceName `flatfile:"1,3,3"`
+}
 
-	expectedNames := [3]Name{Name{NameData: "MY"}, Name{NameData: "OB"}, Name{NameData: "AM"}}
+func TestSliceNestedStructParse(t *testing.T) {
+	testVal := &sliceNestedStruct{}
+
+	expectedNames := [3]sliceName{sliceName{NameData: "MY"}, sliceName{NameData: "OB"}, sliceName{NameData: "AM"}}
 
 	data := []byte("AMYBOBCAM")
 
-	err := Unmarshal(data, testVal, 0, 0, false)
+	err := unmarshalBoth(t, data, testVal)
 
 	if err != nil {
 		t.Log(err)
@@ -1001,28 +1009,28 @@ func TestCalcNumFieldsToUnmarshalRemainder(t *testing.T) {
 	}
 }
 
-func TestByte_Unmarshal(t *testing.T) {
-	type ByteStruct struct {
-		ByteOne byte `flatfile:"1,1,override=byte"`
-		ByteTwo byte `flatfile:"2,1,override=byte"`
-	}
+type byteStruct struct {
+	ByteOne byte `flatfile:"1,1,override=byte"`
+	ByteTwo byte `flatfile:"2,1,override=byte"`
+}
 
+func TestByte_Unmarshal(t *testing.T) {
 	var tests = []struct {
-		ByteSt ByteStruct
+		ByteSt byteStruct
 		Record []byte
-		Want   ByteStruct
+		Want   byteStruct
 	}{
-		{ByteStruct{}, []byte("1134567891"), ByteStruct{byte('1'), byte('1')}},
-		{ByteStruct{}, []byte("a1345678910"), ByteStruct{'a', '1'}},
-		{ByteStruct{}, []byte("a2"), ByteStruct{'a', '2'}},
-		{ByteStruct{}, []byte("/a"), ByteStruct{'/', 'a'}},
-		{ByteStruct{}, []byte("?1"), ByteStruct{'?', '1'}},
+		{byteStruct{}, []byte("1134567891"), byteStruct{byte('1'), byte('1')}},
+		{byteStruct{}, []byte("a1345678910"), byteStruct{'a', '1'}},
+		{byteStruct{}, []byte("a2"), byteStruct{'a', '2'}},
+		{byteStruct{}, []byte("/a"), byteStruct{'/', 'a'}},
+		{byteStruct{}, []byte("?1"), byteStruct{'?', '1'}},
 	}
 
 	for idx, tt := range tests {
 		testName := fmt.Sprintf("TestByte_Unmarshal-%d", idx)
 		t.Run(testName, func(t *testing.T) {
-			err := Unmarshal(tt.Record, &tt.ByteSt, 0, 0, false)
+			err := unmarshalBoth(t, tt.Record, &tt.ByteSt)
 			if err != nil {
 				t.Errorf("err: %s", err)
 			}
@@ -1033,28 +1041,28 @@ func TestByte_Unmarshal(t *testing.T) {
 	}
 }
 
-func TestRune_Unmarshal(t *testing.T) {
-	type RuneStruct struct {
-		RuneOne rune `flatfile:"1,1,override=rune"`
-		RuneTwo rune `flatfile:"2,1,override=rune"`
-	}
+type runeStruct struct {
+	RuneOne rune `flatfile:"1,1,override=rune"`
+	RuneTwo rune `flatfile:"2,1,override=rune"`
+}
 
+func TestRune_Unmarshal(t *testing.T) {
 	var tests = []struct {
-		RuneSt RuneStruct
+		RuneSt runeStruct
 		Record []byte
-		Want   RuneStruct
+		Want   runeStruct
 	}{
-		{RuneStruct{}, []byte("1134567891"), RuneStruct{'1', '1'}},
-		{RuneStruct{}, []byte("a1345678910"), RuneStruct{'a', '1'}},
-		{RuneStruct{}, []byte("a2"), RuneStruct{'a', '2'}},
-		{RuneStruct{}, []byte("/a"), RuneStruct{'/', 'a'}},
-		{RuneStruct{}, []byte("?1"), RuneStruct{'?', '1'}},
+		{runeStruct{}, []byte("1134567891"), runeStruct{'1', '1'}},
+		{runeStruct{}, []byte("a1345678910"), runeStruct{'a', '1'}},
+		{runeStruct{}, []byte("a2"), runeStruct{'a', '2'}},
+		{runeStruct{}, []byte("/a"), runeStruct{'/', 'a'}},
+		{runeStruct{}, []byte("?1"), runeStruct{'?', '1'}},
 	}
 
 	for idx, tt := range tests {
 		testName := fmt.Sprintf("TestRune_Unmarshal-%d", idx)
 		t.Run(testName, func(t *testing.T) {
-			err := Unmarshal(tt.Record, &tt.RuneSt, 0, 0, false)
+			err := unmarshalBoth(t, tt.Record, &tt.RuneSt)
 			if err != nil {
 				t.Errorf("err: %s", err)
 			}
@@ -1117,15 +1125,15 @@ func TestNotAPointerErr_Umarshal(t *testing.T) {
 	t.Log(err)
 }
 
-func TestNestedPointer_Unmarshal(t *testing.T) {
-	type NestedStruct struct {
-		ByteOne *int `flatfile:"1,1"`
-	}
+type nestedPointerData struct {
+	ByteOne *int `flatfile:"1,1"`
+}
 
-	type ByteStruct struct {
-		NestedData *NestedStruct `flatfile:"1,1"`
-	}
+type nestedPointerStruct struct {
+	NestedData *nestedPointerData `flatfile:"1,1"`
+}
 
+func TestNestedPointer_Unmarshal(t *testing.T) {
 	var wantByte int
 	wantByte = int(1)
 
@@ -1133,17 +1141,17 @@ func TestNestedPointer_Unmarshal(t *testing.T) {
 	var GotByte *int
 	GotByte = &initByte
 	var tests = []struct {
-		ByteSt ByteStruct
+		ByteSt nestedPointerStruct
 		Record []byte
-		Want   ByteStruct
+		Want   nestedPointerStruct
 	}{
-		{ByteStruct{NestedData: &NestedStruct{ByteOne: GotByte}}, []byte("1134567891"), ByteStruct{NestedData: &NestedStruct{ByteOne: &wantByte}}},
+		{nestedPointerStruct{NestedData: &nestedPointerData{ByteOne: GotByte}}, []byte("1134567891"), nestedPointerStruct{NestedData: &nestedPointerData{ByteOne: &wantByte}}},
 	}
 
 	for idx, tt := range tests {
 		testName := fmt.Sprintf("TestByte_Unmarshal-%d", idx)
 		t.Run(testName, func(t *testing.T) {
-			err := Unmarshal(tt.Record, &tt.ByteSt, 0, 0, false)
+			err := unmarshalBoth(t, tt.Record, &tt.ByteSt)
 			if err != nil {
 				t.Errorf("err: %s", err)
 			}
@@ -1165,6 +1173,8 @@ func TestShouldUnmarshal(t *testing.T) {
 		{flatfileTag{condCol: 1, condLen: 1, condVal: "9", condChk: true}, []byte("1134567891"), false},
 		{flatfileTag{condCol: 4, condLen: 2, condVal: "45", condChk: true}, []byte("1134567891"), true},
 		{flatfileTag{condCol: 4, condLen: 7, condVal: "456789", condChk: true}, []byte("1134567891"), false},
+		{flatfileTag{condCol: 20, condLen: 3, condVal: "ABC", condChk: true}, []byte("11345"), false},
+		{flatfileTag{condCol: 4, condLen: 3, condVal: "45", condChk: true}, []byte("11345"), true},
 		{flatfileTag{condCol: 1, condLen: 1, condVal: "1", condChk: false}, []byte("1134567891"), true},
 		{flatfileTag{condCol: 1, condLen: 1, condVal: "9", condChk: false}, []byte("1134567891"), true},
 		{flatfileTag{condCol: 4, condLen: 2, condVal: "45", condChk: false}, []byte("1134567891"), true},
@@ -1181,3 +1191,448 @@ func TestShouldUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordLength(t *testing.T) {
+	type Name struct {
+		NameData string `flatfile:"1,3"`
+	}
+	type Profile struct {
+		NameData string    `flatfile:"1,9"`
+		Age      int       `flatfile:"10,2"`
+		Phones   []string  `flatfile:"12,10,3"`
+		Initials [2]string `flatfile:"42,1"`
+		Nickname Name      `flatfile:"44,3"`
+		Ignored  string
+	}
+
+	got, err := RecordLength(&Profile{})
+	if err != nil {
+		t.Errorf("err: %s", err)
+	}
+	if got != 46 {
+		t.Errorf("RecordLength() got: %d want: %d", got, 46)
+	}
+
+	if _, err := RecordLength(1); err == nil {
+		t.Error("RecordLength should return error when v is not a struct")
+	}
+}
+
+type numericRecord struct {
+	Int     int     `flatfile:"1,5"`
+	Int8    int8    `flatfile:"6,4"`
+	Int16   int16   `flatfile:"10,6"`
+	Int32   int32   `flatfile:"16,11"`
+	Int64   int64   `flatfile:"27,20"`
+	Uint    uint    `flatfile:"47,5"`
+	Uint8   uint8   `flatfile:"52,3"`
+	Uint64  uint64  `flatfile:"55,20"`
+	Float32 float32 `flatfile:"75,8"`
+	Float64 float64 `flatfile:"83,12"`
+	Bool    bool    `flatfile:"95,5"`
+}
+
+var numericRecordData = []byte("-1234-128-32768-2147483648-9223372036854775808012342551844674407370955161512345.67-1234567.891false")
+
+func TestUnmarshalNumericAllocs(t *testing.T) {
+	record := &numericRecord{}
+	if err := unmarshalBoth(t, numericRecordData, record); err != nil {
+		t.Fatal(err)
+	}
+	want := numericRecord{-1234, -128, -32768, -2147483648, -9223372036854775808, 1234, 255, 18446744073709551615, 12345.67, -1234567.891, false}
+	if *record != want {
+		t.Errorf("Unmarshal() got: %v want: %v", *record, want)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		Unmarshal(numericRecordData, record, 0, 0, false)
+	})
+	if allocs != 0 {
+		t.Errorf("Unmarshal() allocated %v times per run want 0", allocs)
+	}
+	reflection := &decoder{reflection: true}
+	allocs = testing.AllocsPerRun(100, func() {
+		reflection.unmarshal(numericRecordData, record, 0, 0, false)
+	})
+	if allocs != 0 {
+		t.Errorf("Unmarshal() with reflection allocated %v times per run want 0", allocs)
+	}
+}
+
+func BenchmarkUnmarshalNumeric(b *testing.B) {
+	record := &numericRecord{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := Unmarshal(numericRecordData, record, 0, 0, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalNumericReflection(b *testing.B) {
+	record := &numericRecord{}
+	reflection := &decoder{reflection: true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := reflection.unmarshal(numericRecordData, record, 0, 0, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type lenientRecord struct {
+	Name   string  `flatfile:"1,3"`
+	Count  int     `flatfile:"4,2"`
+	Active bool    `flatfile:"6,1"`
+	Code   string  `flatfile:"7,2,oneof=AA|BB"`
+	Amount *amount `flatfile:"9,4"`
+}
+
+type amount struct {
+	Value    float64 `flatfile:"1,3"`
+	Currency string  `flatfile:"4,1"`
+}
+
+func TestUnmarshalWithLenient(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want lenientRecord
+		errs []string
+	}{
+		{"Valid", "AMY12TAA1.5$", lenientRecord{"AMY", 12, true, "AA", &amount{1.5, "$"}}, nil},
+		{"Bad fields", "AMY1XZCC1.X$", lenientRecord{"AMY", 0, false, "CC", &amount{0, "$"}},
+			[]string{"Count decode 1X", "Active decode Z", "Code oneof=AA|BB CC", "Amount.Value decode 1.X"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := lenientRecord{Count: 7, Amount: &amount{Value: 9}}
+			err := UnmarshalWith([]byte(tt.data), &record, WithLenient())
+			if got := describeFieldErrors(t, err); !reflect.DeepEqual(got, tt.errs) {
+				t.Errorf("UnmarshalWith() = %v, want %v", got, tt.errs)
+			}
+			if !reflect.DeepEqual(record, tt.want) {
+				t.Errorf("UnmarshalWith() got: %+v %+v want: %+v %+v", record, record.Amount, tt.want, tt.want.Amount)
+			}
+		})
+	}
+
+	var record lenientRecord
+	err := UnmarshalWith([]byte("AMY1XZCC1.X$"), &record)
+	var fieldErrs FieldErrors
+	if err == nil || errors.As(err, &fieldErrs) {
+		t.Errorf("UnmarshalWith() without WithLenient should stop at the first field, got %v", err)
+	}
+	if record.Active {
+		t.Errorf("UnmarshalWith() without WithLenient decoded fields after the first error")
+	}
+}
+
+type embeddedHeader struct {
+	RecordType string `flatfile:"1,1"`
+	Version    int    `flatfile:"2,2,max=9"`
+}
+
+type embeddedDetail struct {
+	embeddedHeader
+	Amount int `flatfile:"4,5,cond=RecordType = D and Version >= 2"`
+}
+
+type EmbeddedHeader struct {
+	RecordType string `flatfile:"1,1"`
+	Version    int    `flatfile:"2,2,max=9"`
+}
+
+type embeddedRecord struct {
+	*EmbeddedHeader
+	Amount int `flatfile:"4,5,cond=RecordType = D and Version >= 2"`
+	Name   string
+}
+
+func TestUnmarshalEmbedded(t *testing.T) {
+	tests := []struct {
+		data string
+		want embeddedRecord
+		errs []string
+		//marshalled is the record written by Marshal, fields whose condition is not met are spaces
+		marshalled string
+	}{
+		{"D0200150", embeddedRecord{EmbeddedHeader: &EmbeddedHeader{RecordType: "D", Version: 2}, Amount: 150}, nil, "D0200150"},
+		{"D0100150", embeddedRecord{EmbeddedHeader: &EmbeddedHeader{RecordType: "D", Version: 1}}, nil, "D01     "},
+		{"D1200150", embeddedRecord{EmbeddedHeader: &EmbeddedHeader{RecordType: "D", Version: 12}, Amount: 150}, []string{"Version max=9 12"}, "D1200150"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var record embeddedRecord
+			err := Unmarshal([]byte(tt.data), &record, 0, 0, false)
+			if got := describeFieldErrors(t, err); !reflect.DeepEqual(got, tt.errs) {
+				t.Errorf("Unmarshal() = %v, want %v", got, tt.errs)
+			}
+			if !reflect.DeepEqual(record, tt.want) {
+				t.Errorf("Unmarshal() got: %+v %+v want: %+v %+v", record, record.EmbeddedHeader, tt.want, tt.want.EmbeddedHeader)
+			}
+			if got := describeFieldErrors(t, Validate(record)); !reflect.DeepEqual(got, tt.errs) {
+				t.Errorf("Validate() = %v, want %v", got, tt.errs)
+			}
+
+			data, err := Marshal(tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.marshalled {
+				t.Errorf("Marshal() got: %q want: %q", data, tt.marshalled)
+			}
+		})
+	}
+
+	//unexported embedded structs are not flattened
+	var detail embeddedDetail
+	if err := Unmarshal([]byte("D0200150"), &detail, 0, 0, false); err == nil {
+		t.Error("Unmarshal() expected error for a condition on a field of an unexported embedded struct")
+	}
+
+	if n, err := RecordLength(embeddedRecord{}); err != nil || n != 8 {
+		t.Errorf("RecordLength() = %d, %v, want 8", n, err)
+	}
+	layout, err := DescribeLayout(embeddedRecord{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, field := range layout.Fields {
+		names = append(names, field.Name)
+	}
+	if want := []string{"RecordType", "Version", "Amount"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("DescribeLayout() fields = %v, want %v", names, want)
+	}
+}
+
+type taggedEmbedded struct {
+	EmbeddedHeader `flatfile:"4,3"`
+	Count          int `flatfile:"1,3"`
+}
+
+func TestUnmarshalTaggedEmbedded(t *testing.T) {
+	var record taggedEmbedded
+	if err := Unmarshal([]byte("007D02"), &record, 0, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	if want := (taggedEmbedded{EmbeddedHeader{"D", 2}, 7}); record != want {
+		t.Errorf("Unmarshal() got: %+v want: %+v", record, want)
+	}
+}
+
+type pointerNested struct {
+	Code string `flatfile:"1,2"`
+}
+
+type pointerRecord struct {
+	Count    *int           `flatfile:"1,2"`
+	Name     **string       `flatfile:"3,3"`
+	Nested   *pointerNested `flatfile:"6,2"`
+	Scores   [2]*int        `flatfile:"8,1"`
+	Codes    []*string      `flatfile:"10,1,occurs=2"`
+	Optional *pointerNested `flatfile:"12,2,optional"`
+	Elements []*int         `flatfile:"14,1,occurs=2,optional"`
+}
+
+func TestUnmarshalAllocatesPointers(t *testing.T) {
+	tests := []struct {
+		data string
+		//optional and elements are the values of the optional pointers, nil when blank
+		optional *pointerNested
+		elements []*int
+	}{
+		{"07AMYXY12AB    ", nil, []*int{nil, nil}},
+		{"07AMYXY12ABZZ35", &pointerNested{Code: "ZZ"}, []*int{intPtr(3), intPtr(5)}},
+		{"07AMYXY12AB  3 ", nil, []*int{intPtr(3), nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			record := pointerRecord{Optional: &pointerNested{Code: "OLD"}}
+			if err := unmarshalBoth(t, []byte(tt.data), &record); err != nil {
+				t.Fatal(err)
+			}
+			name := "AMY"
+			want := pointerRecord{
+				Count: intPtr(7), Name: func() **string { p := &name; return &p }(), Nested: &pointerNested{Code: "XY"},
+				Scores: [2]*int{intPtr(1), intPtr(2)}, Codes: []*string{stringPtr("A"), stringPtr("B")},
+				Optional: tt.optional, Elements: tt.elements,
+			}
+			if !reflect.DeepEqual(record, want) {
+				t.Errorf("Unmarshal() got: %s want: %s", pointerText(record), pointerText(want))
+			}
+		})
+	}
+
+	invalid := &struct {
+		Count int `flatfile:"1,2,optional"`
+	}{}
+	if err := Unmarshal([]byte("07"), invalid, 0, 0, false); err == nil {
+		t.Error("Unmarshal() expected error for the optional option on a field which is not a pointer")
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+//pointerText formats the values pointers of a pointerRecord point to
+func pointerText(record pointerRecord) string {
+	text := fmt.Sprintf("%+v", record)
+	if record.Optional != nil {
+		text += fmt.Sprintf(" Optional=%+v", *record.Optional)
+	}
+	for _, element := range record.Elements {
+		if element != nil {
+			text += fmt.Sprintf(" Element=%d", *element)
+		}
+	}
+	return text
+}
+
+type elementLine struct {
+	Amount int    `flatfile:"1,3"`
+	Code   string `flatfile:"4,1"`
+}
+
+type elementRecord struct {
+	Name   string        `flatfile:"1,3"`
+	Lines  []elementLine `flatfile:"4,4,occurs=3"`
+	Counts [2]int        `flatfile:"16,2"`
+}
+
+func TestUnmarshalElementErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want elementRecord
+		errs []string
+	}{
+		{"Valid", "AMY001A002B003C0102", elementRecord{"AMY", []elementLine{{1, "A"}, {2, "B"}, {3, "C"}}, [2]int{1, 2}}, nil},
+		{"Bad elements", "AMY001A002B0X3C01XX", elementRecord{"AMY", []elementLine{{1, "A"}, {2, "B"}, {0, "C"}}, [2]int{1, 0}},
+			[]string{"Lines[2].Amount decode 0X3", "Counts[1] decode XX"}},
+		{"Missing elements", "AMY001A002B", elementRecord{"AMY", []elementLine{{1, "A"}, {2, "B"}, {}}, [2]int{7, 7}}, nil},
+		{"Element cut short", "AMY001A002B00", elementRecord{"AMY", []elementLine{{1, "A"}, {2, "B"}, {}}, [2]int{7, 7}},
+			[]string{"Lines[2].Amount decode 00"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := elementRecord{Counts: [2]int{7, 7}}
+			err := UnmarshalWith([]byte(tt.data), &record, WithLenient())
+			if got := describeFieldErrors(t, err); !reflect.DeepEqual(got, tt.errs) {
+				t.Errorf("UnmarshalWith() = %v, want %v", got, tt.errs)
+			}
+			if !reflect.DeepEqual(record, tt.want) {
+				t.Errorf("UnmarshalWith() got: %+v want: %+v", record, tt.want)
+			}
+		})
+	}
+
+	//without WithLenient decoding stops at the first element which fails to decode
+	var record elementRecord
+	err := unmarshalBoth(t, []byte("AMY001A002B0X3C01XX"), &record)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Lines[2].Amount" {
+		t.Errorf("Unmarshal() got error %v, want a decode error for Lines[2].Amount", err)
+	}
+	if record.Counts != [2]int{} {
+		t.Errorf("Unmarshal() decoded fields after the first error: %+v", record)
+	}
+
+	noOccurs := &struct {
+		Lines []elementLine `flatfile:"1,4"`
+	}{}
+	if err := Unmarshal([]byte("001A"), noOccurs, 0, 0, false); err == nil {
+		t.Error("Unmarshal() expected error for a slice without the occurs option")
+	}
+}
+
+type cutShortRecord struct {
+	Code   string      `flatfile:"1,2"`
+	Amount int         `flatfile:"3,4"`
+	Line   elementLine `flatfile:"7,4"`
+	Names  [2]string   `flatfile:"11,3"`
+}
+
+func TestUnmarshalCutShort(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want cutShortRecord
+		//errField is the field which is cut short, empty when the record decodes
+		errField string
+	}{
+		{"Whole record", "AB0012001XAMYBOB", cutShortRecord{"AB", 12, elementLine{1, "X"}, [2]string{"AMY", "BOB"}}, ""},
+		{"String", "A", cutShortRecord{Code: "A"}, ""},
+		{"Number", "AB00", cutShortRecord{}, "Amount"},
+		{"Nested struct", "AB0012001", cutShortRecord{"AB", 12, elementLine{Amount: 1}, [2]string{}}, ""},
+		{"Field of nested struct", "AB001200", cutShortRecord{}, "Line.Amount"},
+		{"String element", "AB0012001XAMYBO", cutShortRecord{"AB", 12, elementLine{1, "X"}, [2]string{"AMY", "BO"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record cutShortRecord
+			err := unmarshalBoth(t, []byte(tt.data), &record)
+			if tt.errField != "" {
+				var fieldErr *FieldError
+				if !errors.As(err, &fieldErr) || fieldErr.Field != tt.errField || !errors.Is(err, ErrCutShort) {
+					t.Errorf("Unmarshal() got error %v, want ErrCutShort for %s", err, tt.errField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal() unexpected error %s", err)
+			}
+			if record != tt.want {
+				t.Errorf("Unmarshal() got: %+v want: %+v", record, tt.want)
+			}
+		})
+	}
+}
+
+/*unmarshalBoth unmarshals data into v, whose type has generated methods, and into a copy of v by reflection
+
+It reports the differences between the values and errors of the two and returns the error of the generated methods.
+*/
+func unmarshalBoth(t *testing.T, data []byte, v interface{}) error {
+	t.Helper()
+	if _, ok := v.(Unmarshaler); !ok {
+		t.Fatalf("%T has no generated methods, run go generate", v)
+	}
+	reflected := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+	//the copy does not share pointers with v
+	copied, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(copied, reflected); err != nil {
+		t.Fatal(err)
+	}
+
+	err = Unmarshal(data, v, 0, 0, false)
+	errReflect := (&decoder{reflection: true}).unmarshal(data, reflected, 0, 0, false)
+	if got, want := fieldErrorText(err), fieldErrorText(errReflect); got != want {
+		t.Errorf("Unmarshal(%q) error = %s, reflection error = %s", data, got, want)
+	}
+	if !reflect.DeepEqual(v, reflected) {
+		t.Errorf("Unmarshal(%q) = %+v, reflection = %+v", data, reflect.ValueOf(v).Elem(), reflect.ValueOf(reflected).Elem())
+	}
+	return err
+}
+
+//fieldErrorText describes the FieldError of err, comparable between the generated methods and reflection
+func fieldErrorText(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		return "not a FieldError: " + err.Error()
+	}
+	return fieldErr.Error()
+}
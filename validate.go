@@ -0,0 +1,317 @@
+package flatfile
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+//FieldError is a validation rule which a field does not satisfy, or a field which failed to decode in lenient mode
+type FieldError struct {
+	//Field is the path of the field from the record, for example Address.City or Phones[1]
+	Field string
+	//Rule is the tag option of the rule: required, min, max, oneof or pattern. It is empty when the field failed to decode.
+	Rule  string
+	Value interface{}
+	//Err is the reason a field failed to decode, Value is then the data of the field
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("flatfile: Field %s value %q failed to decode: %v", e.Field, e.Value, e.Err)
+	}
+	if e.Rule == "required" {
+		return fmt.Sprintf("flatfile: Field %s is required", e.Field)
+	}
+	return fmt.Sprintf("flatfile: Field %s value %v does not satisfy %s", e.Field, e.Value, e.Rule)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+//FieldErrors holds every validation rule which a record does not satisfy, and in lenient mode every field which failed to decode
+//Unmarshal and Validate return FieldErrors once all fields are checked, use errors.As to retrieve them.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+//prefix returns the errors of a nested struct or element with name prepended to their fields
+func (e FieldErrors) prefix(name string) FieldErrors {
+	for _, err := range e {
+		err.Field = fieldPath(name, err.Field)
+	}
+	return e
+}
+
+//fieldPath returns the path of field within name, for example Address.City or Phones[1]
+func fieldPath(name, field string) string {
+	if strings.HasPrefix(field, "[") {
+		return name + field
+	}
+	return name + "." + field
+}
+
+//DecodeError returns err, the error of decoding fieldData, as the FieldError Unmarshal returns for the field or element name
+//name is a field name or an index such as [2]. It is used by the methods generated by cmd/flatfilegen.
+func DecodeError(name string, fieldData []byte, err error) *FieldError {
+	return decodeError(name, fieldData, err)
+}
+
+//decodeError returns err, the error of decoding fieldData, as a FieldError of the field name
+//A FieldError of a nested struct or element already names the field within name, which is prepended to its path.
+func decodeError(name string, fieldData []byte, err error) *FieldError {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		fieldErr.Field = fieldPath(name, fieldErr.Field)
+		return fieldErr
+	}
+	return &FieldError{Field: name, Value: string(fieldData), Err: errors.Cause(err)}
+}
+
+/*Validate checks the validation rules of the flatfile tags of v, a struct or pointer to struct, as Unmarshal does after decoding
+
+	required    strings must not be blank, pointers must not be nil and times must not be zero
+	min, max    bounds of numbers, of times in the format of the field, and of the length of strings without padding
+	oneof=A|B   allowed values, compared with strings without padding
+	pattern     regular expression which must match the whole string without padding
+
+Rules other than required are not checked for blank strings and zero times.
+Nested structs are validated with their own tags. Validate returns FieldErrors listing every rule which is not satisfied.
+*/
+func Validate(v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return errors.Errorf("flatfile.Validate: %s is not a struct or pointer to struct", reflect.TypeOf(v))
+	}
+	errs, err := validateStruct(value)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+//validateStruct checks the rules of all fields of a struct value
+func validateStruct(value reflect.Value) (FieldErrors, error) {
+	var errs FieldErrors
+	vType := value.Type()
+	for i, field := range layoutOf(vType).fields {
+		if field.embedded {
+			if embedded, ok := embeddedValue(value.Field(i), false); ok {
+				fieldErrs, err := validateStruct(embedded)
+				if err != nil {
+					return nil, err
+				}
+				errs = append(errs, fieldErrs...)
+			}
+			continue
+		}
+		//unexported fields are never unmarshalled
+		if !field.tagged || !vType.Field(i).IsExported() {
+			continue
+		}
+		if field.tagErr != nil {
+			return nil, errors.Wrapf(field.tagErr, "flatfile.Validate: Failed to parse field tag %s", field.rawTag)
+		}
+		fieldErrs, err := validateNested(vType.Field(i).Name, value.Field(i), &field.tag)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, fieldErrs...)
+	}
+	return errs, nil
+}
+
+//validateNested checks a field, the elements of arrays and slices and the fields of nested structs
+func validateNested(name string, field reflect.Value, ffpTag *flatfileTag) (FieldErrors, error) {
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() {
+			return validateField(name, field, ffpTag)
+		}
+		return validateNested(name, field.Elem(), ffpTag)
+	case reflect.Array, reflect.Slice:
+		var errs FieldErrors
+		for i := 0; i < field.Len(); i++ {
+			elemErrs, err := validateNested(name+"["+strconv.Itoa(i)+"]", field.Index(i), ffpTag)
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, elemErrs...)
+		}
+		return errs, nil
+	case reflect.Struct:
+		if field.Type() != timeType {
+			errs, err := validateStruct(field)
+			return errs.prefix(name), err
+		}
+	}
+	return validateField(name, field, ffpTag)
+}
+
+/*validateField checks the rules of a field which is not a nested struct
+
+Arrays and slices are checked element by element. Unmarshal calls it for each decoded field,
+the fields of nested structs are checked when the nested struct is unmarshalled.
+*/
+func validateField(name string, field reflect.Value, ffpTag *flatfileTag) (FieldErrors, error) {
+	if !ffpTag.validated() {
+		return nil, nil
+	}
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() {
+			if ffpTag.required {
+				return FieldErrors{{Field: name, Rule: "required"}}, nil
+			}
+			return nil, nil
+		}
+		return validateField(name, field.Elem(), ffpTag)
+	case reflect.Array, reflect.Slice:
+		var errs FieldErrors
+		for i := 0; i < field.Len(); i++ {
+			elemErrs, err := validateField(name+"["+strconv.Itoa(i)+"]", field.Index(i), ffpTag)
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, elemErrs...)
+		}
+		return errs, nil
+	case reflect.Struct:
+		if field.Type() != timeType {
+			return nil, nil
+		}
+	}
+
+	text := valueText(field, ffpTag)
+	if text == "" || (field.Type() == timeType && field.Interface().(time.Time).IsZero()) {
+		if ffpTag.required {
+			return FieldErrors{{Field: name, Rule: "required"}}, nil
+		}
+		return nil, nil
+	}
+
+	var errs FieldErrors
+	for _, bound := range []struct {
+		rule, value string
+		sign        int
+	}{{"min", ffpTag.min, -1}, {"max", ffpTag.max, 1}} {
+		if bound.value == "" {
+			continue
+		}
+		cmp, err := compareBound(field, text, bound.value, ffpTag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "flatfile.validateField: Invalid %s option of field %s", bound.rule, name)
+		}
+		if cmp == bound.sign {
+			errs = append(errs, &FieldError{Field: name, Rule: bound.rule + "=" + bound.value, Value: text})
+		}
+	}
+	if ffpTag.oneOf != nil && !contains(ffpTag.oneOf, text) {
+		errs = append(errs, &FieldError{Field: name, Rule: "oneof=" + strings.Join(ffpTag.oneOf, "|"), Value: text})
+	}
+	if ffpTag.pattern != nil && !ffpTag.pattern.MatchString(text) {
+		errs = append(errs, &FieldError{Field: name, Rule: "pattern=" + ffpTag.patternText, Value: text})
+	}
+	return errs, nil
+}
+
+//valueText returns the text rules compare with: strings without padding, times in the format of the field and bytes and runes as characters
+func valueText(field reflect.Value, ffpTag *flatfileTag) string {
+	switch field.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(field.String())
+	case reflect.Struct:
+		return field.Interface().(time.Time).Format(ffpTag.format)
+	case reflect.Uint8:
+		if ffpTag.override == "byte" {
+			return string(rune(field.Uint()))
+		}
+	case reflect.Int32:
+		if ffpTag.override == "rune" {
+			return string(rune(field.Int()))
+		}
+	case reflect.Float32:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(field)
+}
+
+//compareBound returns -1, 0 or 1 when the field is below, equal to or above bound
+func compareBound(field reflect.Value, text, bound string, ffpTag *flatfileTag) (int, error) {
+	switch field.Kind() {
+	case reflect.String:
+		n, err := strconv.Atoi(bound)
+		return compare(utf8.RuneCountInString(text), n), err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(bound, 10, 64)
+		return compare(field.Int(), n), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(bound, 10, 64)
+		return compare(field.Uint(), n), err
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(bound, 64)
+		return compare(field.Float(), f), err
+	case reflect.Struct:
+		if field.Type() == timeType {
+			t, err := time.Parse(ffpTag.format, bound)
+			return field.Interface().(time.Time).Compare(t), err
+		}
+	}
+	return 0, errors.Errorf("flatfile.compareBound: Bounds are not supported for %s fields", field.Type())
+}
+
+//checkRules returns an error when the min or max option of a field cannot be compared with values of fieldType
+func checkRules(ffpTag *flatfileTag, fieldType reflect.Type) error {
+	for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Array || fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+	for _, bound := range []string{ffpTag.min, ffpTag.max} {
+		if bound == "" {
+			continue
+		}
+		if _, err := compareBound(reflect.Zero(fieldType), "", bound, ffpTag); err != nil {
+			return errors.Wrapf(err, "flatfile.checkRules: Invalid bound %s", bound)
+		}
+	}
+	return nil
+}
+
+func compare[T int | int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package flatfile
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type validatedAddress struct {
+	City    string `flatfile:"1,8,required"`
+	Country string `flatfile:"9,2,oneof=CA|US"`
+}
+
+type validatedRecord struct {
+	Name    string            `flatfile:"1,5,required,min=2,pattern=[A-Z]+"`
+	Age     uint8             `flatfile:"6,3,min=18,max=120"`
+	Balance float64           `flatfile:"9,6,min=-100.5"`
+	Grade   byte              `flatfile:"15,1,ovr=byte,oneof=A|B|C"`
+	Opened  time.Time         `flatfile:"16,8,fmt=20060102,min=20000101,max=20991231"`
+	Address *validatedAddress `flatfile:"24,10,required"`
+	Scores  [2]int            `flatfile:"34,2,max=10"`
+	Items   []validatedItem   `flatfile:"38,2,occurs=2"`
+	Comment string            `flatfile:"42,4,max=3"`
+}
+
+type validatedItem struct {
+	Code string `flatfile:"1,2,oneof=AA|BB"`
+}
+
+func TestValidate(t *testing.T) {
+	valid := validatedRecord{Name: "AMY", Age: 19, Balance: -100.5, Grade: 'A', Opened: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Address: &validatedAddress{City: "TORONTO", Country: "CA"}, Scores: [2]int{10, 2}, Items: []validatedItem{{"AA"}, {"BB"}}}
+
+	tests := []struct {
+		name   string
+		modify func(r *validatedRecord)
+		want   []string
+	}{
+		{"Valid", func(r *validatedRecord) {}, nil},
+		{"Blank optional fields", func(r *validatedRecord) { r.Opened = time.Time{}; r.Address.Country = "  "; r.Items = nil }, nil},
+		{"Required", func(r *validatedRecord) { r.Name = "   "; r.Address = nil }, []string{"Name required", "Address required"}},
+		{"Required nested", func(r *validatedRecord) { r.Address.City = "" }, []string{"Address.City required"}},
+		{"Min and max", func(r *validatedRecord) { r.Age = 17; r.Balance = -100.6; r.Comment = "LONG" }, []string{"Age min=18 17", "Balance min=-100.5 -100.6", "Comment max=3 LONG"}},
+		{"Max", func(r *validatedRecord) { r.Age = 121 }, []string{"Age max=120 121"}},
+		{"Min length and pattern", func(r *validatedRecord) { r.Name = "a" }, []string{"Name min=2 a", "Name pattern=[A-Z]+ a"}},
+		{"Oneof", func(r *validatedRecord) { r.Grade = 'D'; r.Address.Country = "MX" }, []string{"Grade oneof=A|B|C D", "Address.Country oneof=CA|US MX"}},
+		{"Date window", func(r *validatedRecord) { r.Opened = time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC) }, []string{"Opened min=20000101 19991231"}},
+		{"Elements", func(r *validatedRecord) { r.Scores[1] = 11; r.Items[1].Code = "CC" }, []string{"Scores[1] max=10 11", "Items[1].Code oneof=AA|BB CC"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := valid
+			address := *valid.Address
+			record.Address = &address
+			record.Items = append([]validatedItem(nil), valid.Items...)
+			tt.modify(&record)
+
+			err := Validate(&record)
+			if got := describeFieldErrors(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//describeFieldErrors returns each FieldError as "Field Rule Value", omitting the value of required
+func describeFieldErrors(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("error = %v, want FieldErrors", err)
+	}
+	var got []string
+	for _, fieldErr := range fieldErrs {
+		if fieldErr.Err != nil {
+			got = append(got, fieldErr.Field+" decode "+fieldErr.Value.(string))
+			continue
+		}
+		if fieldErr.Rule == "required" {
+			got = append(got, fieldErr.Field+" required")
+			continue
+		}
+		got = append(got, fieldErr.Field+" "+fieldErr.Rule+" "+fieldErr.Value.(string))
+	}
+	return got
+}
+
+func TestUnmarshalValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"Valid", "AMY  019-00100A20240131TORONTO CA0102AABB", nil},
+		{"Invalid", "a    017-00100D19991231        MX0112AACC", []string{
+			"Name min=2 a", "Name pattern=[A-Z]+ a", "Age min=18 17", "Grade oneof=A|B|C D", "Opened min=20000101 19991231",
+			"Address.City required", "Address.Country oneof=CA|US MX", "Scores[1] max=10 12", "Items[1].Code oneof=AA|BB CC"}},
+		{"Skipped fields", "AMY  019", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record validatedRecord
+			record.Address = &validatedAddress{}
+			err := Unmarshal([]byte(tt.data), &record, 0, 0, false)
+			if got := describeFieldErrors(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %v, want %v", got, tt.want)
+			}
+			if record.Name == "" {
+				t.Errorf("Unmarshal() did not decode the record")
+			}
+		})
+	}
+
+	reader := NewReader[validatedItem](strings.NewReader("AA\nCC\n"))
+	records, err := reader.ReadAll()
+	if len(records) != 1 || !reflect.DeepEqual(describeFieldErrors(t, err), []string{"Code oneof=AA|BB CC"}) || !strings.Contains(err.Error(), "record 2") {
+		t.Errorf("ReadAll() = %v, %v", records, err)
+	}
+}
+
+func TestValidationTagErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"Invalid int bound", &struct {
+			A int `flatfile:"1,1,min=x"`
+		}{}},
+		{"Invalid string bound", &struct {
+			A string `flatfile:"1,1,max=1.5"`
+		}{}},
+		{"Invalid time bound", &struct {
+			A time.Time `flatfile:"1,8,fmt=20060102,max=2024"`
+		}{}},
+		{"Bound on bool", &struct {
+			A bool `flatfile:"1,1,min=1"`
+		}{}},
+		{"Bound on struct", &struct {
+			A validatedItem `flatfile:"1,2,min=1"`
+		}{}},
+		{"Invalid pattern", &struct {
+			A string `flatfile:"1,1,pattern=[A-"`
+		}{}},
+		{"Empty oneof", &struct {
+			A string `flatfile:"1,1,oneof="`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.v); err == nil {
+				t.Errorf("Validate() expected error")
+			}
+			if err := Unmarshal([]byte("20240101"), tt.v, 0, 0, false); err == nil {
+				t.Errorf("Unmarshal() expected error")
+			}
+		})
+	}
+	if err := Validate(1); err == nil {
+		t.Errorf("Validate() expected error for a value which is not a struct")
+	}
+}
+
+func TestFieldErrorsError(t *testing.T) {
+	err := FieldErrors{{Field: "Name", Rule: "required"}, {Field: "Age", Rule: "min=18", Value: "17"}, {Field: "Count", Value: "1X", Err: strconv.ErrSyntax}}
+	want := `flatfile: Field Name is required; flatfile: Field Age value 17 does not satisfy min=18; flatfile: Field Count value "1X" failed to decode: invalid syntax`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err[2], strconv.ErrSyntax) {
+		t.Errorf("FieldError does not unwrap to its decode error")
+	}
+}